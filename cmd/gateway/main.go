@@ -2,90 +2,283 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"flag"
 	"fmt"
 	"log/slog"
+	"net/http"
 	"os"
 	"os/signal"
+	"sync/atomic"
 	"syscall"
-	"time"
 
+	goredis "github.com/redis/go-redis/v9"
 	"golang.org/x/sync/errgroup"
+	"gopkg.in/go-jose/go-jose.v2"
 
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/audit"
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/auth"
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/clients/auth0"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/clients/introspection"
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/clients/provider"
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/clients/redis"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/concurrency"
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/config"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/discovery"
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/exchange"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/geoip"
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/handlers/admin"
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/handlers/gateway"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/identity"
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/m2m"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/override"
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/processor"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/quota"
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/ratelimit"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/replay"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/revocation"
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/utils/server"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/utils/slice"
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/utils/store"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/utils/tlsutil"
 )
 
 var (
-	configPath = flag.String("config-path", "./config.json", "Path to config")
+	configPath  = flag.String("config-path", "./config.json", "Path to config, format is chosen by extension (.json, .yaml, .yml, .toml)")
+	printConfig = flag.Bool("print-config", false, "Print effective config with redacted secrets and exit")
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "validate" {
+		os.Exit(runValidate(os.Args[2:]))
+	}
+
 	flag.Parse()
 
 	slog.SetDefault(slog.New(slog.NewJSONHandler(os.Stdout, nil)))
 
+	if *printConfig {
+		cfg, err := config.FromFile(*configPath)
+		if err != nil {
+			slog.Error("failed to load config file", slog.String("err", err.Error()))
+			os.Exit(1)
+		}
+
+		if err = config.Print(os.Stdout, cfg, config.FormatFromPath(*configPath)); err != nil {
+			slog.Error("failed to print config", slog.String("err", err.Error()))
+			os.Exit(1)
+		}
+
+		return
+	}
+
 	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer cancel()
 
-	cfg, err := config.FromFile(*configPath)
+	cfg, err := config.Load(ctx, *configPath)
 	if err != nil {
 		slog.Error("failed to load config file", slog.String("err", err.Error()))
 		return
 	}
 
-	redisClient, err := redis.New(ctx, cfg.RedisAddress, cfg.RedisPassword)
+	var redisPassword atomic.Pointer[string]
+	redisPassword.Store(ptr(cfg.RedisPassword.Value()))
+
+	var redisClient *goredis.Client
+	if cfg.RedisRequired() {
+		redisClient, err = redis.New(ctx, cfg.RedisAddress, func() string {
+			return *redisPassword.Load()
+		})
+		if err != nil {
+			slog.Error("failed to create redis client", slog.String("err", err.Error()))
+			return
+		}
+	}
+
+	auth0Client, err := initAuth0Client(cfg)
 	if err != nil {
-		slog.Error("failed to create redis client", slog.String("err", err.Error()))
+		slog.Error("failed to create auth0 client", slog.String("err", err.Error()))
 		return
 	}
 
-	auth0Client := auth0.New(auth0.NewOptions{
-		Domain:       cfg.Auth0Domain,
-		ClientID:     cfg.Auth0ClientID,
-		ClientSecret: cfg.Auth0ClientSecret,
-	})
+	// reloaders apply config that is re-read on SIGHUP.
+	reloaders := []func(cfg *domain.Config) error{
+		func(cfg *domain.Config) error {
+			auth0Client.SetClientSecret(cfg.Auth0ClientSecret.Value())
+			redisPassword.Store(ptr(cfg.RedisPassword.Value()))
+
+			return nil
+		},
+	}
 
-	clientStore, err := initClientStore(ctx, auth0Client, cfg.Services)
+	var m2mSharedCache m2m.SharedCache
+	if cfg.M2MSharedCache {
+		m2mSharedCache = m2m.NewRedisCache(redisClient)
+	}
+
+	clientStore, err := initClientStore(ctx, auth0Client, m2mSharedCache, cfg.Services)
 	if err != nil {
 		slog.Error("failed to initialize client store", slog.String("err", err.Error()))
 		return
 	}
 
 	descriptionStore := store.New[string, *domain.ProviderDescription](nil)
-	descriptionStoreSync(ctx, cfg.DescriptionSyncPeriod, clientStore, descriptionStore)
+	discovery.New(discovery.Options{
+		Services:         cfg.Services,
+		ClientStore:      clientStore,
+		DescriptionStore: descriptionStore,
+		PollPeriod:       cfg.DescriptionSyncPeriod,
+		StaleTTL:         cfg.DescriptionStaleTTL,
+	}).Run(ctx)
+
+	var tokenParser auth.Parser
 
-	tokenParser, err := auth.New(cfg.Auth0Domain, cfg.Auth0Audience)
+	tokenParser, err = auth.New(cfg.Auth0Domain, cfg.Auth0Audience)
 	if err != nil {
 		slog.Error("failed to initialize token parser", slog.String("err", err.Error()))
 		return
 	}
 
-	processorSvc := processor.New(
-		descriptionStore,
-		clientStore,
-		tokenParser,
-		audit.NewLogAuditor(slog.With("kind", "auditor")),
-		ratelimit.NewRedis(redisClient),
-	)
+	if cfg.Introspection != nil {
+		introspectionClient := introspection.New(introspection.NewOptions{
+			Endpoint:     cfg.Introspection.Endpoint,
+			ClientID:     cfg.Introspection.ClientID,
+			ClientSecret: cfg.Introspection.ClientSecret.Value(),
+		})
+
+		reloaders = append(reloaders, func(cfg *domain.Config) error {
+			if cfg.Introspection != nil {
+				introspectionClient.SetClientSecret(cfg.Introspection.ClientSecret.Value())
+			}
+
+			return nil
+		})
+
+		introspector := auth.NewIntrospector(introspectionClient, auth.IntrospectorOptions{
+			Audience:  cfg.Auth0Audience,
+			ClientIDs: cfg.Introspection.AllowedClientIDs,
+			CacheTTL:  cfg.Introspection.CacheTTL,
+		})
+		tokenParser = auth.WithIntrospection(tokenParser, introspector)
+	}
+
+	var revocationList revocation.List
+	if cfg.RevocationEnabled {
+		revocationList = revocation.NewRedis(redisClient)
+		tokenParser = auth.WithRevocationCheck(tokenParser, revocationList)
+	}
+
+	processorOpts := processor.NewOptions{
+		DescriptionStore:   descriptionStore,
+		ClientStore:        clientStore,
+		ServiceConfigStore: initServiceConfigStore(cfg.Services),
+		TokenParser:        tokenParser,
+		DPoPValidator:      auth.NewDPoPValidator(newReplayCache(redisClient), cfg.DPoPProofLifetime),
+		CertificateMapper:  auth.NewCertificateMapper(slice.ConvertFunc(cfg.ClientCertificates, certificateRuleFromConfig)),
+		TokenExchanger:     exchange.New(auth0Client),
+		Auditor:            audit.NewLogAuditor(slog.With("kind", "auditor")),
+		RateLimiter:        newRateLimiter(ctx, cfg.RateLimit, redisClient),
+	}
+
+	adminOpts := admin.HandlerOptions{
+		RevocationList: revocationList,
+	}
+
+	quotaStore, hasQuotas, err := initQuotaStore(cfg.Services)
+	if err != nil {
+		slog.Error("failed to initialize quotas", slog.String("err", err.Error()))
+		return
+	}
+
+	if hasQuotas {
+		quotaCounter := quota.NewRedis(redisClient)
+
+		processorOpts.QuotaStore = quotaStore
+		processorOpts.QuotaCounter = quotaCounter
+		adminOpts.QuotaStore = quotaStore
+		adminOpts.QuotaUsage = quotaCounter
+	}
+
+	processorOpts.ConcurrencyStore = initConcurrencyStore(cfg.Services)
+
+	if cfg.GeoIPDatabase != "" {
+		geoIPReader, err := geoip.Open(cfg.GeoIPDatabase)
+		if err != nil {
+			slog.Error("failed to initialize GeoIP", slog.String("err", err.Error()))
+			return
+		}
+
+		defer geoIPReader.Close() //nolint:errcheck
+
+		processorOpts.GeoIP = geoIPReader
+	}
+
+	if err = initAccess(cfg, &processorOpts); err != nil {
+		slog.Error("failed to initialize access rules", slog.String("err", err.Error()))
+		return
+	}
+
+	if cfg.Priority != nil {
+		if processorOpts.PriorityRules, err = cfg.Priority.ToPriorityRules(); err != nil {
+			slog.Error("failed to initialize priority rules", slog.String("err", err.Error()))
+			return
+		}
+	}
+
+	if cfg.RateLimit.Overrides {
+		overrideTable := override.NewRedis(ctx, redisClient, cfg.RateLimit.OverrideRefreshPeriod)
+
+		processorOpts.OverrideTable = overrideTable
+		processorOpts.OverrideOrgClaim = cfg.RateLimit.OverrideOrgClaim
+		adminOpts.OverrideTable = overrideTable
+	}
+
+	if cfg.IdentityToken != nil {
+		identitySigner, err := initIdentitySigner(cfg.IdentityToken)
+		if err != nil {
+			slog.Error("failed to initialize identity token signer", slog.String("err", err.Error()))
+			return
+		}
+
+		processorOpts.IdentitySigner = identitySigner
+		adminOpts.JWKSProvider = identitySigner
+
+		reloaders = append(reloaders, func(cfg *domain.Config) error {
+			return reloadIdentityKeys(identitySigner, cfg)
+		})
+	}
 
-	processorSvc = processor.WithMetricsMiddleware(processorSvc)
+	reloadOnSignal(ctx, func() error {
+		cfg, err := config.Load(ctx, *configPath)
+		if err != nil {
+			return fmt.Errorf("failed to load config file: %w", err)
+		}
+
+		for _, reload := range reloaders {
+			if err = reload(cfg); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+
+	processorSvc := processor.WithMetricsMiddleware(processor.New(processorOpts))
+
+	publicServer := server.New(cfg.PublicListenAddress, gateway.Handler(processorSvc, processorOpts.TrustedProxies), slog.With("kind", "public"))
+	if cfg.PublicTLS != nil {
+		tlsConfig, err := publicTLSConfig(cfg.PublicTLS)
+		if err != nil {
+			slog.Error("failed to initialize public TLS", slog.String("err", err.Error()))
+			return
+		}
+
+		publicServer = publicServer.WithTLS(tlsConfig)
+	}
 
-	publicServer := server.New(cfg.PublicListenAddress, gateway.Handler(processorSvc), slog.With("kind", "public"))
-	adminServer := server.New(cfg.AdminListenAddress, admin.Handler(), slog.With("kind", "admin"))
+	adminServer := server.New(cfg.AdminListenAddress, admin.Handler(adminOpts), slog.With("kind", "admin"))
 
 	eg, eCtx := errgroup.WithContext(ctx)
 	eg.Go(func() error {
@@ -100,13 +293,17 @@ func main() {
 	}
 }
 
-func initClientStore(ctx context.Context, auth0Client *auth0.Client, services []*domain.ConfigService) (*store.Store[string, provider.Client], error) {
+func initClientStore(ctx context.Context, auth0Client *auth0.Client, m2mSharedCache m2m.SharedCache, services []*domain.ConfigService) (*store.Store[string, provider.Client], error) {
 	clients := make(map[string]provider.Client)
 
 	for _, service := range services {
 		var m2mTokenSource m2m.Source
 		if service.M2MAudience != "" {
-			src, err := m2m.Create(ctx, auth0Client, service.M2MAudience)
+			src, err := m2m.Create(ctx, m2m.CreateOptions{
+				TokenIssuer: auth0Client,
+				Audience:    service.M2MAudience,
+				SharedCache: m2mSharedCache,
+			})
 			if err != nil {
 				return nil, fmt.Errorf("failed to create m2m token source for provider %s: %w", service.Name, err)
 			}
@@ -114,57 +311,338 @@ func initClientStore(ctx context.Context, auth0Client *auth0.Client, services []
 			m2mTokenSource = src
 		}
 
-		providerClient, err := provider.New(provider.NewOptions{
+		providerClient, err := newProviderClient(service, m2mTokenSource)
+		if err != nil {
+			return nil, fmt.Errorf("could not create client to provider %s: %w", service.Name, err)
+		}
+
+		clients[service.Name] = providerClient
+	}
+
+	return store.New[string, provider.Client](clients), nil
+}
+
+func newProviderClient(service *domain.ConfigService, m2mTokenSource m2m.Source) (provider.Client, error) {
+	switch service.Kind {
+	case domain.ServiceKindHttp.String():
+		return newHTTPProviderClient(service, m2mTokenSource)
+	case domain.ServiceKindGrpcTranscoding.String():
+		return provider.NewTranscoding(provider.TranscodingOptions{
 			Name:             service.Name,
 			Address:          service.Address,
+			Service:          service.Transcoding.Service,
+			DescriptorSet:    service.Transcoding.DescriptorSet,
 			M2MTokenSource:   m2mTokenSource,
 			OperationTimeout: service.OperationTimeout,
 		})
+	default:
+		return provider.New(provider.NewOptions{
+			Name:             service.Name,
+			Address:          service.Address,
+			M2MTokenSource:   m2mTokenSource,
+			OperationTimeout: service.OperationTimeout,
+		})
+	}
+}
+
+func newHTTPProviderClient(service *domain.ConfigService, m2mTokenSource m2m.Source) (provider.Client, error) {
+	opts := provider.HTTPOptions{
+		BaseURL:          service.Address,
+		M2MTokenSource:   m2mTokenSource,
+		OperationTimeout: service.OperationTimeout,
+	}
+
+	if service.HTTP != nil {
+		opts.PathRewrite = service.HTTP.PathRewrite
+		opts.HostHeader = service.HTTP.HostHeader
+		opts.MaxIdleConns = service.HTTP.MaxIdleConns
+		opts.MaxIdleConnsPerHost = service.HTTP.MaxIdleConnsPerHost
+		opts.IdleConnTimeout = service.HTTP.IdleConnTimeout
+	}
+
+	return provider.NewHTTP(opts)
+}
+
+func newRateLimiter(ctx context.Context, cfg *domain.ConfigRateLimit, redisClient *goredis.Client) ratelimit.Limiter {
+	var limiter ratelimit.Limiter
+
+	switch cfg.Backend {
+	case domain.RateLimitBackendMemory.String():
+		limiter = ratelimit.NewMemory()
+	case domain.RateLimitBackendHybrid.String():
+		limiter = ratelimit.NewHybrid(ctx, ratelimit.HybridOptions{
+			Client:     redisClient,
+			SyncPeriod: cfg.SyncPeriod,
+		})
+	default:
+		limiter = ratelimit.NewRedis(redisClient)
+	}
+
+	if cfg.FailOpen {
+		limiter = ratelimit.WithFailOpen(limiter)
+	}
+
+	return limiter
+}
+
+// newReplayCache returns cache in memory when gateway runs without Redis.
+func newReplayCache(redisClient *goredis.Client) replay.Cache {
+	if redisClient == nil {
+		return replay.NewMemory()
+	}
+
+	return replay.NewRedis(redisClient)
+}
+
+func initAuth0Client(cfg *domain.Config) (*auth0.Client, error) {
+	authMethod, err := domain.ParseClientAuthMethod(cfg.Auth0ClientAuthMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := auth0.NewOptions{
+		Domain:       cfg.Auth0Domain,
+		ClientID:     cfg.Auth0ClientID,
+		ClientSecret: cfg.Auth0ClientSecret.Value(),
+		AuthMethod:   authMethod,
+	}
+
+	switch authMethod {
+	case domain.ClientAuthMethodPrivateKeyJwt:
+		key, err := identity.LoadKey(cfg.Auth0ClientKey.ID, cfg.Auth0ClientKey.PrivateKeyFile)
 		if err != nil {
-			return nil, fmt.Errorf("could not create client to provider %s: %w", service.Name, err)
+			return nil, fmt.Errorf("failed to load client key: %w", err)
 		}
 
-		clients[service.Name] = providerClient
+		algorithm, err := key.Algorithm()
+		if err != nil {
+			return nil, err
+		}
+
+		opts.AssertionKey = &jose.JSONWebKey{
+			Key:       key.PrivateKey,
+			KeyID:     key.ID,
+			Algorithm: string(algorithm),
+		}
+	case domain.ClientAuthMethodTlsClientAuth:
+		tlsConfig, err := tlsutil.ClientConfig(tlsutil.ClientConfigOptions{
+			CertFile: cfg.Auth0ClientTLS.CertFile,
+			KeyFile:  cfg.Auth0ClientTLS.KeyFile,
+			CAFiles:  cfg.Auth0ClientTLS.CAFiles,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+		transport.TLSClientConfig = tlsConfig
+
+		opts.HTTPClient = &http.Client{Transport: transport}
+
+		if cfg.Auth0ClientTLS.Domain != "" {
+			opts.Domain = cfg.Auth0ClientTLS.Domain
+		}
 	}
 
-	return store.New[string, provider.Client](clients), nil
+	return auth0.New(opts)
 }
 
-func descriptionStoreSync(ctx context.Context, period time.Duration, clientStore *store.Store[string, provider.Client], descriptionStore *store.Store[string, *domain.ProviderDescription]) {
-	syncFunc := func() error {
-		var syncErr error
+func loadIdentityKeys(cfg *domain.ConfigIdentityToken) ([]identity.Key, error) {
+	keys := make([]identity.Key, 0, len(cfg.Keys))
 
-		for name, client := range clientStore.Data() {
-			description, err := client.Description(ctx)
-			if err != nil {
-				// we try to sync all descriptions, but skip errors.
-				syncErr = errors.Join(syncErr, fmt.Errorf("could not get description for %s: %w", name, err))
-				continue
-			}
-
-			descriptionStore.Set(name, description)
+	for _, keyCfg := range cfg.Keys {
+		key, err := identity.LoadKey(keyCfg.ID, keyCfg.PrivateKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load key %s: %w", keyCfg.ID, err)
 		}
 
-		return syncErr
+		keys = append(keys, key)
 	}
 
-	if err := syncFunc(); err != nil {
-		slog.Error("failed to sync some description store entities", slog.String("err", err.Error()))
+	return keys, nil
+}
+
+func initIdentitySigner(cfg *domain.ConfigIdentityToken) (*identity.Signer, error) {
+	keys, err := loadIdentityKeys(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return identity.NewSigner(cfg.Issuer, cfg.TTL, keys, cfg.ActiveKeyID)
+}
+
+// reloadIdentityKeys re-reads keys from files, so keys can be rotated without restart.
+func reloadIdentityKeys(signer *identity.Signer, cfg *domain.Config) error {
+	if cfg.IdentityToken == nil {
+		return errors.New("identity token config was removed, restart is required")
 	}
 
+	keys, err := loadIdentityKeys(cfg.IdentityToken)
+	if err != nil {
+		return err
+	}
+
+	return signer.SetKeys(keys, cfg.IdentityToken.ActiveKeyID)
+}
+
+// reloadOnSignal calls reload on every SIGHUP until context is done.
+func reloadOnSignal(ctx context.Context, reload func() error) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+
 	go func() {
-		ticker := time.NewTicker(period)
-		defer ticker.Stop()
+		defer signal.Stop(signals)
 
 		for {
 			select {
-			case <-ticker.C:
-				if err := syncFunc(); err != nil {
-					slog.Error("failed to sync some description store entities", slog.String("err", err.Error()))
+			case <-signals:
+				if err := reload(); err != nil {
+					slog.Error("failed to reload", slog.String("err", err.Error()))
+					continue
 				}
+
+				slog.Info("reloaded")
 			case <-ctx.Done():
 				return
 			}
 		}
 	}()
 }
+
+func publicTLSConfig(cfg *domain.ConfigTLS) (*tls.Config, error) {
+	clientAuth, err := domain.ParseTLSClientAuth(cfg.ClientAuth)
+	if err != nil {
+		return nil, err
+	}
+
+	var tlsClientAuth tls.ClientAuthType
+
+	switch clientAuth {
+	case domain.TLSClientAuthNone:
+		tlsClientAuth = tls.NoClientCert
+	case domain.TLSClientAuthRequest:
+		tlsClientAuth = tls.RequestClientCert
+	case domain.TLSClientAuthVerifyIfGiven:
+		tlsClientAuth = tls.VerifyClientCertIfGiven
+	case domain.TLSClientAuthRequire:
+		tlsClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return tlsutil.ServerConfig(tlsutil.ServerConfigOptions{
+		CertFile:      cfg.CertFile,
+		KeyFile:       cfg.KeyFile,
+		ClientCAFiles: cfg.ClientCAFiles,
+		ClientAuth:    tlsClientAuth,
+	})
+}
+
+func certificateRuleFromConfig(cc *domain.ConfigClientCertificate) auth.CertificateRule {
+	return auth.CertificateRule{
+		SubjectDN:   cc.SubjectDN,
+		DNSName:     cc.DNSName,
+		URI:         cc.URI,
+		Email:       cc.Email,
+		Fingerprint: cc.Fingerprint,
+		SubjectID:   cc.SubjectID,
+		Permissions: cc.Permissions,
+	}
+}
+
+func initServiceConfigStore(services []*domain.ConfigService) *store.Store[string, *domain.ConfigService] {
+	configs := make(map[string]*domain.ConfigService, len(services))
+	for _, service := range services {
+		configs[service.Name] = service
+	}
+
+	return store.New[string, *domain.ConfigService](configs)
+}
+
+// initQuotaStore returns quotas of services and whether any service has quotas.
+func initQuotaStore(services []*domain.ConfigService) (*store.Store[string, []*domain.Quota], bool, error) {
+	quotas := make(map[string][]*domain.Quota, len(services))
+	hasQuotas := false
+
+	for _, service := range services {
+		serviceQuotas, err := domain.ToQuotas(service.Quotas)
+		if err != nil {
+			return nil, false, fmt.Errorf("quotas of service %s are invalid: %w", service.Name, err)
+		}
+
+		quotas[service.Name] = serviceQuotas
+		hasQuotas = hasQuotas || len(serviceQuotas) != 0
+	}
+
+	return store.New[string, []*domain.Quota](quotas), hasQuotas, nil
+}
+
+func initAccess(cfg *domain.Config, opts *processor.NewOptions) error {
+	var err error
+
+	if opts.TrustedProxies, err = domain.ParsePrefixes(cfg.TrustedProxies); err != nil {
+		return fmt.Errorf("trusted proxies are invalid: %w", err)
+	}
+
+	if cfg.Access != nil {
+		if opts.AccessRules, err = cfg.Access.ToAccessRules(); err != nil {
+			return fmt.Errorf("access is invalid: %w", err)
+		}
+	}
+
+	rules := make(map[string]*domain.ServiceAccessRules, len(cfg.Services))
+
+	for _, service := range cfg.Services {
+		serviceRules, err := service.ToAccessRules()
+		if err != nil {
+			return fmt.Errorf("access of service %s is invalid: %w", service.Name, err)
+		}
+
+		if serviceRules != nil {
+			rules[service.Name] = serviceRules
+		}
+	}
+
+	opts.AccessStore = store.New[string, *domain.ServiceAccessRules](rules)
+
+	return nil
+}
+
+func initConcurrencyStore(services []*domain.ConfigService) *store.Store[string, *concurrency.Group] {
+	groups := make(map[string]*concurrency.Group, len(services))
+
+	for _, service := range services {
+		if service.Concurrency == nil && len(service.MethodConcurrency) == 0 {
+			continue
+		}
+
+		var serviceLimiter *concurrency.Limiter
+		if service.Concurrency != nil {
+			serviceLimiter = newConcurrencyLimiter(service.Name, "", service.Concurrency)
+		}
+
+		methodLimiters := make(map[string]*concurrency.Limiter, len(service.MethodConcurrency))
+		for method, cfg := range service.MethodConcurrency {
+			methodLimiters[method] = newConcurrencyLimiter(service.Name, method, cfg)
+		}
+
+		groups[service.Name] = concurrency.NewGroup(serviceLimiter, methodLimiters)
+	}
+
+	return store.New[string, *concurrency.Group](groups)
+}
+
+func newConcurrencyLimiter(service, method string, cfg *domain.ConfigConcurrency) *concurrency.Limiter {
+	return concurrency.New(concurrency.Options{
+		Service:          service,
+		Method:           method,
+		MaxInFlight:      cfg.MaxInFlight,
+		MaxQueue:         cfg.MaxQueue,
+		QueueTimeout:     cfg.QueueTimeout,
+		Adaptive:         cfg.Adaptive,
+		MinInFlight:      cfg.MinInFlight,
+		LatencyTolerance: cfg.LatencyTolerance,
+	})
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
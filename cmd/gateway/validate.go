@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/config"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/lint"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/m2m"
+)
+
+// runValidate runs validate subcommand and returns exit code.
+func runValidate(args []string) int {
+	flags := flag.NewFlagSet("validate", flag.ExitOnError)
+	path := flags.String("config-path", "./config.json", "Path to config")
+	format := flags.String("format", "text", "Output format: text or json")
+	checkProviders := flags.Bool("check-providers", false, "Fetch descriptions of providers and check M2M audiences")
+	timeout := flags.Duration("timeout", 30*time.Second, "Timeout of provider checks")
+	_ = flags.Parse(args) //nolint:errcheck
+
+	write := lint.WriteText
+	switch *format {
+	case "text":
+	case "json":
+		write = lint.WriteJSON
+	default:
+		fmt.Fprintf(os.Stderr, "unknown format %s\n", *format)
+		return 2
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), *timeout)
+	defer cancel()
+
+	report, err := validate(ctx, *path, *checkProviders)
+	if err != nil {
+		report = &lint.Report{Findings: []lint.Finding{{Severity: lint.SeverityError, Message: err.Error()}}}
+	}
+
+	if err = write(os.Stdout, report); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write report: %s\n", err)
+		return 2
+	}
+
+	if report.HasErrors() {
+		return 1
+	}
+
+	return 0
+}
+
+func validate(ctx context.Context, path string, checkProviders bool) (*lint.Report, error) {
+	cfg, err := config.Load(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := lint.Options{
+		Config: cfg,
+		FetchDescription: func(_ context.Context, service *domain.ConfigService) (*domain.ProviderDescription, error) {
+			if !service.NeedFetchDescription() {
+				return service.EffectiveDescription(nil)
+			}
+
+			return nil, nil
+		},
+	}
+
+	if checkProviders {
+		auth0Client, err := initAuth0Client(cfg)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create auth0 client: %w", err)
+		}
+
+		opts.CheckAudience = func(ctx context.Context, audience string) error {
+			_, err := auth0Client.Token(ctx, audience)
+			return err
+		}
+
+		opts.FetchDescription = func(ctx context.Context, service *domain.ConfigService) (*domain.ProviderDescription, error) {
+			if !service.NeedFetchDescription() {
+				return service.EffectiveDescription(nil)
+			}
+
+			// M2M source is not used, so unreachable audience is reported once instead of failing all checks.
+			var m2mTokenSource m2m.Source
+			if service.M2MAudience != "" {
+				token, err := auth0Client.Token(ctx, service.M2MAudience)
+				if err != nil {
+					return nil, fmt.Errorf("failed to obtain M2M token: %w", err)
+				}
+
+				m2mTokenSource = staticTokenSource(token.AccessToken)
+			}
+
+			providerClient, err := newProviderClient(service, m2mTokenSource)
+			if err != nil {
+				return nil, err
+			}
+
+			fetched, err := providerClient.Description(ctx)
+			if err != nil {
+				return nil, err
+			}
+
+			return service.EffectiveDescription(fetched)
+		}
+	}
+
+	return lint.Run(ctx, opts), nil
+}
+
+type staticTokenSource string
+
+func (s staticTokenSource) Token() string {
+	return string(s)
+}
+
+func (s staticTokenSource) Refresh(context.Context) error {
+	return nil
+}
@@ -13,6 +13,9 @@ type Fields struct {
 	Method  string
 	Subject *domain.SubjectInformation
 	Result  Result
+	// ClientIP and Country are set for requests denied by access rules.
+	ClientIP string
+	Country  string
 }
 
 // Auditor is a audit message writer.
@@ -32,12 +35,21 @@ func (l logAuditor) Write(ctx context.Context, fields Fields) {
 		slog.String("result", fields.Result.String()),
 	}
 
+	if fields.ClientIP != "" {
+		attrs = append(attrs, slog.String("client_ip", fields.ClientIP))
+	}
+
+	if fields.Country != "" {
+		attrs = append(attrs, slog.String("country", fields.Country))
+	}
+
 	if fields.Subject != nil {
 		attrs = append(
 			attrs,
 			slog.Group(
 				"subject",
 				slog.String("id", fields.Subject.ID),
+				slog.Bool("anonymous", fields.Subject.Anonymous),
 				slog.String("permissions", fields.Subject.Permissions.String()),
 			),
 		)
@@ -3,5 +3,5 @@ package audit
 //go:generate go run github.com/abice/go-enum
 
 // Result ...
-// ENUM(unspecified, ok, error)
+// ENUM(unspecified, ok, error, denied)
 type Result uint8
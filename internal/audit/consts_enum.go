@@ -18,16 +18,19 @@ const (
 	ResultOk
 	// ResultError is a Result of type Error.
 	ResultError
+	// ResultDenied is a Result of type Denied.
+	ResultDenied
 )
 
 var ErrInvalidResult = errors.New("not a valid Result")
 
-const _ResultName = "unspecifiedokerror"
+const _ResultName = "unspecifiedokerrordenied"
 
 var _ResultMap = map[Result]string{
 	ResultUnspecified: _ResultName[0:11],
 	ResultOk:          _ResultName[11:13],
 	ResultError:       _ResultName[13:18],
+	ResultDenied:      _ResultName[18:24],
 }
 
 // String implements the Stringer interface.
@@ -49,6 +52,7 @@ var _ResultValue = map[string]Result{
 	_ResultName[0:11]:  ResultUnspecified,
 	_ResultName[11:13]: ResultOk,
 	_ResultName[13:18]: ResultError,
+	_ResultName[18:24]: ResultDenied,
 }
 
 // ParseResult attempts to convert a string to a Result.
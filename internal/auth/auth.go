@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
@@ -15,13 +16,47 @@ import (
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
 )
 
+// Parser of access tokens.
+type Parser interface {
+	ParseToken(ctx context.Context, token string) (*domain.SubjectInformation, error)
+}
+
 // Auth ...
 type Auth struct {
 	validator *validator.Validator
 }
 
 type customClaims struct {
-	Permissions []string `json:"permissions"`
+	Permissions  []string       `json:"permissions"`
+	Confirmation *confirmation  `json:"cnf,omitempty"`
+	Claims       map[string]any `json:"-"`
+}
+
+// UnmarshalJSON keeps all claims of token along with known ones.
+func (c *customClaims) UnmarshalJSON(data []byte) error {
+	type plainClaims customClaims
+
+	if err := json.Unmarshal(data, (*plainClaims)(c)); err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, &c.Claims)
+}
+
+type confirmation struct {
+	JWKThumbprint         string `json:"jkt"`
+	CertificateThumbprint string `json:"x5t#S256"`
+}
+
+func (c *confirmation) toDomain() domain.TokenConfirmation {
+	if c == nil {
+		return domain.TokenConfirmation{}
+	}
+
+	return domain.TokenConfirmation{
+		JWKThumbprint:         c.JWKThumbprint,
+		CertificateThumbprint: c.CertificateThumbprint,
+	}
 }
 
 // Validate is just a func to make compatibility with validator.CustomClaims.
@@ -85,15 +120,25 @@ func (a *Auth) ParseToken(ctx context.Context, token string) (*domain.SubjectInf
 }
 
 func claimsToSubjectInformationAdapter(claims *validator.ValidatedClaims) (*domain.SubjectInformation, error) {
-	var permissions mapset.Set[string]
+	permissions := mapset.NewThreadUnsafeSet[string]()
+	var (
+		tokenConfirmation domain.TokenConfirmation
+		rawClaims         map[string]any
+	)
+
 	cClaims, ok := claims.CustomClaims.(*customClaims)
 	if ok {
 		permissions = cClaims.UniquePermissions()
+		tokenConfirmation = cClaims.Confirmation.toDomain()
+		rawClaims = cClaims.Claims
 	}
 
 	subjectInfo := &domain.SubjectInformation{
-		ID:          claims.RegisteredClaims.Subject,
-		Permissions: permissions,
+		ID:           claims.RegisteredClaims.Subject,
+		TokenID:      claims.RegisteredClaims.ID,
+		Permissions:  permissions,
+		Confirmation: tokenConfirmation,
+		Claims:       rawClaims,
 	}
 
 	if err := subjectInfo.Validate(); err != nil {
@@ -0,0 +1,146 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strings"
+
+	mapset "github.com/deckarep/golang-set/v2"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+const certificateSubjectPrefix = "cert:"
+
+// CertificateRule maps client certificate to subject. All non-empty fields must match.
+type CertificateRule struct {
+	SubjectDN   string
+	DNSName     string
+	URI         string
+	Email       string
+	Fingerprint string
+	SubjectID   string
+	Permissions []string
+}
+
+func (r CertificateRule) match(cert *x509.Certificate, fingerprint string) bool {
+	if r.SubjectDN != "" && r.SubjectDN != cert.Subject.String() {
+		return false
+	}
+
+	if r.DNSName != "" && !containsFold(cert.DNSNames, r.DNSName) {
+		return false
+	}
+
+	if r.Email != "" && !containsFold(cert.EmailAddresses, r.Email) {
+		return false
+	}
+
+	if r.URI != "" {
+		found := false
+		for _, uri := range cert.URIs {
+			if uri.String() == r.URI {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	if r.Fingerprint != "" && !strings.EqualFold(normalizeFingerprint(r.Fingerprint), fingerprint) {
+		return false
+	}
+
+	return true
+}
+
+// CertificateMapper maps verified client certificates to subjects.
+type CertificateMapper struct {
+	rules []CertificateRule
+}
+
+// NewCertificateMapper returns new CertificateMapper, first matched rule wins.
+func NewCertificateMapper(rules []CertificateRule) *CertificateMapper {
+	return &CertificateMapper{
+		rules: rules,
+	}
+}
+
+var (
+	errCertificateNotMapped = errors.New("client certificate is not mapped to subject")
+)
+
+// Map certificate to subject.
+func (m *CertificateMapper) Map(cert *x509.Certificate) (*domain.SubjectInformation, error) {
+	fingerprint := CertificateFingerprint(cert)
+
+	for _, rule := range m.rules {
+		if !rule.match(cert, fingerprint) {
+			continue
+		}
+
+		subjectID := rule.SubjectID
+		if subjectID == "" {
+			subjectID = certificateSubjectPrefix + fingerprint
+		}
+
+		return &domain.SubjectInformation{
+			ID:          subjectID,
+			Permissions: mapset.NewThreadUnsafeSet(rule.Permissions...),
+		}, nil
+	}
+
+	return nil, errCertificateNotMapped
+}
+
+// CertificateFingerprint returns hex encoded SHA-256 fingerprint of certificate.
+func CertificateFingerprint(cert *x509.Certificate) string {
+	sum := sha256.Sum256(cert.Raw)
+	return hex.EncodeToString(sum[:])
+}
+
+var (
+	errCertificateRequired = errors.New("access token is bound to client certificate, but certificate is missing")
+	errCertificateMismatch = errors.New("client certificate doesn't match access token binding")
+)
+
+// ValidateCertificateBinding checks x5t#S256 confirmation of access token (RFC 8705).
+func ValidateCertificateBinding(cert *x509.Certificate, confirmation domain.TokenConfirmation) error {
+	if confirmation.CertificateThumbprint == "" {
+		return nil
+	}
+
+	if cert == nil {
+		return errCertificateRequired
+	}
+
+	sum := sha256.Sum256(cert.Raw)
+	thumbprint := base64.RawURLEncoding.EncodeToString(sum[:])
+
+	if subtle.ConstantTimeCompare([]byte(thumbprint), []byte(confirmation.CertificateThumbprint)) != 1 {
+		return errCertificateMismatch
+	}
+
+	return nil
+}
+
+func normalizeFingerprint(fingerprint string) string {
+	return strings.ReplaceAll(fingerprint, ":", "")
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
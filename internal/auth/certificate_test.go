@@ -0,0 +1,116 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+func newTestCertificate(t *testing.T, template *x509.Certificate) *x509.Certificate {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template.SerialNumber = big.NewInt(1)
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert
+}
+
+func TestCertificateMapper(t *testing.T) {
+	t.Parallel()
+
+	cert := newTestCertificate(t, &x509.Certificate{
+		Subject:        pkix.Name{CommonName: "billing", Organization: []string{"Acme"}},
+		DNSNames:       []string{"billing.internal"},
+		EmailAddresses: []string{"billing@acme.example"},
+		URIs:           []*url.URL{{Scheme: "spiffe", Host: "acme.example", Path: "/billing"}},
+	})
+
+	fingerprint := CertificateFingerprint(cert)
+
+	// fingerprints are often copied from openssl output, e.g. AB:CD:...
+	pairs := make([]string, 0, len(fingerprint)/2)
+	for i := 0; i < len(fingerprint); i += 2 {
+		pairs = append(pairs, strings.ToUpper(fingerprint[i:i+2]))
+	}
+
+	tests := []struct {
+		name    string
+		rule    CertificateRule
+		matched bool
+	}{
+		{name: "subject DN", rule: CertificateRule{SubjectDN: "CN=billing,O=Acme"}, matched: true},
+		{name: "other subject DN", rule: CertificateRule{SubjectDN: "CN=orders,O=Acme"}},
+		{name: "DNS name ignores case", rule: CertificateRule{DNSName: "Billing.Internal"}, matched: true},
+		{name: "email", rule: CertificateRule{Email: "billing@acme.example"}, matched: true},
+		{name: "URI", rule: CertificateRule{URI: "spiffe://acme.example/billing"}, matched: true},
+		{name: "other URI", rule: CertificateRule{URI: "spiffe://acme.example/orders"}},
+		{name: "fingerprint with colons", rule: CertificateRule{Fingerprint: strings.Join(pairs, ":")}, matched: true},
+		{name: "all fields must match", rule: CertificateRule{DNSName: "billing.internal", Email: "orders@acme.example"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.rule.Permissions = []string{"read:invoices"}
+
+			subject, err := NewCertificateMapper([]CertificateRule{tt.rule}).Map(cert)
+			if !tt.matched {
+				require.ErrorIs(t, err, errCertificateNotMapped)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, certificateSubjectPrefix+fingerprint, subject.ID)
+			assert.True(t, subject.Permissions.Contains("read:invoices"))
+		})
+	}
+
+	// first matched rule wins.
+	subject, err := NewCertificateMapper([]CertificateRule{
+		{DNSName: "orders.internal", SubjectID: "orders"},
+		{DNSName: "billing.internal", SubjectID: "billing"},
+		{SubjectID: "fallback"},
+	}).Map(cert)
+	require.NoError(t, err)
+	assert.Equal(t, "billing", subject.ID)
+}
+
+func TestValidateCertificateBinding(t *testing.T) {
+	t.Parallel()
+
+	cert := newTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "client"}})
+	other := newTestCertificate(t, &x509.Certificate{Subject: pkix.Name{CommonName: "other"}})
+
+	sum := sha256.Sum256(cert.Raw)
+	bound := domain.TokenConfirmation{CertificateThumbprint: base64.RawURLEncoding.EncodeToString(sum[:])}
+
+	require.NoError(t, ValidateCertificateBinding(nil, domain.TokenConfirmation{}), "unbound token needs no certificate")
+	require.NoError(t, ValidateCertificateBinding(cert, bound))
+	require.ErrorIs(t, ValidateCertificateBinding(nil, bound), errCertificateRequired)
+	require.ErrorIs(t, ValidateCertificateBinding(other, bound), errCertificateMismatch)
+}
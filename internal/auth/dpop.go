@@ -0,0 +1,227 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"gopkg.in/go-jose/go-jose.v2"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+const (
+	dpopProofType = "dpop+jwt"
+
+	defaultDPoPProofLifetime = time.Minute
+	dpopClockSkew            = 5 * time.Second
+)
+
+// DPoPSigningAlgorithms is a list of algorithms accepted in DPoP proofs.
+var DPoPSigningAlgorithms = []string{
+	string(jose.ES256), string(jose.ES384), string(jose.ES512),
+	string(jose.RS256), string(jose.RS384), string(jose.RS512),
+	string(jose.PS256), string(jose.PS384), string(jose.PS512),
+	string(jose.EdDSA),
+}
+
+type replayCache interface {
+	// Remember returns false if key was already remembered.
+	Remember(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+// DPoPValidator validates DPoP proofs (RFC 9449).
+type DPoPValidator struct {
+	replayCache   replayCache
+	proofLifetime time.Duration
+	now           func() time.Time
+}
+
+// NewDPoPValidator returns new DPoPValidator, proofs older than proofLifetime are rejected.
+func NewDPoPValidator(replayCache replayCache, proofLifetime time.Duration) *DPoPValidator {
+	if proofLifetime <= 0 {
+		proofLifetime = defaultDPoPProofLifetime
+	}
+
+	return &DPoPValidator{
+		replayCache:   replayCache,
+		proofLifetime: proofLifetime,
+		now:           time.Now,
+	}
+}
+
+// DPoPRequest is a request that proof must be bound to.
+type DPoPRequest struct {
+	Proof        string
+	HTTPMethod   string
+	URL          string
+	AccessToken  string
+	Confirmation domain.TokenConfirmation
+}
+
+type dpopClaims struct {
+	ID              string `json:"jti"`
+	HTTPMethod      string `json:"htm"`
+	HTTPURI         string `json:"htu"`
+	IssuedAt        int64  `json:"iat"`
+	AccessTokenHash string `json:"ath"`
+}
+
+var (
+	errDPoPInvalidProof    = errors.New("invalid DPoP proof")
+	errDPoPUnbound         = errors.New("access token is not bound to DPoP key")
+	errDPoPKeyMismatch     = errors.New("DPoP proof key doesn't match access token binding")
+	errDPoPMethodMismatch  = errors.New("DPoP proof htm doesn't match request method")
+	errDPoPURIMismatch     = errors.New("DPoP proof htu doesn't match request URI")
+	errDPoPTokenMismatch   = errors.New("DPoP proof ath doesn't match access token")
+	errDPoPProofExpired    = errors.New("DPoP proof is expired or issued in the future")
+	errDPoPProofReplayed   = errors.New("DPoP proof was already used")
+	errDPoPUnsupportedAlgo = errors.New("unsupported DPoP proof algorithm")
+)
+
+// Validate DPoP proof against request and access token.
+func (v *DPoPValidator) Validate(ctx context.Context, req DPoPRequest) error {
+	jws, err := jose.ParseSigned(req.Proof)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errDPoPInvalidProof, err)
+	}
+
+	if len(jws.Signatures) != 1 {
+		return errDPoPInvalidProof
+	}
+
+	header := jws.Signatures[0].Protected
+	if typ, _ := header.ExtraHeaders[jose.HeaderType].(string); typ != dpopProofType { //nolint:errcheck
+		return fmt.Errorf("%w: unexpected typ %q", errDPoPInvalidProof, typ)
+	}
+
+	if !isDPoPAlgorithmSupported(header.Algorithm) {
+		return errDPoPUnsupportedAlgo
+	}
+
+	jwk := header.JSONWebKey
+	if jwk == nil || !jwk.Valid() || !jwk.IsPublic() {
+		return fmt.Errorf("%w: missing or invalid public jwk", errDPoPInvalidProof)
+	}
+
+	payload, err := jws.Verify(jwk)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errDPoPInvalidProof, err)
+	}
+
+	var claims dpopClaims
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return fmt.Errorf("%w: %w", errDPoPInvalidProof, err)
+	}
+
+	if err = v.validateClaims(claims, req); err != nil {
+		return err
+	}
+
+	if err = validateDPoPBinding(jwk, req.Confirmation); err != nil {
+		return err
+	}
+
+	firstUse, err := v.replayCache.Remember(ctx, "dpop_jti:"+claims.ID, v.proofLifetime+dpopClockSkew)
+	if err != nil {
+		return fmt.Errorf("check DPoP proof replay: %w", err)
+	}
+
+	if !firstUse {
+		return errDPoPProofReplayed
+	}
+
+	return nil
+}
+
+func (v *DPoPValidator) validateClaims(claims dpopClaims, req DPoPRequest) error {
+	if claims.ID == "" {
+		return fmt.Errorf("%w: missing jti", errDPoPInvalidProof)
+	}
+
+	if claims.HTTPMethod != req.HTTPMethod {
+		return errDPoPMethodMismatch
+	}
+
+	if !equalDPoPURI(claims.HTTPURI, req.URL) {
+		return errDPoPURIMismatch
+	}
+
+	issuedAt := time.Unix(claims.IssuedAt, 0)
+	now := v.now()
+
+	if issuedAt.After(now.Add(dpopClockSkew)) || issuedAt.Before(now.Add(-v.proofLifetime)) {
+		return errDPoPProofExpired
+	}
+
+	if req.AccessToken != "" {
+		tokenHash := sha256.Sum256([]byte(req.AccessToken))
+		expected := base64.RawURLEncoding.EncodeToString(tokenHash[:])
+
+		if subtle.ConstantTimeCompare([]byte(claims.AccessTokenHash), []byte(expected)) != 1 {
+			return errDPoPTokenMismatch
+		}
+	}
+
+	return nil
+}
+
+func validateDPoPBinding(jwk *jose.JSONWebKey, confirmation domain.TokenConfirmation) error {
+	if confirmation.JWKThumbprint == "" {
+		return errDPoPUnbound
+	}
+
+	thumbprint, err := jwk.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return fmt.Errorf("%w: %w", errDPoPInvalidProof, err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(base64.RawURLEncoding.EncodeToString(thumbprint)), []byte(confirmation.JWKThumbprint)) != 1 {
+		return errDPoPKeyMismatch
+	}
+
+	return nil
+}
+
+func isDPoPAlgorithmSupported(algorithm string) bool {
+	for _, supported := range DPoPSigningAlgorithms {
+		if supported == algorithm {
+			return true
+		}
+	}
+
+	return false
+}
+
+// equalDPoPURI compares URIs without query and fragment parts as described in RFC 9449.
+func equalDPoPURI(proofURI, requestURI string) bool {
+	pu, err := url.Parse(proofURI)
+	if err != nil {
+		return false
+	}
+
+	ru, err := url.Parse(requestURI)
+	if err != nil {
+		return false
+	}
+
+	return strings.EqualFold(pu.Scheme, ru.Scheme) &&
+		strings.EqualFold(pu.Host, ru.Host) &&
+		normalizePath(pu.Path) == normalizePath(ru.Path)
+}
+
+func normalizePath(path string) string {
+	if path == "" {
+		return "/"
+	}
+
+	return path
+}
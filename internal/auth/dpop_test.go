@@ -0,0 +1,137 @@
+package auth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-jose/go-jose.v2"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+type memoryReplayCache map[string]struct{}
+
+func (m memoryReplayCache) Remember(_ context.Context, key string, _ time.Duration) (bool, error) {
+	if _, ok := m[key]; ok {
+		return false, nil
+	}
+
+	m[key] = struct{}{}
+	return true, nil
+}
+
+func TestDPoPValidator_Validate(t *testing.T) {
+	t.Parallel()
+
+	const accessToken = "access-token"
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	publicJWK := jose.JSONWebKey{Key: key.Public(), Algorithm: string(jose.ES256)}
+	thumbprint, err := publicJWK.Thumbprint(crypto.SHA256)
+	require.NoError(t, err)
+
+	tokenHash := sha256.Sum256([]byte(accessToken))
+
+	newProof := func(t *testing.T, claims map[string]any) string {
+		signer, err := jose.NewSigner(
+			jose.SigningKey{Algorithm: jose.ES256, Key: key},
+			(&jose.SignerOptions{EmbedJWK: true}).WithType(dpopProofType),
+		)
+		require.NoError(t, err)
+
+		payload, err := json.Marshal(claims)
+		require.NoError(t, err)
+
+		jws, err := signer.Sign(payload)
+		require.NoError(t, err)
+
+		proof, err := jws.CompactSerialize()
+		require.NoError(t, err)
+
+		return proof
+	}
+
+	validClaims := func() map[string]any {
+		return map[string]any{
+			"jti": "id",
+			"htm": "GET",
+			"htu": "https://gateway.example.com/service/method",
+			"iat": time.Now().Unix(),
+			"ath": base64.RawURLEncoding.EncodeToString(tokenHash[:]),
+		}
+	}
+
+	request := func(proof string) DPoPRequest {
+		return DPoPRequest{
+			Proof:       proof,
+			HTTPMethod:  "GET",
+			URL:         "https://gateway.example.com/service/method?query=1",
+			AccessToken: accessToken,
+			Confirmation: domain.TokenConfirmation{
+				JWKThumbprint: base64.RawURLEncoding.EncodeToString(thumbprint),
+			},
+		}
+	}
+
+	t.Run("Valid proof and replay", func(t *testing.T) {
+		t.Parallel()
+
+		v := NewDPoPValidator(memoryReplayCache{}, time.Minute)
+		proof := newProof(t, validClaims())
+
+		assert.NoError(t, v.Validate(context.Background(), request(proof)))
+		assert.ErrorIs(t, v.Validate(context.Background(), request(proof)), errDPoPProofReplayed)
+	})
+
+	t.Run("Wrong method", func(t *testing.T) {
+		t.Parallel()
+
+		claims := validClaims()
+		claims["htm"] = "POST"
+
+		v := NewDPoPValidator(memoryReplayCache{}, time.Minute)
+		assert.ErrorIs(t, v.Validate(context.Background(), request(newProof(t, claims))), errDPoPMethodMismatch)
+	})
+
+	t.Run("Wrong access token", func(t *testing.T) {
+		t.Parallel()
+
+		claims := validClaims()
+		claims["ath"] = "other"
+
+		v := NewDPoPValidator(memoryReplayCache{}, time.Minute)
+		assert.ErrorIs(t, v.Validate(context.Background(), request(newProof(t, claims))), errDPoPTokenMismatch)
+	})
+
+	t.Run("Expired proof", func(t *testing.T) {
+		t.Parallel()
+
+		claims := validClaims()
+		claims["iat"] = time.Now().Add(-time.Hour).Unix()
+
+		v := NewDPoPValidator(memoryReplayCache{}, time.Minute)
+		assert.ErrorIs(t, v.Validate(context.Background(), request(newProof(t, claims))), errDPoPProofExpired)
+	})
+
+	t.Run("Key mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		req := request(newProof(t, validClaims()))
+		req.Confirmation.JWKThumbprint = "other"
+
+		v := NewDPoPValidator(memoryReplayCache{}, time.Minute)
+		assert.ErrorIs(t, v.Validate(context.Background(), req), errDPoPKeyMismatch)
+	})
+}
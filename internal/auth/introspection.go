@@ -0,0 +1,147 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	mapset "github.com/deckarep/golang-set/v2"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/clients/introspection"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/utils/cache"
+)
+
+type introspectionClient interface {
+	Introspect(ctx context.Context, token string) (*introspection.Response, error)
+}
+
+// Introspector parses opaque tokens using introspection endpoint.
+type Introspector struct {
+	client    introspectionClient
+	audience  string
+	clientIDs []string
+	cacheTTL  time.Duration
+	cache     *cache.Cache[[sha256.Size]byte, *introspection.Response]
+}
+
+// IntrospectorOptions ...
+type IntrospectorOptions struct {
+	// Audience that tokens must be issued for, the same as for JWT tokens.
+	Audience string
+	// ClientIDs that tokens must be issued to, any client is accepted when empty.
+	ClientIDs []string
+	// CacheTTL is a time introspection results are cached for.
+	CacheTTL time.Duration
+}
+
+// NewIntrospector returns new Introspector.
+func NewIntrospector(client introspectionClient, opts IntrospectorOptions) *Introspector {
+	return &Introspector{
+		client:    client,
+		audience:  opts.Audience,
+		clientIDs: opts.ClientIDs,
+		cacheTTL:  opts.CacheTTL,
+		cache:     cache.New[[sha256.Size]byte, *introspection.Response](),
+	}
+}
+
+var (
+	errInactiveToken   = errors.New("token is not active")
+	errInvalidAudience = errors.New("token is not issued for audience of gateway")
+	errInvalidClientID = errors.New("token is issued to not allowed client")
+)
+
+// ParseToken ...
+func (i *Introspector) ParseToken(ctx context.Context, token string) (*domain.SubjectInformation, error) {
+	key := sha256.Sum256([]byte(token))
+
+	resp, ok := i.cache.Get(key)
+	if !ok {
+		var err error
+
+		resp, err = i.client.Introspect(ctx, token)
+		if err != nil {
+			return nil, fmt.Errorf("introspect token: %w", err)
+		}
+
+		i.cache.Set(key, resp, i.responseTTL(resp))
+	}
+
+	if !resp.Active {
+		return nil, errInactiveToken
+	}
+
+	if resp.ExpiresAt != 0 && !time.Now().Before(time.Unix(resp.ExpiresAt, 0)) {
+		return nil, errInactiveToken
+	}
+
+	// active token of the same authorization server may be issued for another API.
+	if !slices.Contains(resp.Audience, i.audience) {
+		return nil, errInvalidAudience
+	}
+
+	if len(i.clientIDs) != 0 && !slices.Contains(i.clientIDs, resp.ClientID) {
+		return nil, errInvalidClientID
+	}
+
+	permissions := mapset.NewThreadUnsafeSet(resp.Permissions...)
+	permissions.Append(resp.Scopes()...)
+
+	subjectInfo := &domain.SubjectInformation{
+		ID:          resp.Subject,
+		TokenID:     resp.TokenID,
+		Permissions: permissions,
+	}
+
+	if resp.Confirmation != nil {
+		subjectInfo.Confirmation = domain.TokenConfirmation{
+			JWKThumbprint:         resp.Confirmation.JWKThumbprint,
+			CertificateThumbprint: resp.Confirmation.CertificateThumbprint,
+		}
+	}
+
+	if err := subjectInfo.Validate(); err != nil {
+		return nil, fmt.Errorf("failed to validate SubjectInformation: %w", err)
+	}
+
+	return subjectInfo, nil
+}
+
+func (i *Introspector) responseTTL(resp *introspection.Response) time.Duration {
+	ttl := i.cacheTTL
+	if resp.Active && resp.ExpiresAt != 0 {
+		ttl = min(ttl, time.Until(time.Unix(resp.ExpiresAt, 0)))
+	}
+
+	return ttl
+}
+
+type introspectionFallback struct {
+	jwtParser    Parser
+	introspector Parser
+}
+
+// WithIntrospection returns Parser that validates JWT locally and uses introspector for opaque tokens.
+func WithIntrospection(jwtParser, introspector Parser) Parser {
+	return &introspectionFallback{
+		jwtParser:    jwtParser,
+		introspector: introspector,
+	}
+}
+
+func (p *introspectionFallback) ParseToken(ctx context.Context, token string) (*domain.SubjectInformation, error) {
+	if isJWT(token) {
+		return p.jwtParser.ParseToken(ctx, token)
+	}
+
+	return p.introspector.ParseToken(ctx, token)
+}
+
+func isJWT(token string) bool {
+	return strings.Count(token, ".") == 2
+}
@@ -0,0 +1,139 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/clients/introspection"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+type fakeIntrospectionClient struct {
+	responses map[string]*introspection.Response
+	calls     int
+}
+
+func (f *fakeIntrospectionClient) Introspect(_ context.Context, token string) (*introspection.Response, error) {
+	f.calls++
+
+	if resp, ok := f.responses[token]; ok {
+		return resp, nil
+	}
+
+	return &introspection.Response{}, nil
+}
+
+func TestIntrospectorParseToken(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeIntrospectionClient{responses: map[string]*introspection.Response{
+		"active": {
+			Active:      true,
+			Subject:     "user-1",
+			TokenID:     "jti-1",
+			Scope:       "read write",
+			Permissions: []string{"admin"},
+			ExpiresAt:   time.Now().Add(time.Hour).Unix(),
+			Audience:    introspection.Audience{"https://api.example.com"},
+		},
+		"expired": {Active: true, Subject: "user-1", ExpiresAt: time.Now().Add(-time.Minute).Unix(), Audience: introspection.Audience{"https://api.example.com"}},
+	}}
+
+	introspector := NewIntrospector(client, IntrospectorOptions{Audience: "https://api.example.com", CacheTTL: time.Minute})
+	ctx := context.Background()
+
+	subject, err := introspector.ParseToken(ctx, "active")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", subject.ID)
+	assert.Equal(t, "jti-1", subject.TokenID)
+	assert.ElementsMatch(t, []string{"read", "write", "admin"}, subject.Permissions.ToSlice())
+
+	_, err = introspector.ParseToken(ctx, "expired")
+	require.ErrorIs(t, err, errInactiveToken)
+
+	_, err = introspector.ParseToken(ctx, "revoked")
+	require.ErrorIs(t, err, errInactiveToken)
+
+	// inactive result is cached too, so endpoint isn't hit by replayed tokens.
+	_, err = introspector.ParseToken(ctx, "revoked")
+	require.ErrorIs(t, err, errInactiveToken)
+
+	_, err = introspector.ParseToken(ctx, "active")
+	require.NoError(t, err)
+	assert.Equal(t, 3, client.calls)
+}
+
+func TestIntrospectorChecksAudienceAndClient(t *testing.T) {
+	t.Parallel()
+
+	client := &fakeIntrospectionClient{responses: map[string]*introspection.Response{
+		"gateway":      {Active: true, Subject: "user-1", ClientID: "web", Audience: introspection.Audience{"https://other.example.com", "https://api.example.com"}},
+		"other-api":    {Active: true, Subject: "user-1", ClientID: "web", Audience: introspection.Audience{"https://other.example.com"}},
+		"no-audience":  {Active: true, Subject: "user-1", ClientID: "web"},
+		"other-client": {Active: true, Subject: "user-1", ClientID: "cli", Audience: introspection.Audience{"https://api.example.com"}},
+	}}
+
+	introspector := NewIntrospector(client, IntrospectorOptions{
+		Audience:  "https://api.example.com",
+		ClientIDs: []string{"web"},
+		CacheTTL:  time.Minute,
+	})
+	ctx := context.Background()
+
+	subject, err := introspector.ParseToken(ctx, "gateway")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", subject.ID)
+
+	_, err = introspector.ParseToken(ctx, "other-api")
+	require.ErrorIs(t, err, errInvalidAudience)
+
+	_, err = introspector.ParseToken(ctx, "no-audience")
+	require.ErrorIs(t, err, errInvalidAudience)
+
+	_, err = introspector.ParseToken(ctx, "other-client")
+	require.ErrorIs(t, err, errInvalidClientID)
+}
+
+func TestAudienceUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	var resp introspection.Response
+
+	require.NoError(t, json.Unmarshal([]byte(`{"aud":"https://api.example.com"}`), &resp))
+	assert.Equal(t, introspection.Audience{"https://api.example.com"}, resp.Audience)
+
+	require.NoError(t, json.Unmarshal([]byte(`{"aud":["a","b"]}`), &resp))
+	assert.Equal(t, introspection.Audience{"a", "b"}, resp.Audience)
+
+	require.Error(t, json.Unmarshal([]byte(`{"aud":1}`), &resp))
+}
+
+type fakeParser struct {
+	subject *domain.SubjectInformation
+}
+
+func (f fakeParser) ParseToken(context.Context, string) (*domain.SubjectInformation, error) {
+	return f.subject, nil
+}
+
+func TestWithIntrospection(t *testing.T) {
+	t.Parallel()
+
+	parser := WithIntrospection(
+		fakeParser{subject: &domain.SubjectInformation{ID: "jwt"}},
+		fakeParser{subject: &domain.SubjectInformation{ID: "opaque"}},
+	)
+
+	subject, err := parser.ParseToken(context.Background(), "header.payload.signature")
+	require.NoError(t, err)
+	assert.Equal(t, "jwt", subject.ID)
+
+	subject, err = parser.ParseToken(context.Background(), "opaque-token")
+	require.NoError(t, err)
+	assert.Equal(t, "opaque", subject.ID)
+}
@@ -0,0 +1,47 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+type revocationChecker interface {
+	IsRevoked(ctx context.Context, subject *domain.SubjectInformation) (bool, error)
+}
+
+type revocationCheck struct {
+	next    Parser
+	checker revocationChecker
+}
+
+// WithRevocationCheck returns Parser that rejects tokens revoked by token ID or subject.
+func WithRevocationCheck(next Parser, checker revocationChecker) Parser {
+	return &revocationCheck{
+		next:    next,
+		checker: checker,
+	}
+}
+
+// ErrTokenRevoked is returned when token or its subject was revoked.
+var ErrTokenRevoked = errors.New("token revoked")
+
+func (r *revocationCheck) ParseToken(ctx context.Context, token string) (*domain.SubjectInformation, error) {
+	subjectInfo, err := r.next.ParseToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := r.checker.IsRevoked(ctx, subjectInfo)
+	if err != nil {
+		return nil, fmt.Errorf("check revocation: %w", err)
+	}
+
+	if revoked {
+		return nil, ErrTokenRevoked
+	}
+
+	return subjectInfo, nil
+}
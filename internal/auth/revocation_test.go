@@ -0,0 +1,40 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+type fakeRevocationChecker struct {
+	revokedTokens map[string]bool
+	err           error
+}
+
+func (f fakeRevocationChecker) IsRevoked(_ context.Context, subject *domain.SubjectInformation) (bool, error) {
+	return f.revokedTokens[subject.TokenID], f.err
+}
+
+func TestWithRevocationCheck(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	checker := fakeRevocationChecker{revokedTokens: map[string]bool{"jti-1": true}}
+
+	_, err := WithRevocationCheck(fakeParser{subject: &domain.SubjectInformation{ID: "user", TokenID: "jti-1"}}, checker).ParseToken(ctx, "token")
+	require.ErrorIs(t, err, ErrTokenRevoked)
+
+	subject, err := WithRevocationCheck(fakeParser{subject: &domain.SubjectInformation{ID: "user", TokenID: "jti-2"}}, checker).ParseToken(ctx, "token")
+	require.NoError(t, err)
+	assert.Equal(t, "jti-2", subject.TokenID)
+
+	// unavailable list fails closed.
+	checker.err = errors.New("redis is down")
+	_, err = WithRevocationCheck(fakeParser{subject: &domain.SubjectInformation{ID: "user", TokenID: "jti-2"}}, checker).ParseToken(ctx, "token")
+	require.ErrorIs(t, err, checker.err)
+}
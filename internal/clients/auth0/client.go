@@ -3,18 +3,36 @@ package auth0
 import (
 	"bytes"
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/go-jose/go-jose.v2"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+const (
+	clientAssertionType     = "urn:ietf:params:oauth:client-assertion-type:jwt-bearer"
+	clientAssertionLifetime = time.Minute
 )
 
 // Client ...
 type Client struct {
 	domain       string
 	clientID     string
-	clientSecret string
+	clientSecret atomic.Pointer[string]
+	authMethod   domain.ClientAuthMethod
+	assertion    jose.Signer
 	httpClient   *http.Client
 }
 
@@ -23,6 +41,10 @@ type NewOptions struct {
 	Domain       string
 	ClientID     string
 	ClientSecret string
+	// AuthMethod is a client authentication method, for tls_client_auth HTTPClient must present client certificate.
+	AuthMethod domain.ClientAuthMethod
+	// AssertionKey signs client assertions for private_key_jwt.
+	AssertionKey *jose.JSONWebKey
 	HTTPClient   *http.Client
 }
 
@@ -36,16 +58,45 @@ func (opts *NewOptions) setDefaults() {
 	}
 }
 
+var (
+	errAssertionKeyRequired = errors.New("assertion key is required for private_key_jwt")
+)
+
 // New returns new Client.
-func New(opts NewOptions) *Client {
+func New(opts NewOptions) (*Client, error) {
 	opts.setDefaults()
 
-	return &Client{
-		domain:       opts.Domain,
-		clientID:     opts.ClientID,
-		clientSecret: opts.ClientSecret,
-		httpClient:   opts.HTTPClient,
+	c := &Client{
+		domain:     opts.Domain,
+		clientID:   opts.ClientID,
+		authMethod: opts.AuthMethod,
+		httpClient: opts.HTTPClient,
+	}
+
+	c.SetClientSecret(opts.ClientSecret)
+
+	if opts.AuthMethod == domain.ClientAuthMethodPrivateKeyJwt {
+		if opts.AssertionKey == nil {
+			return nil, errAssertionKeyRequired
+		}
+
+		signer, err := jose.NewSigner(
+			jose.SigningKey{Algorithm: jose.SignatureAlgorithm(opts.AssertionKey.Algorithm), Key: opts.AssertionKey},
+			(&jose.SignerOptions{}).WithType("JWT"),
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create assertion signer: %w", err)
+		}
+
+		c.assertion = signer
 	}
+
+	return c, nil
+}
+
+// SetClientSecret replaces client secret, it is used to rotate secret without restart.
+func (c *Client) SetClientSecret(secret string) {
+	c.clientSecret.Store(&secret)
 }
 
 // Token ...
@@ -55,21 +106,60 @@ type Token struct {
 	ExpiresIn   uint   `json:"expires_in"`
 }
 
+const (
+	grantTypeClientCredentials = "client_credentials"
+	grantTypeTokenExchange     = "urn:ietf:params:oauth:grant-type:token-exchange"
+
+	// TokenTypeAccessToken is a RFC 8693 token type of access token.
+	TokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+)
+
 type tokenRequest struct {
-	Audience     string `json:"audience"`
-	GrantType    string `json:"grant_type"`
-	ClientID     string `json:"client_id"`
-	ClientSecret string `json:"client_secret"`
+	Audience            string `json:"audience"`
+	GrantType           string `json:"grant_type"`
+	ClientID            string `json:"client_id,omitempty"`
+	ClientSecret        string `json:"client_secret,omitempty"`
+	ClientAssertionType string `json:"client_assertion_type,omitempty"`
+	ClientAssertion     string `json:"client_assertion,omitempty"`
+	SubjectToken        string `json:"subject_token,omitempty"`
+	SubjectTokenType    string `json:"subject_token_type,omitempty"`
 }
 
 // Token returns new Token from Auth0 with provided audience.
 func (c *Client) Token(ctx context.Context, audience string) (*Token, error) {
-	jsonRequest, err := json.Marshal(tokenRequest{
-		Audience:     audience,
-		GrantType:    "client_credentials",
-		ClientID:     c.clientID,
-		ClientSecret: c.clientSecret,
+	return c.requestToken(ctx, tokenRequest{
+		Audience:  audience,
+		GrantType: grantTypeClientCredentials,
 	})
+}
+
+// ExchangeToken exchanges subject token to token for provided audience on behalf of subject (RFC 8693).
+func (c *Client) ExchangeToken(ctx context.Context, subjectToken, subjectTokenType, audience string) (*Token, error) {
+	return c.requestToken(ctx, tokenRequest{
+		Audience:         audience,
+		GrantType:        grantTypeTokenExchange,
+		SubjectToken:     subjectToken,
+		SubjectTokenType: subjectTokenType,
+	})
+}
+
+func (c *Client) requestToken(ctx context.Context, tokenReq tokenRequest) (*Token, error) {
+	tokenReq.ClientID = c.clientID
+
+	switch c.authMethod {
+	case domain.ClientAuthMethodClientSecretPost:
+		tokenReq.ClientSecret = *c.clientSecret.Load()
+	case domain.ClientAuthMethodPrivateKeyJwt:
+		assertion, err := c.clientAssertion()
+		if err != nil {
+			return nil, err
+		}
+
+		tokenReq.ClientAssertionType = clientAssertionType
+		tokenReq.ClientAssertion = assertion
+	}
+
+	jsonRequest, err := json.Marshal(tokenReq)
 	if err != nil {
 		return nil, fmt.Errorf("failed to marshal token request: %w", err)
 	}
@@ -81,6 +171,10 @@ func (c *Client) Token(ctx context.Context, audience string) (*Token, error) {
 
 	req.Header.Set("Content-Type", "application/json")
 
+	if c.authMethod == domain.ClientAuthMethodClientSecretBasic {
+		req.SetBasicAuth(url.QueryEscape(c.clientID), url.QueryEscape(*c.clientSecret.Load()))
+	}
+
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to do request: %w", err)
@@ -104,3 +198,28 @@ func (c *Client) Token(ctx context.Context, audience string) (*Token, error) {
 
 	return &token, nil
 }
+
+// clientAssertion returns signed JWT that authenticates client (RFC 7523).
+func (c *Client) clientAssertion() (string, error) {
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", fmt.Errorf("failed to generate assertion id: %w", err)
+	}
+
+	now := time.Now()
+
+	assertion, err := jwt.Signed(c.assertion).Claims(jwt.Claims{
+		Issuer:    c.clientID,
+		Subject:   c.clientID,
+		Audience:  jwt.Audience{c.domain},
+		ID:        hex.EncodeToString(jti),
+		IssuedAt:  jwt.NewNumericDate(now),
+		Expiry:    jwt.NewNumericDate(now.Add(clientAssertionLifetime)),
+		NotBefore: jwt.NewNumericDate(now),
+	}).CompactSerialize()
+	if err != nil {
+		return "", fmt.Errorf("failed to sign client assertion: %w", err)
+	}
+
+	return assertion, nil
+}
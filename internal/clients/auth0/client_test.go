@@ -0,0 +1,84 @@
+package auth0
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/go-jose/go-jose.v2"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+func TestClientAuthentication(t *testing.T) {
+	t.Parallel()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	var srv *httptest.Server
+
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req tokenRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		clientID, secret, basic := r.BasicAuth()
+
+		authenticated := false
+
+		switch {
+		case basic:
+			authenticated = clientID == "client" && secret == "secret" && req.ClientSecret == ""
+		case req.ClientSecret != "":
+			authenticated = req.ClientID == "client" && req.ClientSecret == "secret"
+		case req.ClientAssertion != "":
+			var claims jwt.Claims
+			if assertion, err := jwt.ParseSigned(req.ClientAssertion); err != nil || assertion.Claims(&privateKey.PublicKey, &claims) != nil {
+				break
+			}
+
+			authenticated = req.ClientAssertionType == clientAssertionType && claims.Issuer == "client" &&
+				claims.Subject == "client" && claims.Audience.Contains(srv.URL+"/")
+		}
+
+		if !authenticated {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(Token{AccessToken: "token", ExpiresIn: 60}) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	for _, method := range []domain.ClientAuthMethod{
+		domain.ClientAuthMethodClientSecretPost,
+		domain.ClientAuthMethodClientSecretBasic,
+		domain.ClientAuthMethodPrivateKeyJwt,
+	} {
+		t.Run(method.String(), func(t *testing.T) {
+			client, err := New(NewOptions{
+				Domain:       srv.URL,
+				ClientID:     "client",
+				ClientSecret: "secret",
+				AuthMethod:   method,
+				AssertionKey: &jose.JSONWebKey{Key: privateKey, KeyID: "key", Algorithm: string(jose.ES256)},
+			})
+			require.NoError(t, err)
+
+			token, err := client.Token(context.Background(), "api")
+			require.NoError(t, err)
+			assert.Equal(t, "token", token.AccessToken)
+		})
+	}
+}
@@ -0,0 +1,138 @@
+package introspection
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync/atomic"
+)
+
+// Client to OAuth 2.0 token introspection endpoint (RFC 7662).
+type Client struct {
+	endpoint     string
+	clientID     string
+	clientSecret atomic.Pointer[string]
+	httpClient   *http.Client
+}
+
+// NewOptions ...
+type NewOptions struct {
+	Endpoint     string
+	ClientID     string
+	ClientSecret string
+	HTTPClient   *http.Client
+}
+
+func (opts *NewOptions) setDefaults() {
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+}
+
+// New returns new Client.
+func New(opts NewOptions) *Client {
+	opts.setDefaults()
+
+	c := &Client{
+		endpoint:   opts.Endpoint,
+		clientID:   opts.ClientID,
+		httpClient: opts.HTTPClient,
+	}
+
+	c.SetClientSecret(opts.ClientSecret)
+
+	return c
+}
+
+// SetClientSecret replaces client secret, it is used to rotate secret without restart.
+func (c *Client) SetClientSecret(secret string) {
+	c.clientSecret.Store(&secret)
+}
+
+// Response of introspection endpoint.
+type Response struct {
+	Active       bool          `json:"active"`
+	Subject      string        `json:"sub"`
+	Scope        string        `json:"scope"`
+	Permissions  []string      `json:"permissions"`
+	TokenID      string        `json:"jti"`
+	ExpiresAt    int64         `json:"exp"`
+	Audience     Audience      `json:"aud"`
+	ClientID     string        `json:"client_id"`
+	Confirmation *Confirmation `json:"cnf,omitempty"`
+}
+
+// Audience of token, endpoints return it as a string or an array of strings.
+type Audience []string
+
+// UnmarshalJSON ...
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = Audience{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+
+	*a = multiple
+
+	return nil
+}
+
+// Confirmation of token key binding.
+type Confirmation struct {
+	JWKThumbprint         string `json:"jkt"`
+	CertificateThumbprint string `json:"x5t#S256"`
+}
+
+// Scopes returns space-delimited scope as a slice.
+func (r *Response) Scopes() []string {
+	return strings.Fields(r.Scope)
+}
+
+// Introspect returns information about provided token.
+func (c *Client) Introspect(ctx context.Context, token string) (*Response, error) {
+	form := url.Values{}
+	form.Set("token", token)
+	form.Set("token_type_hint", "access_token")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	req.SetBasicAuth(url.QueryEscape(c.clientID), url.QueryEscape(*c.clientSecret.Load()))
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to do request: %w", err)
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	readData, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got response with unexpected status %d: %s", resp.StatusCode, string(readData))
+	}
+
+	var introspection Response
+	if err = json.Unmarshal(readData, &introspection); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal introspection response: %w", err)
+	}
+
+	return &introspection, nil
+}
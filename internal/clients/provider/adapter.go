@@ -1,7 +1,11 @@
 package provider
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+
 	mapset "github.com/deckarep/golang-set/v2"
+	"google.golang.org/protobuf/proto"
 
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/utils/slice"
@@ -15,23 +19,114 @@ func descriptionFromProto(desc *provider.DescriptionResponse) *domain.ProviderDe
 	}
 
 	return &domain.ProviderDescription{
-		AuditEnabled:          desc.GetAuditEnabled(),
-		RateLimiter:           rateLimiterFromProto(desc.GetRateLimiter()),
-		RequireAuthentication: desc.GetRequiredAuthentication(),
-		RequiredPermissions:   desc.GetRequiredPermissions(),
-		DescriptionByMethod:   descriptionByMethod,
+		AuditEnabled:              desc.GetAuditEnabled(),
+		RateLimiters:              rateLimitersFromProto(desc.GetRateLimiter(), desc.GetRateLimiters()),
+		AuthenticationMode:        authenticationModeFromProto(desc.GetAuthenticationMode(), desc.GetRequiredAuthentication()),
+		CertificateAuthentication: certificateAuthenticationFromProto(desc.GetCertificateAuthentication()),
+		RequiredPermissions:       desc.GetRequiredPermissions(),
+		DescriptionByMethod:       descriptionByMethod,
+		Version:                   descriptionVersion(desc),
+	}
+}
+
+// descriptionVersion returns version sent by provider or hash of description for providers built with older SDK.
+func descriptionVersion(desc *provider.DescriptionResponse) string {
+	if desc.GetVersion() != "" {
+		return desc.GetVersion()
 	}
+
+	data, _ := proto.MarshalOptions{Deterministic: true}.Marshal(desc) //nolint:errcheck
+	hash := sha256.Sum256(data)
+
+	return hex.EncodeToString(hash[:])
 }
 
 func descriptionMethodFromProto(desc *provider.DescriptionMethod) *domain.ProviderDescriptionMethod {
 	return &domain.ProviderDescriptionMethod{
-		Method:                 desc.GetMethod(),
-		AuditEnabled:           desc.GetAuditEnabled(),
-		RateLimiter:            rateLimiterFromProto(desc.GetRateLimiter()),
-		RequiredAuthentication: desc.GetRequiredAuthentication(),
-		RequiredPermissions:    desc.GetRequiredPermissions(),
-		AllowedHTTPMethods:     mapset.NewThreadUnsafeSet(slice.ConvertFunc(desc.GetAllowedHttpMethods(), httpMethodFromProto)...),
+		Method:                    desc.GetMethod(),
+		AuditEnabled:              desc.GetAuditEnabled(),
+		RateLimiters:              rateLimitersFromProto(desc.GetRateLimiter(), desc.GetRateLimiters()),
+		AuthenticationMode:        authenticationModeFromProto(desc.GetAuthenticationMode(), desc.GetRequiredAuthentication()),
+		CertificateAuthentication: certificateAuthenticationFromProto(desc.GetCertificateAuthentication()),
+		RequiredPermissions:       desc.GetRequiredPermissions(),
+		AllowedHTTPMethods:        mapset.NewThreadUnsafeSet(slice.ConvertFunc(desc.GetAllowedHttpMethods(), httpMethodFromProto)...),
+		Cost:                      requestCostFromProto(desc.GetCost()),
+		Priority:                  priorityFromProto(desc.GetPriority()),
+	}
+}
+
+func priorityFromProto(priority provider.Priority) domain.Priority {
+	switch priority {
+	case provider.Priority_PRIORITY_LOW:
+		return domain.PriorityLow
+	case provider.Priority_PRIORITY_NORMAL:
+		return domain.PriorityNormal
+	case provider.Priority_PRIORITY_HIGH:
+		return domain.PriorityHigh
+	case provider.Priority_PRIORITY_CRITICAL:
+		return domain.PriorityCritical
+	}
+
+	return domain.PriorityUnspecified
+}
+
+func priorityToProto(priority domain.Priority) provider.Priority {
+	switch priority {
+	case domain.PriorityLow:
+		return provider.Priority_PRIORITY_LOW
+	case domain.PriorityNormal:
+		return provider.Priority_PRIORITY_NORMAL
+	case domain.PriorityHigh:
+		return provider.Priority_PRIORITY_HIGH
+	case domain.PriorityCritical:
+		return provider.Priority_PRIORITY_CRITICAL
+	}
+
+	return provider.Priority_PRIORITY_UNSPECIFIED
+}
+
+func requestCostFromProto(cost *provider.RequestCost) *domain.RequestCost {
+	if cost == nil {
+		return nil
+	}
+
+	return &domain.RequestCost{
+		Base:             cost.GetBase(),
+		QueryParam:       cost.GetQueryParam(),
+		BodyBytesPerUnit: cost.GetBodyBytesPerUnit(),
+		Max:              cost.GetMax(),
+	}
+}
+
+// authenticationModeFromProto handles providers that only set legacy required_authentication flag.
+func authenticationModeFromProto(mode provider.AuthenticationMode, requiredAuthentication bool) domain.AuthenticationMode {
+	switch mode {
+	case provider.AuthenticationMode_AUTHENTICATION_MODE_NONE:
+		return domain.AuthenticationModeNone
+	case provider.AuthenticationMode_AUTHENTICATION_MODE_OPTIONAL:
+		return domain.AuthenticationModeOptional
+	case provider.AuthenticationMode_AUTHENTICATION_MODE_REQUIRED:
+		return domain.AuthenticationModeRequired
+	}
+
+	if requiredAuthentication {
+		return domain.AuthenticationModeRequired
+	}
+
+	return domain.AuthenticationModeUnspecified
+}
+
+func certificateAuthenticationFromProto(certificateAuthentication provider.CertificateAuthentication) domain.CertificateAuthentication {
+	switch certificateAuthentication {
+	case provider.CertificateAuthentication_CERTIFICATE_AUTHENTICATION_DISABLED:
+		return domain.CertificateAuthenticationDisabled
+	case provider.CertificateAuthentication_CERTIFICATE_AUTHENTICATION_ACCEPTED:
+		return domain.CertificateAuthenticationAccepted
+	case provider.CertificateAuthentication_CERTIFICATE_AUTHENTICATION_REQUIRED:
+		return domain.CertificateAuthenticationRequired
 	}
+
+	return domain.CertificateAuthenticationUnspecified
 }
 
 func httpMethodFromProto(method provider.HttpMethod) domain.HTTPMethod {
@@ -91,19 +186,36 @@ func headersFromProto(headers map[string]*provider.HeaderValue) map[string][]str
 }
 
 func subjectInformationToProto(info *domain.SubjectInformation) *provider.SubjectInformation {
+	if info == nil {
+		return nil
+	}
+
+	var permissions []string
+	if info.Permissions != nil {
+		permissions = info.Permissions.ToSlice()
+	}
+
 	return &provider.SubjectInformation{
 		Id:          info.ID,
-		Permissions: info.Permissions.ToSlice(),
+		Permissions: permissions,
+		Anonymous:   info.Anonymous,
 	}
 }
 
-func rateLimiterFromProto(rateLimiter *provider.RateLimiter) *domain.RateLimiterDescription {
-	if rateLimiter == nil {
-		return nil
+// rateLimitersFromProto returns list of limiters, single limiter is read from providers built with older SDK.
+func rateLimitersFromProto(single *provider.RateLimiter, list []*provider.RateLimiter) []*domain.RateLimiterDescription {
+	if len(list) == 0 && single != nil {
+		list = []*provider.RateLimiter{single}
 	}
 
+	return slice.ConvertFunc(list, rateLimiterFromProto)
+}
+
+func rateLimiterFromProto(rateLimiter *provider.RateLimiter) *domain.RateLimiterDescription {
 	return &domain.RateLimiterDescription{
 		By:     rateLimitByFromProto(rateLimiter.GetBy()),
+		Name:   rateLimiter.GetName(),
+		Keys:   slice.ConvertFunc(rateLimiter.GetKeys(), rateLimitKeyFromProto),
 		Rate:   rateLimiter.GetLimit(),
 		Burst:  rateLimiter.GetBurst(),
 		Period: rateLimiter.GetPeriod().AsDuration(),
@@ -116,7 +228,34 @@ func rateLimitByFromProto(by provider.RateLimitBy) domain.RateLimitDescriptionBy
 		return domain.RateLimitDescriptionByIp
 	case provider.RateLimitBy_RATE_LIMIT_BY_SUBJECT_ID:
 		return domain.RateLimitDescriptionBySubjectId
+	case provider.RateLimitBy_RATE_LIMIT_BY_CLAIM:
+		return domain.RateLimitDescriptionByClaim
+	case provider.RateLimitBy_RATE_LIMIT_BY_HEADER:
+		return domain.RateLimitDescriptionByHeader
+	case provider.RateLimitBy_RATE_LIMIT_BY_QUERY:
+		return domain.RateLimitDescriptionByQuery
+	case provider.RateLimitBy_RATE_LIMIT_BY_GLOBAL:
+		return domain.RateLimitDescriptionByGlobal
+	case provider.RateLimitBy_RATE_LIMIT_BY_API_KEY:
+		return domain.RateLimitDescriptionByApiKey
+	case provider.RateLimitBy_RATE_LIMIT_BY_COMPOSITE:
+		return domain.RateLimitDescriptionByComposite
 	}
 
 	return domain.RateLimitDescriptionByIp
 }
+
+func rateLimitKeyFromProto(key *provider.RateLimitKey) domain.RateLimitKey {
+	return domain.RateLimitKey{
+		By:   rateLimitByFromProto(key.GetBy()),
+		Name: key.GetName(),
+	}
+}
+
+func watchResponseDescription(resp *provider.WatchDescriptionResponse) *domain.ProviderDescription {
+	if resp.GetDescription() == nil {
+		return nil
+	}
+
+	return descriptionFromProto(resp.GetDescription())
+}
@@ -2,18 +2,27 @@ package provider
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 	"time"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/credentials/insecure"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
 
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/identity"
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/m2m"
 	provider "github.com/TheUnitedCoders/devpost-auth0-api-gateway/pkg/pb/contract/v1"
 )
 
+// ErrMethodNotFound is returned by clients that resolve methods when no method serves request.
+var ErrMethodNotFound = errors.New("method not found")
+
 // Client to provider.
 type Client interface {
 	Description(ctx context.Context) (*domain.ProviderDescription, error)
@@ -58,9 +67,12 @@ func (i *impl) Description(ctx context.Context) (*domain.ProviderDescription, er
 	ctx, cancel := context.WithTimeout(ctx, i.timeout)
 	defer cancel()
 
-	ctx = i.addM2MToken(ctx)
+	var resp *provider.DescriptionResponse
 
-	resp, err := i.client.Description(ctx, &provider.DescriptionRequest{})
+	err := withM2MToken(ctx, i.m2mTokenSource, m2mTokenMetadataKey, "", m2mTokenRejected, func(ctx context.Context) (err error) {
+		resp, err = i.client.Description(ctx, &provider.DescriptionRequest{})
+		return err
+	})
 	if err != nil {
 		return nil, fmt.Errorf("could not get provider description: %w", err)
 	}
@@ -68,13 +80,57 @@ func (i *impl) Description(ctx context.Context) (*domain.ProviderDescription, er
 	return descriptionFromProto(resp), nil
 }
 
+// WatchDescription calls update with description on every change until stream is broken.
+// Update with nil description means description is not changed since version.
+func (i *impl) WatchDescription(ctx context.Context, version string, update func(*domain.ProviderDescription)) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var stream provider.ProviderService_WatchDescriptionClient
+
+	// errors of server streams are received with first message, so M2M token is checked by it.
+	err := withM2MToken(ctx, i.m2mTokenSource, m2mTokenMetadataKey, "", m2mTokenRejected, func(ctx context.Context) (err error) {
+		stream, err = i.client.WatchDescription(ctx, &provider.WatchDescriptionRequest{Version: version})
+		if err != nil {
+			return err
+		}
+
+		resp, err := stream.Recv()
+		if err != nil {
+			return err
+		}
+
+		update(watchResponseDescription(resp))
+
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("could not watch provider description: %w", err)
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err != nil {
+			return fmt.Errorf("provider description stream is broken: %w", err)
+		}
+
+		update(watchResponseDescription(resp))
+	}
+}
+
 func (i *impl) Process(ctx context.Context, req *domain.ProviderProcessRequest) (*domain.ProviderProcessResponse, error) {
 	ctx, cancel := context.WithTimeout(ctx, i.timeout)
 	defer cancel()
 
-	ctx = i.addM2MToken(ctx)
+	if req.IdentityToken != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, identity.MetadataKey, req.IdentityToken)
+	}
+
+	if req.UserToken != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, UserTokenMetadataKey, req.UserToken)
+	}
 
-	resp, err := i.client.Process(ctx, &provider.ProcessRequest{
+	processReq := &provider.ProcessRequest{
 		ApiMethod:          req.APIMethod,
 		HttpMethod:         httpMethodToProto(req.HTTPMethod),
 		Path:               req.Path,
@@ -82,6 +138,14 @@ func (i *impl) Process(ctx context.Context, req *domain.ProviderProcessRequest)
 		Body:               req.Body,
 		Headers:            headersToProto(req.Headers),
 		SubjectInformation: subjectInformationToProto(req.SubjectInformation),
+		Priority:           priorityToProto(req.Priority),
+	}
+
+	var resp *provider.ProcessResponse
+
+	err := withM2MToken(ctx, i.m2mTokenSource, m2mTokenMetadataKey, "", m2mTokenRejected, func(ctx context.Context) (err error) {
+		resp, err = i.client.Process(ctx, processReq)
+		return err
 	})
 	if err != nil {
 		return nil, fmt.Errorf("could not process: %w", err)
@@ -91,15 +155,57 @@ func (i *impl) Process(ctx context.Context, req *domain.ProviderProcessRequest)
 		Body:       resp.GetBody(),
 		StatusCode: resp.GetStatusCode(),
 		Headers:    headersFromProto(resp.GetHeaders()),
+		ActualCost: resp.GetActualCost(),
 	}, nil
 }
 
 const m2mTokenMetadataKey = "x-m2m-token"
 
-func (i *impl) addM2MToken(ctx context.Context) context.Context {
-	if i.m2mTokenSource == nil {
-		return ctx
+// UserTokenMetadataKey is a metadata key of token exchanged on behalf of user.
+const UserTokenMetadataKey = "x-user-token"
+
+// PriorityMetadataKey is a metadata key of request priority for transcoded gRPC services.
+const PriorityMetadataKey = "x-request-priority"
+
+// withM2MToken calls call with M2M token in metadata key with prefix. When rejected reports that
+// provider rejected token, token is refreshed and call is retried once.
+func withM2MToken(ctx context.Context, source m2m.Source, key, prefix string, rejected func(err error) bool, call func(ctx context.Context) error) error {
+	if source == nil {
+		return call(ctx)
+	}
+
+	err := call(metadata.AppendToOutgoingContext(ctx, key, prefix+source.Token()))
+	if err == nil || !rejected(err) {
+		return err
+	}
+
+	if refreshErr := source.Refresh(ctx); refreshErr != nil {
+		slog.Warn("Failed to refresh rejected m2m token", slog.String("err", refreshErr.Error()))
+		return err
+	}
+
+	return call(metadata.AppendToOutgoingContext(ctx, key, prefix+source.Token()))
+}
+
+// m2mTokenRejected reports whether SDK provider rejected M2M token. Other unauthenticated errors
+// come from handlers and must not cause token refresh and replay of request.
+func m2mTokenRejected(err error) bool {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.Unauthenticated {
+		return false
 	}
 
-	return metadata.AppendToOutgoingContext(ctx, m2mTokenMetadataKey, i.m2mTokenSource.Token())
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok && info.GetReason() == domain.M2MTokenRejectedReason {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isUnauthenticated reports whether transcoded gRPC service rejected M2M token. Such services
+// don't describe reason of rejection, so any unauthenticated error is treated as rejection.
+func isUnauthenticated(err error) bool {
+	return status.Code(err) == codes.Unauthenticated
 }
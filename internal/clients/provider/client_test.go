@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+func TestWithM2MToken(t *testing.T) {
+	t.Parallel()
+
+	rejectedToken, err := status.New(codes.Unauthenticated, "Failed to validate M2M token").WithDetails(&errdetails.ErrorInfo{
+		Reason: domain.M2MTokenRejectedReason,
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		rejected  func(err error) bool
+		err       error
+		refreshes int32
+		tokens    []string
+	}{
+		{name: "rejected token", rejected: m2mTokenRejected, err: rejectedToken.Err(), refreshes: 1, tokens: []string{"stale", "fresh"}},
+		{name: "unauthenticated handler", rejected: m2mTokenRejected, err: status.Error(codes.Unauthenticated, "user is unknown"), tokens: []string{"stale"}},
+		{name: "denied identity", rejected: m2mTokenRejected, err: status.Error(codes.PermissionDenied, "Failed to verify identity token"), tokens: []string{"stale"}},
+		{name: "unauthenticated transcoded service", rejected: isUnauthenticated, err: status.Error(codes.Unauthenticated, "invalid token"), refreshes: 1, tokens: []string{"stale", "fresh"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			source := &testTokenSource{}
+			source.token.Store("stale")
+
+			var tokens []string
+
+			err := withM2MToken(context.Background(), source, m2mTokenMetadataKey, "", tt.rejected, func(ctx context.Context) error {
+				md, _ := metadata.FromOutgoingContext(ctx)
+				tokens = append(tokens, md.Get(m2mTokenMetadataKey)...)
+
+				if len(tokens) == 1 {
+					return tt.err
+				}
+
+				return nil
+			})
+
+			assert.Equal(t, tt.tokens, tokens)
+			assert.Equal(t, tt.refreshes, source.refreshes.Load())
+
+			if tt.refreshes == 0 {
+				assert.Equal(t, status.Code(tt.err), status.Code(err))
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
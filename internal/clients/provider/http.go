@@ -0,0 +1,264 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	mapset "github.com/deckarep/golang-set/v2"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/m2m"
+)
+
+const (
+	defaultPathRewrite = "/{method}/{path}"
+
+	maxResponseBodySize = 10 << 20 // 10mb
+
+	subjectIDHeader          = "X-Subject-Id"
+	subjectPermissionsHeader = "X-Subject-Permissions"
+	identityTokenHeader      = "X-Identity-Token"
+	userTokenHeader          = "X-User-Token"
+	priorityHeader           = "X-Request-Priority"
+)
+
+// hopByHopHeaders are meaningful only for single connection and must not be proxied (RFC 9110).
+var hopByHopHeaders = []string{
+	"Connection", "Keep-Alive", "Proxy-Authenticate", "Proxy-Authorization",
+	"Proxy-Connection", "Te", "Trailer", "Transfer-Encoding", "Upgrade",
+}
+
+// gatewayHeaders are set by gateway only, so callers can't spoof them.
+var gatewayHeaders = []string{
+	"Authorization", subjectIDHeader, subjectPermissionsHeader, identityTokenHeader, userTokenHeader, priorityHeader,
+}
+
+type httpImpl struct {
+	baseURL        *url.URL
+	pathRewrite    string
+	hostHeader     string
+	timeout        time.Duration
+	client         *http.Client
+	m2mTokenSource m2m.Source
+}
+
+// HTTPOptions ...
+type HTTPOptions struct {
+	// BaseURL of upstream, e.g. http://legacy:8080/api.
+	BaseURL string
+	// PathRewrite is a template of upstream path with {method} and {path} placeholders, path is appended to BaseURL.
+	PathRewrite string
+	// HostHeader overrides Host header sent to upstream.
+	HostHeader          string
+	M2MTokenSource      m2m.Source
+	OperationTimeout    time.Duration
+	MaxIdleConns        int
+	MaxIdleConnsPerHost int
+	IdleConnTimeout     time.Duration
+}
+
+func (opts *HTTPOptions) setDefaults() {
+	if opts.PathRewrite == "" {
+		opts.PathRewrite = defaultPathRewrite
+	}
+}
+
+// NewHTTP returns new Client that proxies requests to plain HTTP upstream.
+// HTTP upstreams have no description, so it must be declared in config.
+func NewHTTP(opts HTTPOptions) (Client, error) {
+	opts.setDefaults()
+
+	baseURL, err := url.Parse(opts.BaseURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone() //nolint:forcetypeassert
+	if opts.MaxIdleConns > 0 {
+		transport.MaxIdleConns = opts.MaxIdleConns
+	}
+
+	if opts.MaxIdleConnsPerHost > 0 {
+		transport.MaxIdleConnsPerHost = opts.MaxIdleConnsPerHost
+	}
+
+	if opts.IdleConnTimeout > 0 {
+		transport.IdleConnTimeout = opts.IdleConnTimeout
+	}
+
+	return &httpImpl{
+		baseURL:     baseURL,
+		pathRewrite: opts.PathRewrite,
+		hostHeader:  opts.HostHeader,
+		timeout:     opts.OperationTimeout,
+		client: &http.Client{
+			Transport: transport,
+			// redirects are returned to caller as is.
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		m2mTokenSource: opts.M2MTokenSource,
+	}, nil
+}
+
+var (
+	errDescriptionNotSupported = errors.New("HTTP upstream has no description, declare it in config")
+	errInvalidPath             = errors.New("path must not contain dot segments")
+)
+
+func (h *httpImpl) Description(context.Context) (*domain.ProviderDescription, error) {
+	return nil, errDescriptionNotSupported
+}
+
+func (h *httpImpl) Process(ctx context.Context, req *domain.ProviderProcessRequest) (*domain.ProviderProcessResponse, error) {
+	upstreamURL, err := h.upstreamURL(req)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, err.Error())
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	resp, err := h.do(ctx, upstreamURL, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusUnauthorized && h.m2mTokenSource != nil {
+		// retry with the same token would be rejected again, so original response is returned.
+		if refreshErr := h.m2mTokenSource.Refresh(ctx); refreshErr != nil {
+			slog.Warn("Failed to refresh rejected m2m token", slog.String("err", refreshErr.Error()))
+		} else {
+			resp.Body.Close() //nolint:errcheck,gosec
+
+			if resp, err = h.do(ctx, upstreamURL, req); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if len(body) > maxResponseBodySize {
+		return nil, fmt.Errorf("response body exceeds %d bytes", maxResponseBodySize)
+	}
+
+	headers := resp.Header.Clone()
+	removeHeaders(headers, hopByHopHeaders)
+
+	return &domain.ProviderProcessResponse{
+		Body:       body,
+		StatusCode: uint32(resp.StatusCode), //nolint:gosec
+		Headers:    headers,
+	}, nil
+}
+
+func (h *httpImpl) do(ctx context.Context, upstreamURL string, req *domain.ProviderProcessRequest) (*http.Response, error) {
+	method := strings.ToUpper(req.HTTPMethod.String())
+
+	httpReq, err := http.NewRequestWithContext(ctx, method, upstreamURL, bytes.NewReader(req.Body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new request: %w", err)
+	}
+
+	httpReq.Header = h.upstreamHeaders(req)
+
+	if h.hostHeader != "" {
+		httpReq.Host = h.hostHeader
+	}
+
+	resp, err := h.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to do request: %w", err)
+	}
+
+	return resp, nil
+}
+
+// upstreamURL returns URL of upstream, paths with dot segments are rejected so caller can't escape base URL.
+func (h *httpImpl) upstreamURL(req *domain.ProviderProcessRequest) (string, error) {
+	path := strings.NewReplacer("{method}", req.APIMethod, "{path}", req.Path).Replace(h.pathRewrite)
+	for _, segment := range strings.FieldsFunc(path, func(r rune) bool { return r == '/' || r == '\\' }) {
+		if segment == "." || segment == ".." {
+			return "", errInvalidPath
+		}
+	}
+
+	u := *h.baseURL
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + strings.TrimPrefix(path, "/")
+	u.RawPath = ""
+	u.RawQuery = req.Query
+
+	return u.String(), nil
+}
+
+func (h *httpImpl) upstreamHeaders(req *domain.ProviderProcessRequest) http.Header {
+	headers := req.Headers.Clone()
+	if headers == nil {
+		headers = make(http.Header)
+	}
+
+	removeHeaders(headers, hopByHopHeaders)
+	removeHeaders(headers, gatewayHeaders)
+
+	if h.m2mTokenSource != nil {
+		headers.Set("Authorization", "Bearer "+h.m2mTokenSource.Token())
+	}
+
+	if subject := req.SubjectInformation; subject != nil && !subject.Anonymous {
+		headers.Set(subjectIDHeader, subject.ID)
+
+		if subject.Permissions != nil && subject.Permissions.Cardinality() != 0 {
+			headers.Set(subjectPermissionsHeader, strings.Join(sortedPermissions(subject.Permissions), ","))
+		}
+	}
+
+	if req.IdentityToken != "" {
+		headers.Set(identityTokenHeader, req.IdentityToken)
+	}
+
+	if req.UserToken != "" {
+		headers.Set(userTokenHeader, req.UserToken)
+	}
+
+	if req.Priority != domain.PriorityUnspecified {
+		headers.Set(priorityHeader, req.Priority.String())
+	}
+
+	return headers
+}
+
+func removeHeaders(headers http.Header, names []string) {
+	// headers listed in Connection are hop-by-hop too.
+	for _, value := range headers.Values("Connection") {
+		for _, name := range strings.Split(value, ",") {
+			headers.Del(strings.TrimSpace(name))
+		}
+	}
+
+	for _, name := range names {
+		headers.Del(name)
+	}
+}
+
+func sortedPermissions(permissions mapset.Set[string]) []string {
+	result := permissions.ToSlice()
+	slices.Sort(result)
+
+	return result
+}
@@ -0,0 +1,194 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+type testTokenSource struct {
+	token      atomic.Value
+	refreshes  atomic.Int32
+	refreshErr error
+}
+
+func (s *testTokenSource) Token() string {
+	return s.token.Load().(string) //nolint:forcetypeassert
+}
+
+func (s *testTokenSource) Refresh(context.Context) error {
+	s.refreshes.Add(1)
+
+	if s.refreshErr != nil {
+		return s.refreshErr
+	}
+
+	s.token.Store("fresh")
+
+	return nil
+}
+
+func TestHTTPProcess(t *testing.T) {
+	t.Parallel()
+
+	type received struct {
+		method, uri, host, authorization, subjectID, permissions, body string
+		custom                                                         string
+	}
+
+	var last atomic.Pointer[received]
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body) //nolint:errcheck
+
+		last.Store(&received{
+			method:        r.Method,
+			uri:           r.URL.RequestURI(),
+			host:          r.Host,
+			authorization: r.Header.Get("Authorization"),
+			subjectID:     r.Header.Get(subjectIDHeader),
+			permissions:   r.Header.Get(subjectPermissionsHeader),
+			body:          string(body),
+			custom:        r.Header.Get("X-Custom"),
+		})
+
+		if r.Header.Get("Authorization") != "Bearer fresh" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Header().Set("X-Result", "ok")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("created")) //nolint:errcheck
+	}))
+	t.Cleanup(srv.Close)
+
+	tokenSource := &testTokenSource{}
+	tokenSource.token.Store("stale")
+
+	client, err := NewHTTP(HTTPOptions{
+		BaseURL:          srv.URL + "/api/",
+		PathRewrite:      "/v1/{method}/{path}",
+		HostHeader:       "legacy.internal",
+		M2MTokenSource:   tokenSource,
+		OperationTimeout: time.Second,
+	})
+	require.NoError(t, err)
+
+	resp, err := client.Process(context.Background(), &domain.ProviderProcessRequest{
+		APIMethod:  "orders",
+		HTTPMethod: domain.HTTPMethodPost,
+		Path:       "42/items",
+		Query:      "limit=10",
+		Body:       []byte("payload"),
+		Headers: http.Header{
+			"Connection":    {"X-Custom"},
+			"X-Custom":      {"hop"},
+			subjectIDHeader: {"spoofed"},
+		},
+		SubjectInformation: &domain.SubjectInformation{
+			ID:          "user-1",
+			Permissions: mapset.NewSet("write", "read"),
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, uint32(http.StatusCreated), resp.StatusCode)
+	assert.Equal(t, "created", string(resp.Body))
+	assert.Equal(t, "ok", resp.Headers.Get("X-Result"))
+	assert.Equal(t, int32(1), tokenSource.refreshes.Load())
+
+	assert.Equal(t, &received{
+		method:        http.MethodPost,
+		uri:           "/api/v1/orders/42/items?limit=10",
+		host:          "legacy.internal",
+		authorization: "Bearer fresh",
+		subjectID:     "user-1",
+		permissions:   "read,write",
+		body:          "payload",
+	}, last.Load())
+}
+
+func TestHTTPProcessKeepsUnauthorizedWhenRefreshFails(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte("token expired")) //nolint:errcheck
+	}))
+	t.Cleanup(srv.Close)
+
+	tokenSource := &testTokenSource{refreshErr: errors.New("auth0 is unavailable")}
+	tokenSource.token.Store("stale")
+
+	client, err := NewHTTP(HTTPOptions{
+		BaseURL:          srv.URL,
+		M2MTokenSource:   tokenSource,
+		OperationTimeout: time.Second,
+	})
+	require.NoError(t, err)
+
+	resp, err := client.Process(context.Background(), &domain.ProviderProcessRequest{
+		APIMethod:  "orders",
+		HTTPMethod: domain.HTTPMethodGet,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, uint32(http.StatusUnauthorized), resp.StatusCode)
+	assert.Equal(t, "token expired", string(resp.Body))
+	assert.Equal(t, int32(1), tokenSource.refreshes.Load())
+	assert.Equal(t, int32(1), requests.Load(), "request must not be replayed with rejected token")
+}
+
+func TestHTTPDescriptionNotSupported(t *testing.T) {
+	t.Parallel()
+
+	client, err := NewHTTP(HTTPOptions{BaseURL: "http://localhost", OperationTimeout: time.Second})
+	require.NoError(t, err)
+
+	_, err = client.Description(context.Background())
+	require.ErrorIs(t, err, errDescriptionNotSupported)
+}
+
+func TestHTTPProcessRejectsTraversal(t *testing.T) {
+	t.Parallel()
+
+	var calls atomic.Int32
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	client, err := NewHTTP(HTTPOptions{BaseURL: srv.URL + "/api/public", OperationTimeout: time.Second})
+	require.NoError(t, err)
+
+	for _, req := range []*domain.ProviderProcessRequest{
+		{APIMethod: "books", Path: "../../admin/drop"},
+		{APIMethod: "..", Path: "admin/drop"},
+		{APIMethod: "books", Path: "42/./../../admin"},
+		{APIMethod: "books", Path: `..\..\admin`},
+	} {
+		resp, err := client.Process(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, uint32(http.StatusBadRequest), resp.StatusCode, req.Path)
+	}
+
+	assert.Zero(t, calls.Load())
+}
@@ -0,0 +1,214 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/identity"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/m2m"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/transcoding"
+)
+
+const authorizationMetadataKey = "authorization"
+
+type transcodingImpl struct {
+	timeout        time.Duration
+	conn           *grpc.ClientConn
+	service        string
+	descriptorSet  string
+	m2mTokenSource m2m.Source
+
+	mu         sync.Mutex
+	transcoder *transcoding.Transcoder
+}
+
+// TranscodingOptions ...
+type TranscodingOptions struct {
+	Name    string
+	Address string
+	// Service is a full name of gRPC service, e.g. library.v1.LibraryService.
+	Service string
+	// DescriptorSet is a path to FileDescriptorSet, server reflection is used when empty.
+	DescriptorSet    string
+	M2MTokenSource   m2m.Source
+	OperationTimeout time.Duration
+}
+
+// NewTranscoding returns new Client that transcodes JSON requests to native gRPC service.
+// Service has no description, so it must be declared in config.
+func NewTranscoding(opts TranscodingOptions) (Client, error) {
+	conn, err := grpc.NewClient(
+		opts.Address,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(
+			clMetrics.UnaryClientInterceptor(),
+		),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("could not create client: %w", err)
+	}
+
+	t := &transcodingImpl{
+		timeout:        opts.OperationTimeout,
+		conn:           conn,
+		service:        opts.Service,
+		descriptorSet:  opts.DescriptorSet,
+		m2mTokenSource: opts.M2MTokenSource,
+	}
+
+	// descriptor set is local, so broken one is reported at start.
+	if opts.DescriptorSet != "" {
+		if _, err = t.getTranscoder(context.Background()); err != nil {
+			return nil, err
+		}
+	}
+
+	return t, nil
+}
+
+func (t *transcodingImpl) Description(context.Context) (*domain.ProviderDescription, error) {
+	return nil, errDescriptionNotSupported
+}
+
+// getTranscoder returns transcoder, service is resolved by reflection on first use and on failures.
+func (t *transcodingImpl) getTranscoder(ctx context.Context) (*transcoding.Transcoder, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.transcoder != nil {
+		return t.transcoder, nil
+	}
+
+	var (
+		service protoreflect.ServiceDescriptor
+		err     error
+	)
+
+	if t.descriptorSet != "" {
+		service, err = transcoding.LoadDescriptorSet(t.descriptorSet, t.service)
+	} else {
+		err = withM2MToken(ctx, t.m2mTokenSource, authorizationMetadataKey, "Bearer ", isUnauthenticated, func(ctx context.Context) (err error) {
+			service, err = transcoding.Reflect(ctx, t.conn, t.service)
+			return err
+		})
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("could not resolve service %s: %w", t.service, err)
+	}
+
+	if t.transcoder, err = transcoding.New(service); err != nil {
+		return nil, err
+	}
+
+	return t.transcoder, nil
+}
+
+func (t *transcodingImpl) Process(ctx context.Context, req *domain.ProviderProcessRequest) (*domain.ProviderProcessResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	transcoder, err := t.getTranscoder(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	call, err := transcoder.NewCall(strings.ToUpper(req.HTTPMethod.String()), transcodingPath(req.APIMethod, req.Path), req.Query, req.Body)
+
+	switch {
+	case errors.Is(err, transcoding.ErrNotFound):
+		return errorResponse(http.StatusNotFound, err.Error())
+	case errors.Is(err, transcoding.ErrInvalidRequest):
+		return errorResponse(http.StatusBadRequest, err.Error())
+	case err != nil:
+		return nil, err
+	}
+
+	if req.IdentityToken != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, identity.MetadataKey, req.IdentityToken)
+	}
+
+	if req.UserToken != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, UserTokenMetadataKey, req.UserToken)
+	}
+
+	if req.Priority != domain.PriorityUnspecified {
+		ctx = metadata.AppendToOutgoingContext(ctx, PriorityMetadataKey, req.Priority.String())
+	}
+
+	err = withM2MToken(ctx, t.m2mTokenSource, authorizationMetadataKey, "Bearer ", isUnauthenticated, func(ctx context.Context) error {
+		return t.conn.Invoke(ctx, call.FullMethod, call.Request, call.Response)
+	})
+	if err != nil {
+		st, ok := status.FromError(err)
+		if !ok {
+			return nil, fmt.Errorf("could not invoke %s: %w", call.FullMethod, err)
+		}
+
+		return errorResponse(transcoding.HTTPStatusFromCode(st.Code()), st.Message())
+	}
+
+	body, err := call.MarshalResponse()
+	if err != nil {
+		return nil, fmt.Errorf("could not marshal response: %w", err)
+	}
+
+	return jsonResponse(http.StatusOK, body), nil
+}
+
+// ResolveMethod returns name of gRPC method that serves request routed by first path segment apiMethod.
+func (t *transcodingImpl) ResolveMethod(ctx context.Context, httpMethod domain.HTTPMethod, apiMethod, path string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+
+	transcoder, err := t.getTranscoder(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	method, err := transcoder.Method(strings.ToUpper(httpMethod.String()), transcodingPath(apiMethod, path))
+	if errors.Is(err, transcoding.ErrNotFound) {
+		return "", fmt.Errorf("%w: %w", ErrMethodNotFound, err)
+	}
+
+	return method, err
+}
+
+// transcodingPath returns path below service, e.g. /v1/books/1 for API method v1 and path books/1.
+func transcodingPath(apiMethod, path string) string {
+	if path == "" {
+		return "/" + apiMethod
+	}
+
+	return "/" + apiMethod + "/" + path
+}
+
+func errorResponse(statusCode int, message string) (*domain.ProviderProcessResponse, error) {
+	body, err := json.Marshal(map[string]any{"message": message})
+	if err != nil {
+		return nil, err
+	}
+
+	return jsonResponse(statusCode, body), nil
+}
+
+func jsonResponse(statusCode int, body []byte) *domain.ProviderProcessResponse {
+	return &domain.ProviderProcessResponse{
+		Body:       body,
+		StatusCode: uint32(statusCode), //nolint:gosec
+		Headers:    http.Header{"Content-Type": {"application/json"}},
+	}
+}
@@ -8,11 +8,13 @@ import (
 	"github.com/redis/go-redis/v9"
 )
 
-// New returns new redis.Client with ping.
-func New(ctx context.Context, address, password string) (*redis.Client, error) {
+// New returns new redis.Client with ping, password is requested on every new connection, so it can be rotated.
+func New(ctx context.Context, address string, password func() string) (*redis.Client, error) {
 	c := redis.NewClient(&redis.Options{
-		Addr:     address,
-		Password: password,
+		Addr: address,
+		CredentialsProvider: func() (string, string) {
+			return "", password()
+		},
 	})
 
 	pingCtx, cancel := context.WithTimeout(ctx, time.Second*5)
@@ -0,0 +1,84 @@
+package concurrency
+
+import (
+	"context"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+// Slots are taken by request in limiters of method and service.
+type Slots struct {
+	tokens []*Token
+}
+
+// Release frees slots, failed is true when provider failed.
+func (s *Slots) Release(failed bool) {
+	if s == nil {
+		return
+	}
+
+	for _, token := range s.tokens {
+		token.Release(failed)
+	}
+
+	s.tokens = nil
+}
+
+// Cancel frees slots without taking request into account, e.g. when it was rejected before reaching provider.
+// It does nothing after Release.
+func (s *Slots) Cancel() {
+	if s == nil {
+		return
+	}
+
+	for _, token := range s.tokens {
+		token.Cancel()
+	}
+
+	s.tokens = nil
+}
+
+// Group limits requests to service and its methods, request takes slots of both.
+type Group struct {
+	service *Limiter
+	methods map[string]*Limiter
+}
+
+// NewGroup returns new Group, service limiter may be nil.
+func NewGroup(service *Limiter, methods map[string]*Limiter) *Group {
+	return &Group{
+		service: service,
+		methods: methods,
+	}
+}
+
+// Acquire takes slots of method and service. Slot of method is taken first,
+// so requests queued by busy method don't hold slots of service.
+func (g *Group) Acquire(ctx context.Context, method string, priority domain.Priority) (*Slots, error) {
+	limiters := make([]*Limiter, 0, 2)
+
+	if limiter, ok := g.methods[method]; ok {
+		limiters = append(limiters, limiter)
+	}
+
+	if g.service != nil {
+		limiters = append(limiters, g.service)
+	}
+
+	tokens := make([]*Token, 0, len(limiters))
+
+	for _, limiter := range limiters {
+		token, err := limiter.Acquire(ctx, priority)
+		if err != nil {
+			for _, taken := range tokens {
+				taken.Cancel()
+			}
+
+			return nil, err
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return &Slots{tokens: tokens}, nil
+}
@@ -0,0 +1,289 @@
+package concurrency
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+const (
+	// backoffRatio cuts adaptive limit when provider slows down.
+	backoffRatio = 0.9
+	// baselineWindow is a window of minimal latency, baseline follows provider with delay up to two windows.
+	baselineWindow = 30 * time.Second
+)
+
+// ErrLimitExceeded is returned when request is shed: queue is full or wait in queue timed out.
+var ErrLimitExceeded = errors.New("concurrency limit exceeded")
+
+// Options ...
+type Options struct {
+	// Service and Method are labels of metrics, Method is empty for limiter of service.
+	Service string
+	Method  string
+	// MaxInFlight is a limit of concurrent requests, the upper bound of adaptive limit.
+	MaxInFlight int
+	// MaxQueue is a number of requests waiting for free slot, requests are shed at once when zero.
+	MaxQueue     int
+	QueueTimeout time.Duration
+	// Adaptive enables AIMD limit: it grows by one per limit of completed requests and is cut
+	// when request fails or its latency exceeds LatencyTolerance times the baseline latency.
+	Adaptive         bool
+	MinInFlight      int
+	LatencyTolerance float64
+}
+
+// Limiter bounds in-flight requests, excess requests wait in queue.
+type Limiter struct {
+	opts Options
+	now  func() time.Time
+
+	mu          sync.Mutex
+	limit       float64
+	inFlight    int
+	queues      [domain.PriorityCritical + 1]list.List
+	queued      int
+	baseline    baseline
+	decreasedAt time.Time
+
+	inFlightGauge   prometheus.Gauge
+	queueDepthGauge prometheus.Gauge
+	limitGauge      prometheus.Gauge
+}
+
+// New returns new Limiter.
+func New(opts Options) *Limiter {
+	return newLimiter(opts, time.Now)
+}
+
+func newLimiter(opts Options, now func() time.Time) *Limiter {
+	l := &Limiter{
+		opts:            opts,
+		now:             now,
+		limit:           float64(opts.MaxInFlight),
+		inFlightGauge:   inFlightGauge.WithLabelValues(opts.Service, opts.Method),
+		queueDepthGauge: queueDepthGauge.WithLabelValues(opts.Service, opts.Method),
+		limitGauge:      limitGauge.WithLabelValues(opts.Service, opts.Method),
+	}
+
+	l.observe()
+
+	return l
+}
+
+// Token is a slot taken by request.
+type Token struct {
+	limiter   *Limiter
+	startedAt time.Time
+}
+
+type waiter struct {
+	done chan struct{}
+	// granted is false when waiter was evicted by request of higher priority.
+	granted bool
+}
+
+// Acquire takes slot, waiting in queue when all slots are taken. Queued requests of higher priority
+// take slots first and evict queued requests of lower priority when queue is full.
+// Low priority requests may fill only half of queue.
+func (l *Limiter) Acquire(ctx context.Context, priority domain.Priority) (*Token, error) {
+	if priority == domain.PriorityUnspecified {
+		priority = domain.PriorityNormal
+	}
+
+	l.mu.Lock()
+
+	if l.queued == 0 && l.inFlight < l.currentLimit() {
+		l.inFlight++
+		l.observe()
+		l.mu.Unlock()
+
+		return l.newToken(), nil
+	}
+
+	if l.queued >= l.maxQueue(priority) && !l.evict(priority) {
+		l.mu.Unlock()
+		l.shed(priority, shedReasonQueueFull)
+
+		return nil, ErrLimitExceeded
+	}
+
+	w := &waiter{done: make(chan struct{})}
+	elem := l.queues[priority].PushBack(w)
+	l.queued++
+	l.observe()
+	l.mu.Unlock()
+
+	timer := time.NewTimer(l.opts.QueueTimeout)
+	defer timer.Stop()
+
+	var err error
+
+	select {
+	case <-w.done:
+		return l.waited(w, priority)
+	case <-timer.C:
+		err = ErrLimitExceeded
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	select {
+	case <-w.done:
+		// waiter was granted or evicted together with timeout.
+		return l.waited(w, priority)
+	default:
+	}
+
+	l.queues[priority].Remove(elem)
+	l.queued--
+	l.observe()
+
+	if errors.Is(err, ErrLimitExceeded) {
+		l.shed(priority, shedReasonQueueTimeout)
+	}
+
+	return nil, err
+}
+
+// Release frees slot, failed is true when provider failed, it cuts adaptive limit.
+func (t *Token) Release(failed bool) {
+	t.limiter.release(t.limiter.now().Sub(t.startedAt), failed, true)
+}
+
+// Cancel frees slot without taking request into account, e.g. when it was rejected by another limiter.
+func (t *Token) Cancel() {
+	t.limiter.release(0, false, false)
+}
+
+func (l *Limiter) newToken() *Token {
+	return &Token{limiter: l, startedAt: l.now()}
+}
+
+func (l *Limiter) waited(w *waiter, priority domain.Priority) (*Token, error) {
+	if !w.granted {
+		l.shed(priority, shedReasonEvicted)
+		return nil, ErrLimitExceeded
+	}
+
+	return l.newToken(), nil
+}
+
+func (l *Limiter) maxQueue(priority domain.Priority) int {
+	if priority == domain.PriorityLow {
+		return l.opts.MaxQueue / 2
+	}
+
+	return l.opts.MaxQueue
+}
+
+// evict removes the newest queued request of the lowest priority that is lower than given one.
+func (l *Limiter) evict(priority domain.Priority) bool {
+	for p := domain.PriorityLow; p < priority; p++ {
+		if l.queues[p].Len() == 0 {
+			continue
+		}
+
+		w := l.queues[p].Remove(l.queues[p].Back()).(*waiter) //nolint:forcetypeassert
+		l.queued--
+		close(w.done)
+
+		return true
+	}
+
+	return false
+}
+
+func (l *Limiter) release(latency time.Duration, failed, completed bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if completed && l.opts.Adaptive {
+		l.adapt(latency, failed)
+	}
+
+	l.inFlight--
+
+	// queued requests take freed slots by priority, then in order of arrival.
+	for p := domain.PriorityCritical; p >= domain.PriorityLow && l.inFlight < l.currentLimit(); {
+		if l.queues[p].Len() == 0 {
+			p--
+			continue
+		}
+
+		w := l.queues[p].Remove(l.queues[p].Front()).(*waiter) //nolint:forcetypeassert
+		w.granted = true
+		l.queued--
+		l.inFlight++
+		close(w.done)
+	}
+
+	l.observe()
+}
+
+// adapt applies AIMD: limit grows by 1/limit per completed request of busy limiter, so by one per limit
+// of requests, and is cut by backoffRatio at most once per latency, so one burst of slow requests cuts it once.
+func (l *Limiter) adapt(latency time.Duration, failed bool) {
+	now := l.now()
+	baseline := l.baseline.observe(now, latency)
+
+	if failed || float64(latency) > float64(baseline)*l.opts.LatencyTolerance {
+		if now.Sub(l.decreasedAt) >= latency {
+			l.limit = max(float64(l.opts.MinInFlight), l.limit*backoffRatio)
+			l.decreasedAt = now
+		}
+
+		return
+	}
+
+	// limit that is not reached tells nothing about capacity of provider.
+	if 2*l.inFlight >= l.currentLimit() {
+		l.limit = min(float64(l.opts.MaxInFlight), l.limit+1/l.limit)
+	}
+}
+
+func (l *Limiter) currentLimit() int {
+	return int(l.limit)
+}
+
+func (l *Limiter) observe() {
+	l.inFlightGauge.Set(float64(l.inFlight))
+	l.queueDepthGauge.Set(float64(l.queued))
+	l.limitGauge.Set(float64(l.currentLimit()))
+}
+
+func (l *Limiter) shed(priority domain.Priority, reason string) {
+	shedCount.WithLabelValues(l.opts.Service, l.opts.Method, priority.String(), reason).Inc()
+}
+
+// baseline is a minimal latency of current and previous windows.
+type baseline struct {
+	windowStart time.Time
+	current     time.Duration
+	previous    time.Duration
+}
+
+func (b *baseline) observe(now time.Time, latency time.Duration) time.Duration {
+	if now.Sub(b.windowStart) >= baselineWindow {
+		b.previous, b.current, b.windowStart = b.current, 0, now
+	}
+
+	if b.current == 0 || latency < b.current {
+		b.current = latency
+	}
+
+	if b.previous != 0 && b.previous < b.current {
+		return b.previous
+	}
+
+	return b.current
+}
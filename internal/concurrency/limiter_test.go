@@ -0,0 +1,206 @@
+package concurrency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+func TestLimiterQueue(t *testing.T) {
+	t.Parallel()
+
+	limiter := New(Options{Service: "test-queue", MaxInFlight: 1, MaxQueue: 1, QueueTimeout: time.Minute})
+	ctx := context.Background()
+
+	first, err := limiter.Acquire(ctx, domain.PriorityNormal)
+	require.NoError(t, err)
+
+	acquired := make(chan *Token)
+
+	go func() {
+		token, err := limiter.Acquire(ctx, domain.PriorityNormal)
+		assert.NoError(t, err)
+		acquired <- token
+	}()
+
+	require.Eventually(t, func() bool {
+		limiter.mu.Lock()
+		defer limiter.mu.Unlock()
+
+		return limiter.queued == 1
+	}, time.Second, time.Millisecond)
+
+	// queue is full.
+	_, err = limiter.Acquire(ctx, domain.PriorityNormal)
+	require.ErrorIs(t, err, ErrLimitExceeded)
+
+	first.Release(false)
+
+	second := <-acquired
+	require.NotNil(t, second)
+	second.Release(false)
+
+	assert.Equal(t, 0, limiter.inFlight)
+}
+
+func TestLimiterQueueTimeout(t *testing.T) {
+	t.Parallel()
+
+	limiter := New(Options{Service: "test-timeout", MaxInFlight: 1, MaxQueue: 1, QueueTimeout: time.Millisecond})
+
+	token, err := limiter.Acquire(context.Background(), domain.PriorityNormal)
+	require.NoError(t, err)
+
+	_, err = limiter.Acquire(context.Background(), domain.PriorityNormal)
+	require.ErrorIs(t, err, ErrLimitExceeded)
+	assert.Equal(t, 0, limiter.queued)
+
+	token.Release(false)
+}
+
+func TestLimiterPriority(t *testing.T) {
+	t.Parallel()
+
+	limiter := New(Options{Service: "test-priority", MaxInFlight: 1, MaxQueue: 2, QueueTimeout: time.Minute})
+	ctx := context.Background()
+
+	token, err := limiter.Acquire(ctx, domain.PriorityNormal)
+	require.NoError(t, err)
+
+	type result struct {
+		priority domain.Priority
+		err      error
+	}
+
+	results := make(chan result, 3)
+	enqueue := func(priority domain.Priority, queued int) {
+		go func() {
+			token, err := limiter.Acquire(ctx, priority)
+			results <- result{priority: priority, err: err}
+
+			// slot is released after result is sent, so results come in order of service.
+			if err == nil {
+				token.Release(false)
+			}
+		}()
+
+		require.Eventually(t, func() bool {
+			limiter.mu.Lock()
+			defer limiter.mu.Unlock()
+
+			return limiter.queued == queued
+		}, time.Second, time.Millisecond)
+	}
+
+	enqueue(domain.PriorityLow, 1)
+
+	// low priority fills only half of queue.
+	_, err = limiter.Acquire(ctx, domain.PriorityLow)
+	require.ErrorIs(t, err, ErrLimitExceeded)
+
+	enqueue(domain.PriorityNormal, 2)
+
+	// full queue: critical request evicts low one.
+	enqueue(domain.PriorityCritical, 2)
+
+	evicted := <-results
+	assert.Equal(t, domain.PriorityLow, evicted.priority)
+	require.ErrorIs(t, evicted.err, ErrLimitExceeded)
+
+	token.Release(false)
+
+	for _, priority := range []domain.Priority{domain.PriorityCritical, domain.PriorityNormal} {
+		served := <-results
+		assert.Equal(t, priority, served.priority)
+		assert.NoError(t, served.err)
+	}
+}
+
+func TestLimiterAdaptive(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	limiter := newLimiter(Options{
+		Service:          "test-adaptive",
+		MaxInFlight:      10,
+		Adaptive:         true,
+		MinInFlight:      2,
+		LatencyTolerance: 2,
+	}, func() time.Time { return now })
+
+	complete := func(latency time.Duration, failed bool) {
+		token, err := limiter.Acquire(context.Background(), domain.PriorityNormal)
+		require.NoError(t, err)
+
+		now = now.Add(latency)
+		token.Release(failed)
+	}
+
+	complete(10*time.Millisecond, false)
+	assert.Equal(t, 10, limiter.currentLimit())
+
+	// slow request cuts limit once per latency.
+	complete(100*time.Millisecond, false)
+	assert.Equal(t, 9, limiter.currentLimit())
+
+	complete(100*time.Millisecond, true)
+	assert.Equal(t, 8, limiter.currentLimit())
+
+	for range 20 {
+		now = now.Add(time.Second)
+		complete(time.Second, true)
+	}
+
+	assert.Equal(t, 2, limiter.currentLimit())
+
+	// limit grows by one per limit of requests, only when it is reached.
+	for range 2 {
+		first, err := limiter.Acquire(context.Background(), domain.PriorityNormal)
+		require.NoError(t, err)
+
+		second, err := limiter.Acquire(context.Background(), domain.PriorityNormal)
+		require.NoError(t, err)
+
+		now = now.Add(10 * time.Millisecond)
+		first.Release(false)
+		second.Release(false)
+	}
+
+	assert.Equal(t, 3, limiter.currentLimit())
+}
+
+func TestGroupCancelsTakenSlots(t *testing.T) {
+	t.Parallel()
+
+	service := New(Options{Service: "test-group", MaxInFlight: 1})
+	method := New(Options{Service: "test-group", Method: "report", MaxInFlight: 2})
+	group := NewGroup(service, map[string]*Limiter{"report": method})
+
+	slots, err := group.Acquire(context.Background(), "report", domain.PriorityNormal)
+	require.NoError(t, err)
+
+	_, err = group.Acquire(context.Background(), "report", domain.PriorityNormal)
+	require.ErrorIs(t, err, ErrLimitExceeded)
+	assert.Equal(t, 1, method.inFlight)
+
+	slots.Release(false)
+	assert.Equal(t, 0, method.inFlight)
+	assert.Equal(t, 0, service.inFlight)
+
+	// cancel after release must not free slots of other requests.
+	slots, err = group.Acquire(context.Background(), "report", domain.PriorityNormal)
+	require.NoError(t, err)
+
+	other, err := group.Acquire(context.Background(), "other", domain.PriorityNormal)
+	require.ErrorIs(t, err, ErrLimitExceeded)
+	assert.Nil(t, other)
+
+	slots.Release(false)
+	slots.Cancel()
+	assert.Equal(t, 0, service.inFlight)
+}
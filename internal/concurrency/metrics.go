@@ -0,0 +1,46 @@
+package concurrency
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const (
+	shedReasonQueueFull    = "queue_full"
+	shedReasonQueueTimeout = "queue_timeout"
+	shedReasonEvicted      = "evicted"
+)
+
+var (
+	inFlightGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "concurrency_limiter_in_flight",
+			Help: "The number of in-flight requests to provider",
+		},
+		[]string{"service", "method"},
+	)
+
+	queueDepthGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "concurrency_limiter_queue_depth",
+			Help: "The number of requests waiting for free slot",
+		},
+		[]string{"service", "method"},
+	)
+
+	limitGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "concurrency_limiter_limit",
+			Help: "The current limit of in-flight requests, changes in adaptive mode",
+		},
+		[]string{"service", "method"},
+	)
+
+	shedCount = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "concurrency_limiter_shed_count",
+			Help: "The total number of requests rejected by concurrency limiter",
+		},
+		[]string{"service", "method", "priority", "reason"},
+	)
+)
@@ -1,25 +1,65 @@
 package config
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
 
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/secrets"
+)
+
+// Format of config file.
+type Format string
+
+// Supported formats.
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+	FormatTOML Format = "toml"
 )
 
-// FromFile returns config from file.
+// FormatFromPath returns format by file extension, JSON is used for unknown extensions.
+func FormatFromPath(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	case ".toml":
+		return FormatTOML
+	default:
+		return FormatJSON
+	}
+}
+
+// FromFile returns config from file with GATEWAY_* environment variables overrides.
 func FromFile(path string) (*domain.Config, error) {
 	fileContent, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
-	var cfg domain.Config
-	if err = json.Unmarshal(fileContent, &cfg); err != nil {
+	raw, err := unmarshal(FormatFromPath(path), fileContent)
+	if err != nil {
 		return nil, fmt.Errorf("failed to unmarshal config file: %w", err)
 	}
 
+	if raw, err = applyEnvOverrides(raw, os.Environ()); err != nil {
+		return nil, fmt.Errorf("failed to apply environment overrides: %w", err)
+	}
+
+	var cfg domain.Config
+	if err = decode(raw, &cfg); err != nil {
+		return nil, fmt.Errorf("failed to decode config file: %w", err)
+	}
+
 	cfg.SetDefaults()
 	if err = cfg.Validate(); err != nil {
 		return nil, fmt.Errorf("failed to validate config file: %w", err)
@@ -27,3 +67,59 @@ func FromFile(path string) (*domain.Config, error) {
 
 	return &cfg, nil
 }
+
+func unmarshal(format Format, data []byte) (map[string]any, error) {
+	raw := make(map[string]any)
+
+	switch format {
+	case FormatYAML:
+		if err := yaml.Unmarshal(data, &raw); err != nil {
+			return nil, err
+		}
+	case FormatTOML:
+		if err := toml.Unmarshal(data, &raw); err != nil {
+			return nil, err
+		}
+	default:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+
+		if err := decoder.Decode(&raw); err != nil {
+			return nil, err
+		}
+	}
+
+	return raw, nil
+}
+
+// Load returns config from file with resolved secret references.
+func Load(ctx context.Context, path string) (*domain.Config, error) {
+	cfg, err := FromFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	resolver := secrets.New()
+
+	if cfg.Secrets != nil && cfg.Secrets.Vault != nil {
+		vault := cfg.Secrets.Vault
+
+		token, err := resolver.Resolve(ctx, vault.Token.Value())
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve vault token: %w", err)
+		}
+
+		resolver.Register(secrets.SchemeVault, secrets.NewVault(secrets.VaultOptions{
+			Address:    vault.Address,
+			Token:      token,
+			Namespace:  vault.Namespace,
+			HTTPClient: &http.Client{Timeout: vault.Timeout},
+		}))
+	}
+
+	if err = resolver.ResolveAll(ctx, cfg); err != nil {
+		return nil, fmt.Errorf("failed to resolve secrets: %w", err)
+	}
+
+	return cfg, nil
+}
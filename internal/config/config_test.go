@@ -0,0 +1,97 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromFile(t *testing.T) {
+	for _, path := range []string{"testdata/config.json", "testdata/config.yaml", "testdata/config.toml"} {
+		t.Run(path, func(t *testing.T) {
+			cfg, err := FromFile(path)
+			require.NoError(t, err)
+
+			assert.Equal(t, "https://example.auth0.com/", cfg.Auth0Domain)
+			assert.Equal(t, "s3cr3t-value", cfg.Auth0ClientSecret.Value())
+			assert.Equal(t, 30*time.Second, cfg.DescriptionSyncPeriod)
+			require.Len(t, cfg.Services, 1)
+			assert.Equal(t, "greeting", cfg.Services[0].Name)
+			assert.Equal(t, 5*time.Second, cfg.Services[0].OperationTimeout)
+		})
+	}
+}
+
+func TestFromFileRejectsUnknownFields(t *testing.T) {
+	_, err := FromFile("testdata/unknown.json")
+	require.ErrorContains(t, err, "unknown field services[0].timeuot")
+}
+
+func TestEnvOverrides(t *testing.T) {
+	t.Setenv("GATEWAY_REDIS_ADDRESS", "redis:6379")
+	t.Setenv("GATEWAY_REVOCATION_ENABLED", "true")
+	t.Setenv("GATEWAY_SERVICES__0__TIMEOUT", "1m")
+	t.Setenv("GATEWAY_SERVICES__1__NAME", "orders")
+	t.Setenv("GATEWAY_SERVICES__1__ADDRESS", "127.0.0.1:8002")
+	t.Setenv("GATEWAY_INTROSPECTION__ENDPOINT", "https://example.com/introspect")
+	t.Setenv("GATEWAY_INTROSPECTION__CLIENT_ID", "introspection")
+	t.Setenv("GATEWAY_SERVICES__1__METHOD_CONCURRENCY__GetBook__MAX_IN_FLIGHT", "5")
+	// injected by Kubernetes for service named gateway.
+	t.Setenv("GATEWAY_PORT", "tcp://10.0.0.1:80")
+	t.Setenv("GATEWAY_SERVICE_HOST", "10.0.0.1")
+
+	cfg, err := FromFile("testdata/config.toml")
+	require.NoError(t, err)
+
+	assert.Equal(t, "redis:6379", cfg.RedisAddress)
+	assert.True(t, cfg.RevocationEnabled)
+	require.Len(t, cfg.Services, 2)
+	assert.Equal(t, time.Minute, cfg.Services[0].OperationTimeout)
+	assert.Equal(t, "orders", cfg.Services[1].Name)
+	require.Contains(t, cfg.Services[1].MethodConcurrency, "GetBook")
+	assert.Equal(t, 5, cfg.Services[1].MethodConcurrency["GetBook"].MaxInFlight)
+	assert.Equal(t, "https://example.com/introspect", cfg.Introspection.Endpoint)
+}
+
+func TestEnvOverridesListIndexOrder(t *testing.T) {
+	reversed := make([]string, 0, 11)
+	for i := 10; i >= 0; i-- {
+		reversed = append(reversed, fmt.Sprintf("GATEWAY_SERVICES__%d__NAME=service-%d", i, i))
+	}
+
+	// __10__ goes before __2__ in lexical order.
+	lexical := slices.Clone(reversed)
+	slices.Sort(lexical)
+
+	for _, environ := range [][]string{reversed, lexical} {
+		raw, err := applyEnvOverrides(map[string]any{}, environ)
+		require.NoError(t, err)
+
+		services, ok := raw["services"].([]any)
+		require.True(t, ok)
+		require.Len(t, services, 11)
+
+		for i, service := range services {
+			assert.Equal(t, map[string]any{"name": fmt.Sprintf("service-%d", i)}, service)
+		}
+	}
+}
+
+func TestPrintRedactsSecrets(t *testing.T) {
+	cfg, err := FromFile("testdata/config.yaml")
+	require.NoError(t, err)
+
+	for _, format := range []Format{FormatJSON, FormatYAML, FormatTOML} {
+		var buf bytes.Buffer
+		require.NoError(t, Print(&buf, cfg, format))
+
+		assert.NotContains(t, buf.String(), "s3cr3t-value")
+		assert.Contains(t, buf.String(), "[REDACTED]")
+		assert.Contains(t, buf.String(), "30s")
+	}
+}
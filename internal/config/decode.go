@@ -0,0 +1,285 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// decode raw config into dst pointer using json tags. Unknown fields are rejected,
+// durations are parsed from strings like "1m" and strings are converted to scalars for environment overrides.
+func decode(raw any, dst any) error {
+	return decodeValue(raw, reflect.ValueOf(dst).Elem(), "")
+}
+
+func decodeValue(src any, dst reflect.Value, path string) error {
+	if src == nil {
+		return nil
+	}
+
+	if dst.Type() == durationType {
+		return decodeDuration(src, dst, path)
+	}
+
+	switch dst.Kind() {
+	case reflect.Pointer:
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+
+		return decodeValue(src, dst.Elem(), path)
+	case reflect.Struct:
+		return decodeStruct(src, dst, path)
+	case reflect.Slice:
+		return decodeSlice(src, dst, path)
+	case reflect.Map:
+		return decodeMap(src, dst, path)
+	case reflect.Interface:
+		dst.Set(reflect.ValueOf(src))
+		return nil
+	case reflect.String:
+		s, ok := src.(string)
+		if !ok {
+			return typeError(path, "string", src)
+		}
+
+		dst.SetString(s)
+
+		return nil
+	case reflect.Bool:
+		return decodeBool(src, dst, path)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := toInt(src)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", path, err)
+		}
+
+		dst.SetInt(n)
+
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := toInt(src)
+		if err != nil || n < 0 {
+			return typeError(path, "unsigned integer", src)
+		}
+
+		dst.SetUint(uint64(n))
+
+		return nil
+	case reflect.Float32, reflect.Float64:
+		f, err := toFloat(src)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", path, err)
+		}
+
+		dst.SetFloat(f)
+
+		return nil
+	default:
+		return fmt.Errorf("field %s: unsupported type %s", path, dst.Type())
+	}
+}
+
+func decodeStruct(src any, dst reflect.Value, path string) error {
+	fields, ok := src.(map[string]any)
+	if !ok {
+		return typeError(path, "object", src)
+	}
+
+	index := fieldsByName(dst.Type())
+
+	for name, value := range fields {
+		fieldIndex, ok := index[name]
+		if !ok {
+			return fmt.Errorf("unknown field %s", joinPath(path, name))
+		}
+
+		if err := decodeValue(value, dst.Field(fieldIndex), joinPath(path, name)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func decodeSlice(src any, dst reflect.Value, path string) error {
+	if s, ok := src.(string); ok {
+		// environment overrides of lists are comma separated.
+		items := make([]any, 0)
+		for _, item := range strings.Split(s, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+
+		src = items
+	}
+
+	srcValue := reflect.ValueOf(src)
+	if srcValue.Kind() != reflect.Slice {
+		return typeError(path, "list", src)
+	}
+
+	result := reflect.MakeSlice(dst.Type(), srcValue.Len(), srcValue.Len())
+	for i := 0; i < srcValue.Len(); i++ {
+		if err := decodeValue(srcValue.Index(i).Interface(), result.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+
+	dst.Set(result)
+
+	return nil
+}
+
+func decodeMap(src any, dst reflect.Value, path string) error {
+	if dst.Type().Key().Kind() != reflect.String {
+		return fmt.Errorf("field %s: unsupported map key type %s", path, dst.Type().Key())
+	}
+
+	fields, ok := src.(map[string]any)
+	if !ok {
+		return typeError(path, "object", src)
+	}
+
+	result := reflect.MakeMapWithSize(dst.Type(), len(fields))
+	for key, value := range fields {
+		elem := reflect.New(dst.Type().Elem()).Elem()
+		if err := decodeValue(value, elem, joinPath(path, key)); err != nil {
+			return err
+		}
+
+		result.SetMapIndex(reflect.ValueOf(key).Convert(dst.Type().Key()), elem)
+	}
+
+	dst.Set(result)
+
+	return nil
+}
+
+func decodeDuration(src any, dst reflect.Value, path string) error {
+	if s, ok := src.(string); ok {
+		d, err := time.ParseDuration(s)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", path, err)
+		}
+
+		dst.SetInt(int64(d))
+
+		return nil
+	}
+
+	// numbers are nanoseconds as in encoding/json.
+	n, err := toInt(src)
+	if err != nil {
+		return typeError(path, "duration", src)
+	}
+
+	dst.SetInt(n)
+
+	return nil
+}
+
+func decodeBool(src any, dst reflect.Value, path string) error {
+	switch v := src.(type) {
+	case bool:
+		dst.SetBool(v)
+	case string:
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return fmt.Errorf("field %s: %w", path, err)
+		}
+
+		dst.SetBool(b)
+	default:
+		return typeError(path, "bool", src)
+	}
+
+	return nil
+}
+
+func toInt(src any) (int64, error) {
+	switch v := src.(type) {
+	case int:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint64:
+		return int64(v), nil //nolint:gosec
+	case float64:
+		if v != float64(int64(v)) {
+			return 0, fmt.Errorf("%v is not an integer", v)
+		}
+
+		return int64(v), nil
+	case json.Number:
+		return v.Int64()
+	case string:
+		return strconv.ParseInt(v, 10, 64)
+	default:
+		return 0, fmt.Errorf("unexpected %T, integer expected", src)
+	}
+}
+
+func toFloat(src any) (float64, error) {
+	switch v := src.(type) {
+	case int:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case json.Number:
+		return v.Float64()
+	case string:
+		return strconv.ParseFloat(v, 64)
+	default:
+		return 0, fmt.Errorf("unexpected %T, number expected", src)
+	}
+}
+
+func fieldsByName(t reflect.Type) map[string]int {
+	index := make(map[string]int, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		if name := fieldName(t.Field(i)); name != "" {
+			index[name] = i
+		}
+	}
+
+	return index
+}
+
+// fieldName returns json name of field or empty string if field is skipped.
+func fieldName(field reflect.StructField) string {
+	if !field.IsExported() {
+		return ""
+	}
+
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	default:
+		return name
+	}
+}
+
+func typeError(path, expected string, src any) error {
+	return fmt.Errorf("field %s: unexpected %T, %s expected", path, src, expected)
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
@@ -0,0 +1,206 @@
+package config
+
+import (
+	"cmp"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+const (
+	envPrefix    = "GATEWAY_"
+	envSeparator = "__"
+)
+
+var configType = reflect.TypeOf(domain.Config{})
+
+// applyEnvOverrides sets values from environment variables like GATEWAY_REDIS_ADDRESS,
+// nested fields and list items are separated with double underscore, e.g. GATEWAY_SERVICES__0__ADDRESS.
+// Field names are case-insensitive, map keys are kept as is, e.g. GATEWAY_SERVICES__0__METHOD_CONCURRENCY__GetBook__MAX_IN_FLIGHT.
+// Variables that match no field are skipped, e.g. GATEWAY_PORT injected by Kubernetes for service named gateway.
+func applyEnvOverrides(raw map[string]any, environ []string) (map[string]any, error) {
+	type override struct {
+		name     string
+		segments []string
+		value    string
+	}
+
+	overrides := make([]override, 0, len(environ))
+
+	for _, env := range environ {
+		name, value, found := strings.Cut(env, "=")
+		if !found || !strings.HasPrefix(name, envPrefix) {
+			continue
+		}
+
+		segments, ok := resolvePath(configType, strings.Split(strings.TrimPrefix(name, envPrefix), envSeparator))
+		if !ok {
+			slog.Warn("Skipped environment variable that matches no config field", slog.String("name", name))
+			continue
+		}
+
+		overrides = append(overrides, override{name: name, segments: segments, value: value})
+	}
+
+	// list items are appended by index, so lower indexes must be set first whatever order of environment is.
+	slices.SortStableFunc(overrides, func(a, b override) int {
+		return comparePaths(a.segments, b.segments)
+	})
+
+	for _, o := range overrides {
+		updated, err := setPath(raw, o.segments, o.value)
+		if err != nil {
+			return nil, fmt.Errorf("variable %s: %w", o.name, err)
+		}
+
+		raw = updated.(map[string]any) //nolint:forcetypeassert
+	}
+
+	return raw, nil
+}
+
+// comparePaths orders paths segment by segment, list indexes are compared as numbers, so __2__ goes before __10__.
+func comparePaths(a, b []string) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		aIndex, aErr := strconv.Atoi(a[i])
+		bIndex, bErr := strconv.Atoi(b[i])
+
+		var c int
+		if aErr == nil && bErr == nil {
+			c = cmp.Compare(aIndex, bIndex)
+		} else {
+			c = strings.Compare(a[i], b[i])
+		}
+
+		if c != 0 {
+			return c
+		}
+	}
+
+	return cmp.Compare(len(a), len(b))
+}
+
+// resolvePath returns segments with json names of struct fields, false when path matches no field of t.
+func resolvePath(t reflect.Type, segments []string) ([]string, bool) {
+	resolved := make([]string, 0, len(segments))
+
+	for _, segment := range segments {
+		for t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+
+		switch t.Kind() {
+		case reflect.Struct:
+			field, ok := fieldByEnvName(t, segment)
+			if !ok {
+				return nil, false
+			}
+
+			resolved = append(resolved, fieldName(field))
+			t = field.Type
+		case reflect.Slice:
+			if _, err := strconv.Atoi(segment); err != nil {
+				return nil, false
+			}
+
+			resolved = append(resolved, segment)
+			t = t.Elem()
+		case reflect.Map:
+			resolved = append(resolved, segment)
+			t = t.Elem()
+		case reflect.Interface:
+			// free-form values are set as is.
+			return append(resolved, segments[len(resolved):]...), true
+		default:
+			return nil, false
+		}
+	}
+
+	return resolved, true
+}
+
+func fieldByEnvName(t reflect.Type, segment string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if name := fieldName(field); name != "" && strings.EqualFold(name, segment) {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+// setPath sets value by path in tree of maps and lists, missing nodes are created.
+func setPath(node any, segments []string, value string) (any, error) {
+	if len(segments) == 0 {
+		return value, nil
+	}
+
+	segment := segments[0]
+	if segment == "" {
+		return nil, fmt.Errorf("empty path segment")
+	}
+
+	if index, err := strconv.Atoi(segment); err == nil {
+		return setListItem(node, index, segments[1:], value)
+	}
+
+	var fields map[string]any
+
+	switch n := node.(type) {
+	case nil:
+		fields = make(map[string]any)
+	case map[string]any:
+		fields = n
+	default:
+		return nil, fmt.Errorf("%s is not an object", segment)
+	}
+
+	child, err := setPath(fields[segment], segments[1:], value)
+	if err != nil {
+		return nil, err
+	}
+
+	fields[segment] = child
+
+	return fields, nil
+}
+
+func setListItem(node any, index int, segments []string, value string) (any, error) {
+	var items []any
+
+	switch n := node.(type) {
+	case nil:
+	case []any:
+		items = n
+	case []map[string]any:
+		items = make([]any, 0, len(n))
+		for _, item := range n {
+			items = append(items, item)
+		}
+	default:
+		return nil, fmt.Errorf("index %d is used for not a list", index)
+	}
+
+	if index < 0 || index > len(items) {
+		return nil, fmt.Errorf("index %d is out of range, list has %d items", index, len(items))
+	}
+
+	if index == len(items) {
+		items = append(items, nil)
+	}
+
+	child, err := setPath(items[index], segments, value)
+	if err != nil {
+		return nil, err
+	}
+
+	items[index] = child
+
+	return items, nil
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+var secretType = reflect.TypeOf(domain.Secret(""))
+
+// Print writes config in provided format, durations are written as strings and secrets are redacted.
+func Print(w io.Writer, cfg *domain.Config, format Format) error {
+	raw := encodeValue(reflect.ValueOf(cfg))
+
+	var (
+		data []byte
+		err  error
+	)
+
+	switch format {
+	case FormatYAML:
+		data, err = yaml.Marshal(raw)
+	case FormatTOML:
+		var buf bytes.Buffer
+		err = toml.NewEncoder(&buf).Encode(raw)
+		data = buf.Bytes()
+	default:
+		data, err = json.MarshalIndent(raw, "", "    ")
+		data = append(data, '\n')
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	_, err = w.Write(data)
+
+	return err
+}
+
+// encodeValue converts value to tree of maps and lists, nil values are omitted.
+func encodeValue(v reflect.Value) any {
+	switch v.Type() {
+	case durationType:
+		return time.Duration(v.Int()).String()
+	case secretType:
+		return domain.Secret(v.String()).String()
+	}
+
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+
+		return encodeValue(v.Elem())
+	case reflect.Struct:
+		fields := make(map[string]any, v.NumField())
+
+		for i := 0; i < v.NumField(); i++ {
+			name := fieldName(v.Type().Field(i))
+			if name == "" {
+				continue
+			}
+
+			if value := encodeValue(v.Field(i)); value != nil {
+				fields[name] = value
+			}
+		}
+
+		return fields
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.IsNil() {
+			return nil
+		}
+
+		items := make([]any, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, encodeValue(v.Index(i)))
+		}
+
+		return items
+	case reflect.Map:
+		if v.IsNil() {
+			return nil
+		}
+
+		fields := make(map[string]any, v.Len())
+		for _, key := range v.MapKeys() {
+			if value := encodeValue(v.MapIndex(key)); value != nil {
+				fields[key.String()] = value
+			}
+		}
+
+		return fields
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,208 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/clients/provider"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/utils/store"
+)
+
+const minRetryDelay = time.Second
+
+type descriptionWatcher interface {
+	WatchDescription(ctx context.Context, version string, update func(*domain.ProviderDescription)) error
+}
+
+// Discovery keeps description store in sync with providers. Descriptions are pushed by providers
+// that support WatchDescription and polled from others.
+type Discovery struct {
+	services         []*domain.ConfigService
+	clientStore      *store.Store[string, provider.Client]
+	descriptionStore *store.Store[string, *domain.ProviderDescription]
+	pollPeriod       time.Duration
+	staleTTL         time.Duration
+}
+
+// Options ...
+type Options struct {
+	Services         []*domain.ConfigService
+	ClientStore      *store.Store[string, provider.Client]
+	DescriptionStore *store.Store[string, *domain.ProviderDescription]
+	// PollPeriod is a period of polling and maximum delay of watch reconnects.
+	PollPeriod time.Duration
+	// StaleTTL evicts description when provider is unreachable for this long, zero disables eviction.
+	StaleTTL time.Duration
+}
+
+// New returns new Discovery.
+func New(opts Options) *Discovery {
+	return &Discovery{
+		services:         opts.Services,
+		clientStore:      opts.ClientStore,
+		descriptionStore: opts.DescriptionStore,
+		pollPeriod:       opts.PollPeriod,
+		staleTTL:         opts.StaleTTL,
+	}
+}
+
+// serviceState is owned by goroutine of service.
+type serviceState struct {
+	service  *domain.ConfigService
+	client   provider.Client
+	version  string
+	lastSeen time.Time
+}
+
+// Run fetches all descriptions and keeps them in sync in background until ctx is done.
+func (d *Discovery) Run(ctx context.Context) {
+	var syncErr error
+
+	for _, service := range d.services {
+		if !service.NeedFetchDescription() {
+			// static description never changes.
+			if err := d.apply(&serviceState{service: service}, nil); err != nil {
+				syncErr = errors.Join(syncErr, err)
+			}
+
+			continue
+		}
+
+		client, ok := d.clientStore.Get(service.Name)
+		if !ok {
+			continue
+		}
+
+		state := &serviceState{service: service, client: client, lastSeen: time.Now()}
+
+		// we try to sync all descriptions, but skip errors.
+		if err := d.poll(ctx, state); err != nil {
+			syncErr = errors.Join(syncErr, err)
+		}
+
+		go d.sync(ctx, state)
+	}
+
+	if syncErr != nil {
+		slog.Error("failed to sync some description store entities", slog.String("err", syncErr.Error()))
+	}
+}
+
+// sync watches description of service, or polls it when provider doesn't support watch.
+func (d *Discovery) sync(ctx context.Context, state *serviceState) {
+	watcher, canWatch := state.client.(descriptionWatcher)
+	retryDelay := minRetryDelay
+
+	for {
+		delay := d.pollPeriod
+
+		if canWatch {
+			connected := false
+
+			err := watcher.WatchDescription(ctx, state.version, func(fetched *domain.ProviderDescription) {
+				connected = true
+				state.lastSeen = time.Now()
+
+				if err := d.update(state, fetched); err != nil {
+					slog.Error("failed to update description", slog.String("err", err.Error()))
+				}
+			})
+			if ctx.Err() != nil {
+				return
+			}
+
+			switch {
+			case status.Code(err) == codes.Unimplemented:
+				slog.Info("provider doesn't support description watch, fallback to polling", slog.String("service", state.service.Name))
+				canWatch = false
+			case connected:
+				// provider was alive until stream is broken, e.g. by restart, so new one is watched at once.
+				state.lastSeen = time.Now()
+				retryDelay = minRetryDelay
+				delay = 0
+			default:
+				slog.Warn("failed to watch description", slog.String("service", state.service.Name), slog.String("err", err.Error()))
+				d.evictStale(state)
+
+				delay = retryDelay
+				retryDelay = min(retryDelay*2, d.pollPeriod)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		if !canWatch {
+			if err := d.poll(ctx, state); err != nil {
+				slog.Error("failed to sync description", slog.String("err", err.Error()))
+			}
+		}
+	}
+}
+
+func (d *Discovery) poll(ctx context.Context, state *serviceState) error {
+	fetched, err := state.client.Description(ctx)
+	if err != nil {
+		d.evictStale(state)
+		return fmt.Errorf("could not get description for %s: %w", state.service.Name, err)
+	}
+
+	state.lastSeen = time.Now()
+
+	return d.update(state, fetched)
+}
+
+// update applies fetched description unless its version is applied already, nil description means no changes.
+func (d *Discovery) update(state *serviceState, fetched *domain.ProviderDescription) error {
+	if fetched == nil || (fetched.Version != "" && fetched.Version == state.version) {
+		return nil
+	}
+
+	if err := d.apply(state, fetched); err != nil {
+		return err
+	}
+
+	if state.version != "" {
+		slog.Info("description is updated", slog.String("service", state.service.Name), slog.String("version", fetched.Version))
+	}
+
+	state.version = fetched.Version
+
+	return nil
+}
+
+func (d *Discovery) apply(state *serviceState, fetched *domain.ProviderDescription) error {
+	description, err := state.service.EffectiveDescription(fetched)
+	if err != nil {
+		return fmt.Errorf("could not apply static description for %s: %w", state.service.Name, err)
+	}
+
+	d.descriptionStore.Set(state.service.Name, description)
+
+	return nil
+}
+
+func (d *Discovery) evictStale(state *serviceState) {
+	if d.staleTTL <= 0 || time.Since(state.lastSeen) < d.staleTTL {
+		return
+	}
+
+	if _, ok := d.descriptionStore.Get(state.service.Name); !ok {
+		return
+	}
+
+	slog.Warn("evict description of unreachable provider", slog.String("service", state.service.Name))
+
+	d.descriptionStore.Delete(state.service.Name)
+	state.version = ""
+}
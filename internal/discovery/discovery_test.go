@@ -0,0 +1,159 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/clients/provider"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/utils/store"
+)
+
+type pollClient struct {
+	mu          sync.Mutex
+	description *domain.ProviderDescription
+	err         error
+}
+
+func (c *pollClient) set(description *domain.ProviderDescription, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.description, c.err = description, err
+}
+
+func (c *pollClient) Description(context.Context) (*domain.ProviderDescription, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.description, c.err
+}
+
+func (c *pollClient) Process(context.Context, *domain.ProviderProcessRequest) (*domain.ProviderProcessResponse, error) {
+	return nil, errors.New("not implemented")
+}
+
+type watchClient struct {
+	pollClient
+	unimplemented bool
+	updates       chan *domain.ProviderDescription
+	versions      chan string
+}
+
+func (c *watchClient) WatchDescription(ctx context.Context, version string, update func(*domain.ProviderDescription)) error {
+	if c.unimplemented {
+		return status.Error(codes.Unimplemented, "unknown method")
+	}
+
+	c.versions <- version
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case description := <-c.updates:
+			update(description)
+		}
+	}
+}
+
+func description(version string, methods ...string) *domain.ProviderDescription {
+	byMethod := make(map[string]*domain.ProviderDescriptionMethod, len(methods))
+	for _, method := range methods {
+		byMethod[method] = &domain.ProviderDescriptionMethod{Method: method}
+	}
+
+	return &domain.ProviderDescription{Version: version, DescriptionByMethod: byMethod}
+}
+
+func run(t *testing.T, client provider.Client, staleTTL time.Duration) *store.Store[string, *domain.ProviderDescription] {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	descriptionStore := store.New[string, *domain.ProviderDescription](nil)
+
+	New(Options{
+		Services:         []*domain.ConfigService{{Name: "svc"}},
+		ClientStore:      store.New(map[string]provider.Client{"svc": client}),
+		DescriptionStore: descriptionStore,
+		PollPeriod:       10 * time.Millisecond,
+		StaleTTL:         staleTTL,
+	}).Run(ctx)
+
+	return descriptionStore
+}
+
+func hasMethod(descriptionStore *store.Store[string, *domain.ProviderDescription], method string) bool {
+	desc, ok := descriptionStore.Get("svc")
+	if !ok {
+		return false
+	}
+
+	_, ok = desc.DescriptionByMethod[method]
+
+	return ok
+}
+
+func TestWatch(t *testing.T) {
+	t.Parallel()
+
+	client := &watchClient{
+		updates:  make(chan *domain.ProviderDescription),
+		versions: make(chan string, 1),
+	}
+	client.set(description("v1", "a"), nil)
+
+	descriptionStore := run(t, client, 0)
+	assert.True(t, hasMethod(descriptionStore, "a"))
+	assert.Equal(t, "v1", <-client.versions)
+
+	// unchanged description is skipped.
+	client.updates <- nil
+	assert.True(t, hasMethod(descriptionStore, "a"))
+
+	client.updates <- description("v2", "a", "b")
+	require.Eventually(t, func() bool { return hasMethod(descriptionStore, "b") }, time.Second, time.Millisecond)
+}
+
+func TestPollingFallback(t *testing.T) {
+	t.Parallel()
+
+	client := &watchClient{unimplemented: true}
+	client.set(description("v1", "a"), nil)
+
+	descriptionStore := run(t, client, 0)
+	assert.True(t, hasMethod(descriptionStore, "a"))
+
+	client.set(description("v2", "b"), nil)
+	require.Eventually(t, func() bool { return hasMethod(descriptionStore, "b") }, time.Second, time.Millisecond)
+}
+
+func TestEvictStale(t *testing.T) {
+	t.Parallel()
+
+	client := &pollClient{}
+	client.set(description("v1", "a"), nil)
+
+	descriptionStore := run(t, client, 50*time.Millisecond)
+	assert.True(t, hasMethod(descriptionStore, "a"))
+
+	client.set(nil, status.Error(codes.Unavailable, "connection refused"))
+	require.Eventually(t, func() bool {
+		_, ok := descriptionStore.Get("svc")
+		return !ok
+	}, time.Second, time.Millisecond)
+
+	// description is restored when provider is back.
+	client.set(description("v1", "a"), nil)
+	require.Eventually(t, func() bool { return hasMethod(descriptionStore, "a") }, time.Second, time.Millisecond)
+}
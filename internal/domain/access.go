@@ -0,0 +1,173 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"net/netip"
+	"strings"
+
+	mapset "github.com/deckarep/golang-set/v2"
+)
+
+// AccessRules allow or deny requests by client IP and its country.
+type AccessRules struct {
+	Allow          []netip.Prefix
+	Deny           []netip.Prefix
+	AllowCountries mapset.Set[string]
+	DenyCountries  mapset.Set[string]
+}
+
+// ServiceAccessRules are rules of service and its methods, request must pass both.
+type ServiceAccessRules struct {
+	Service *AccessRules
+	Methods map[string]*AccessRules
+}
+
+// Select returns rules that apply to method.
+func (r *ServiceAccessRules) Select(method string) []*AccessRules {
+	rules := make([]*AccessRules, 0, 2)
+
+	if r.Service != nil {
+		rules = append(rules, r.Service)
+	}
+
+	if methodRules, ok := r.Methods[method]; ok {
+		rules = append(rules, methodRules)
+	}
+
+	return rules
+}
+
+// ToAccessRules converts access rules of service and its methods, nil is returned when there are no rules.
+func (cs *ConfigService) ToAccessRules() (*ServiceAccessRules, error) {
+	if cs.Access == nil && len(cs.MethodAccess) == 0 {
+		return nil, nil
+	}
+
+	rules := &ServiceAccessRules{
+		Methods: make(map[string]*AccessRules, len(cs.MethodAccess)),
+	}
+
+	if cs.Access != nil {
+		serviceRules, err := cs.Access.ToAccessRules()
+		if err != nil {
+			return nil, fmt.Errorf("access is invalid: %w", err)
+		}
+
+		rules.Service = serviceRules
+	}
+
+	for method, access := range cs.MethodAccess {
+		if access == nil {
+			return nil, fmt.Errorf("access of method %s is empty", method)
+		}
+
+		methodRules, err := access.ToAccessRules()
+		if err != nil {
+			return nil, fmt.Errorf("access of method %s is invalid: %w", method, err)
+		}
+
+		rules.Methods[method] = methodRules
+	}
+
+	return rules, nil
+}
+
+// ToAccessRules converts access rules from config.
+func (ca *ConfigAccess) ToAccessRules() (*AccessRules, error) {
+	rules := &AccessRules{
+		AllowCountries: mapset.NewThreadUnsafeSet[string](),
+		DenyCountries:  mapset.NewThreadUnsafeSet[string](),
+	}
+
+	var err error
+
+	if rules.Allow, err = ParsePrefixes(ca.Allow); err != nil {
+		return nil, fmt.Errorf("field Allow is invalid: %w", err)
+	}
+
+	if rules.Deny, err = ParsePrefixes(ca.Deny); err != nil {
+		return nil, fmt.Errorf("field Deny is invalid: %w", err)
+	}
+
+	for _, country := range ca.AllowCountries {
+		rules.AllowCountries.Add(strings.ToUpper(country))
+	}
+
+	for _, country := range ca.DenyCountries {
+		rules.DenyCountries.Add(strings.ToUpper(country))
+	}
+
+	return rules, nil
+}
+
+// NeedCountry returns true if rules check country of client.
+func (ca *ConfigAccess) NeedCountry() bool {
+	return ca != nil && (len(ca.AllowCountries) != 0 || len(ca.DenyCountries) != 0)
+}
+
+// NeedCountry returns true if rules check country of client.
+func (r *AccessRules) NeedCountry() bool {
+	return r.AllowCountries.Cardinality() != 0 || r.DenyCountries.Cardinality() != 0
+}
+
+var (
+	errAccessDenied          = errors.New("client is denied")
+	errAccessNotAllowed      = errors.New("client is not allowed")
+	errAccessUnknownClientIP = errors.New("client IP is unknown")
+)
+
+// Check returns error when client is denied or allow lists don't contain it.
+// Unknown country is empty, it matches no country.
+func (r *AccessRules) Check(addr netip.Addr, country string) error {
+	if !addr.IsValid() {
+		return errAccessUnknownClientIP
+	}
+
+	if containsAddr(r.Deny, addr) || (country != "" && r.DenyCountries.ContainsOne(country)) {
+		return errAccessDenied
+	}
+
+	if len(r.Allow) == 0 && r.AllowCountries.Cardinality() == 0 {
+		return nil
+	}
+
+	if containsAddr(r.Allow, addr) || (country != "" && r.AllowCountries.ContainsOne(country)) {
+		return nil
+	}
+
+	return errAccessNotAllowed
+}
+
+// ParsePrefixes parses IPs and CIDRs, single IP is a prefix of full length.
+func ParsePrefixes(values []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(values))
+
+	for _, value := range values {
+		if addr, err := netip.ParseAddr(value); err == nil {
+			prefixes = append(prefixes, netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()))
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP or CIDR %q", value)
+		}
+
+		prefixes = append(prefixes, prefix.Masked())
+	}
+
+	return prefixes, nil
+}
+
+func containsAddr(prefixes []netip.Prefix, addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+
+	return false
+}
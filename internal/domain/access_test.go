@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessRulesCheck(t *testing.T) {
+	t.Parallel()
+
+	rules, err := (&ConfigAccess{
+		Allow:          []string{"10.8.0.0/16"},
+		Deny:           []string{"10.8.1.0/24", "203.0.113.7"},
+		AllowCountries: []string{"de"},
+	}).ToAccessRules()
+	require.NoError(t, err)
+
+	assert.NoError(t, rules.Check(netip.MustParseAddr("10.8.0.1"), ""))
+	assert.NoError(t, rules.Check(netip.MustParseAddr("198.51.100.1"), "DE"))
+	assert.ErrorIs(t, rules.Check(netip.MustParseAddr("198.51.100.1"), "FR"), errAccessNotAllowed)
+	assert.ErrorIs(t, rules.Check(netip.MustParseAddr("10.8.1.5"), ""), errAccessDenied)
+	assert.ErrorIs(t, rules.Check(netip.MustParseAddr("203.0.113.7"), "DE"), errAccessDenied)
+	assert.ErrorIs(t, rules.Check(netip.Addr{}, "DE"), errAccessUnknownClientIP)
+
+	_, err = (&ConfigAccess{Allow: []string{"10.8.0.0/33"}}).ToAccessRules()
+	assert.Error(t, err)
+}
+
+func TestConfigValidateCountryAccess(t *testing.T) {
+	t.Parallel()
+
+	cfg := &Config{Access: &ConfigAccess{DenyCountries: []string{"XX"}}}
+	assert.Error(t, cfg.validateAccess())
+
+	cfg.GeoIPDatabase = "/etc/gateway/GeoLite2-Country.mmdb"
+	assert.NoError(t, cfg.validateAccess())
+}
@@ -5,3 +5,19 @@ package domain
 // HTTPMethod ...
 // ENUM(unspecified, get, put, post, delete, patch)
 type HTTPMethod uint8
+
+// ClientAuthMethod is a method of client authentication at token endpoint.
+// ENUM(client_secret_post, client_secret_basic, private_key_jwt, tls_client_auth)
+type ClientAuthMethod uint8
+
+// ServiceKind is a protocol of upstream service.
+// ENUM(grpc, http, grpc_transcoding)
+type ServiceKind uint8
+
+// TLSClientAuth ...
+// ENUM(none, request, verify_if_given, require)
+type TLSClientAuth uint8
+
+// RateLimitBackend is a storage of rate limits.
+// ENUM(redis, memory, hybrid)
+type RateLimitBackend uint8
@@ -11,6 +11,58 @@ import (
 	"fmt"
 )
 
+const (
+	// ClientAuthMethodClientSecretPost is a ClientAuthMethod of type Client_secret_post.
+	ClientAuthMethodClientSecretPost ClientAuthMethod = iota
+	// ClientAuthMethodClientSecretBasic is a ClientAuthMethod of type Client_secret_basic.
+	ClientAuthMethodClientSecretBasic
+	// ClientAuthMethodPrivateKeyJwt is a ClientAuthMethod of type Private_key_jwt.
+	ClientAuthMethodPrivateKeyJwt
+	// ClientAuthMethodTlsClientAuth is a ClientAuthMethod of type Tls_client_auth.
+	ClientAuthMethodTlsClientAuth
+)
+
+var ErrInvalidClientAuthMethod = errors.New("not a valid ClientAuthMethod")
+
+const _ClientAuthMethodName = "client_secret_postclient_secret_basicprivate_key_jwttls_client_auth"
+
+var _ClientAuthMethodMap = map[ClientAuthMethod]string{
+	ClientAuthMethodClientSecretPost:  _ClientAuthMethodName[0:18],
+	ClientAuthMethodClientSecretBasic: _ClientAuthMethodName[18:37],
+	ClientAuthMethodPrivateKeyJwt:     _ClientAuthMethodName[37:52],
+	ClientAuthMethodTlsClientAuth:     _ClientAuthMethodName[52:67],
+}
+
+// String implements the Stringer interface.
+func (x ClientAuthMethod) String() string {
+	if str, ok := _ClientAuthMethodMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("ClientAuthMethod(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x ClientAuthMethod) IsValid() bool {
+	_, ok := _ClientAuthMethodMap[x]
+	return ok
+}
+
+var _ClientAuthMethodValue = map[string]ClientAuthMethod{
+	_ClientAuthMethodName[0:18]:  ClientAuthMethodClientSecretPost,
+	_ClientAuthMethodName[18:37]: ClientAuthMethodClientSecretBasic,
+	_ClientAuthMethodName[37:52]: ClientAuthMethodPrivateKeyJwt,
+	_ClientAuthMethodName[52:67]: ClientAuthMethodTlsClientAuth,
+}
+
+// ParseClientAuthMethod attempts to convert a string to a ClientAuthMethod.
+func ParseClientAuthMethod(name string) (ClientAuthMethod, error) {
+	if x, ok := _ClientAuthMethodValue[name]; ok {
+		return x, nil
+	}
+	return ClientAuthMethod(0), fmt.Errorf("%s is %w", name, ErrInvalidClientAuthMethod)
+}
+
 const (
 	// HTTPMethodUnspecified is a HTTPMethod of type Unspecified.
 	HTTPMethodUnspecified HTTPMethod = iota
@@ -70,3 +122,151 @@ func ParseHTTPMethod(name string) (HTTPMethod, error) {
 	}
 	return HTTPMethod(0), fmt.Errorf("%s is %w", name, ErrInvalidHTTPMethod)
 }
+
+const (
+	// RateLimitBackendRedis is a RateLimitBackend of type Redis.
+	RateLimitBackendRedis RateLimitBackend = iota
+	// RateLimitBackendMemory is a RateLimitBackend of type Memory.
+	RateLimitBackendMemory
+	// RateLimitBackendHybrid is a RateLimitBackend of type Hybrid.
+	RateLimitBackendHybrid
+)
+
+var ErrInvalidRateLimitBackend = errors.New("not a valid RateLimitBackend")
+
+const _RateLimitBackendName = "redismemoryhybrid"
+
+var _RateLimitBackendMap = map[RateLimitBackend]string{
+	RateLimitBackendRedis:  _RateLimitBackendName[0:5],
+	RateLimitBackendMemory: _RateLimitBackendName[5:11],
+	RateLimitBackendHybrid: _RateLimitBackendName[11:17],
+}
+
+// String implements the Stringer interface.
+func (x RateLimitBackend) String() string {
+	if str, ok := _RateLimitBackendMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("RateLimitBackend(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x RateLimitBackend) IsValid() bool {
+	_, ok := _RateLimitBackendMap[x]
+	return ok
+}
+
+var _RateLimitBackendValue = map[string]RateLimitBackend{
+	_RateLimitBackendName[0:5]:   RateLimitBackendRedis,
+	_RateLimitBackendName[5:11]:  RateLimitBackendMemory,
+	_RateLimitBackendName[11:17]: RateLimitBackendHybrid,
+}
+
+// ParseRateLimitBackend attempts to convert a string to a RateLimitBackend.
+func ParseRateLimitBackend(name string) (RateLimitBackend, error) {
+	if x, ok := _RateLimitBackendValue[name]; ok {
+		return x, nil
+	}
+	return RateLimitBackend(0), fmt.Errorf("%s is %w", name, ErrInvalidRateLimitBackend)
+}
+
+const (
+	// ServiceKindGrpc is a ServiceKind of type Grpc.
+	ServiceKindGrpc ServiceKind = iota
+	// ServiceKindHttp is a ServiceKind of type Http.
+	ServiceKindHttp
+	// ServiceKindGrpcTranscoding is a ServiceKind of type Grpc_transcoding.
+	ServiceKindGrpcTranscoding
+)
+
+var ErrInvalidServiceKind = errors.New("not a valid ServiceKind")
+
+const _ServiceKindName = "grpchttpgrpc_transcoding"
+
+var _ServiceKindMap = map[ServiceKind]string{
+	ServiceKindGrpc:            _ServiceKindName[0:4],
+	ServiceKindHttp:            _ServiceKindName[4:8],
+	ServiceKindGrpcTranscoding: _ServiceKindName[8:24],
+}
+
+// String implements the Stringer interface.
+func (x ServiceKind) String() string {
+	if str, ok := _ServiceKindMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("ServiceKind(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x ServiceKind) IsValid() bool {
+	_, ok := _ServiceKindMap[x]
+	return ok
+}
+
+var _ServiceKindValue = map[string]ServiceKind{
+	_ServiceKindName[0:4]:  ServiceKindGrpc,
+	_ServiceKindName[4:8]:  ServiceKindHttp,
+	_ServiceKindName[8:24]: ServiceKindGrpcTranscoding,
+}
+
+// ParseServiceKind attempts to convert a string to a ServiceKind.
+func ParseServiceKind(name string) (ServiceKind, error) {
+	if x, ok := _ServiceKindValue[name]; ok {
+		return x, nil
+	}
+	return ServiceKind(0), fmt.Errorf("%s is %w", name, ErrInvalidServiceKind)
+}
+
+const (
+	// TLSClientAuthNone is a TLSClientAuth of type None.
+	TLSClientAuthNone TLSClientAuth = iota
+	// TLSClientAuthRequest is a TLSClientAuth of type Request.
+	TLSClientAuthRequest
+	// TLSClientAuthVerifyIfGiven is a TLSClientAuth of type Verify_if_given.
+	TLSClientAuthVerifyIfGiven
+	// TLSClientAuthRequire is a TLSClientAuth of type Require.
+	TLSClientAuthRequire
+)
+
+var ErrInvalidTLSClientAuth = errors.New("not a valid TLSClientAuth")
+
+const _TLSClientAuthName = "nonerequestverify_if_givenrequire"
+
+var _TLSClientAuthMap = map[TLSClientAuth]string{
+	TLSClientAuthNone:          _TLSClientAuthName[0:4],
+	TLSClientAuthRequest:       _TLSClientAuthName[4:11],
+	TLSClientAuthVerifyIfGiven: _TLSClientAuthName[11:26],
+	TLSClientAuthRequire:       _TLSClientAuthName[26:33],
+}
+
+// String implements the Stringer interface.
+func (x TLSClientAuth) String() string {
+	if str, ok := _TLSClientAuthMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("TLSClientAuth(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x TLSClientAuth) IsValid() bool {
+	_, ok := _TLSClientAuthMap[x]
+	return ok
+}
+
+var _TLSClientAuthValue = map[string]TLSClientAuth{
+	_TLSClientAuthName[0:4]:   TLSClientAuthNone,
+	_TLSClientAuthName[4:11]:  TLSClientAuthRequest,
+	_TLSClientAuthName[11:26]: TLSClientAuthVerifyIfGiven,
+	_TLSClientAuthName[26:33]: TLSClientAuthRequire,
+}
+
+// ParseTLSClientAuth attempts to convert a string to a TLSClientAuth.
+func ParseTLSClientAuth(name string) (TLSClientAuth, error) {
+	if x, ok := _TLSClientAuthValue[name]; ok {
+		return x, nil
+	}
+	return TLSClientAuth(0), fmt.Errorf("%s is %w", name, ErrInvalidTLSClientAuth)
+}
@@ -3,6 +3,7 @@ package domain
 import (
 	"errors"
 	"fmt"
+	"net/url"
 	"time"
 )
 
@@ -12,28 +13,293 @@ const (
 
 	defaultDescriptionSyncPeriod   = time.Minute
 	defaultServiceOperationTimeout = time.Minute
+
+	defaultIntrospectionCacheTTL = 30 * time.Second
+	defaultDPoPProofLifetime     = time.Minute
+
+	defaultRateLimitSyncPeriod   = time.Second
+	defaultOverrideRefreshPeriod = 5 * time.Second
+	defaultOverrideOrgClaim      = "org_id"
+
+	defaultConcurrencyQueueTimeout     = time.Second
+	defaultConcurrencyMinInFlight      = 1
+	defaultConcurrencyLatencyTolerance = 2
+
+	defaultIdentityTokenIssuer = "api-gateway"
+	defaultIdentityTokenTTL    = time.Minute
+
+	defaultVaultTimeout = 10 * time.Second
 )
 
 // Config ...
 type Config struct {
-	PublicListenAddress   string           `json:"public_listen_address"`
-	AdminListenAddress    string           `json:"admin_listen_address"`
-	Auth0Domain           string           `json:"auth0_domain"`
-	Auth0Audience         string           `json:"auth0_audience"`
-	Auth0ClientID         string           `json:"auth0_client_id"`
-	Auth0ClientSecret     string           `json:"auth0_client_secret"`
-	RedisAddress          string           `json:"redis_address"`
-	RedisPassword         string           `json:"redis_password"`
-	DescriptionSyncPeriod time.Duration    `json:"description_sync_period"`
-	Services              []*ConfigService `json:"services"`
+	PublicListenAddress   string                     `json:"public_listen_address"`
+	AdminListenAddress    string                     `json:"admin_listen_address"`
+	Auth0Domain           string                     `json:"auth0_domain"`
+	Auth0Audience         string                     `json:"auth0_audience"`
+	Auth0ClientID         string                     `json:"auth0_client_id"`
+	Auth0ClientSecret     Secret                     `json:"auth0_client_secret"`
+	Auth0ClientAuthMethod string                     `json:"auth0_client_auth_method"`
+	Auth0ClientKey        *ConfigClientKey           `json:"auth0_client_key"`
+	Auth0ClientTLS        *ConfigClientTLS           `json:"auth0_client_tls"`
+	RedisAddress          string                     `json:"redis_address"`
+	RedisPassword         Secret                     `json:"redis_password"`
+	RateLimit             *ConfigRateLimit           `json:"rate_limit"`
+	Priority              *ConfigPriority            `json:"priority"`
+	Access                *ConfigAccess              `json:"access"`
+	TrustedProxies        []string                   `json:"trusted_proxies"`
+	GeoIPDatabase         string                     `json:"geoip_database"`
+	DescriptionSyncPeriod time.Duration              `json:"description_sync_period"`
+	DescriptionStaleTTL   time.Duration              `json:"description_stale_ttl"`
+	RevocationEnabled     bool                       `json:"revocation_enabled"`
+	M2MSharedCache        bool                       `json:"m2m_shared_cache"`
+	Introspection         *ConfigIntrospection       `json:"introspection"`
+	DPoPProofLifetime     time.Duration              `json:"dpop_proof_lifetime"`
+	PublicTLS             *ConfigTLS                 `json:"public_tls"`
+	ClientCertificates    []*ConfigClientCertificate `json:"client_certificates"`
+	IdentityToken         *ConfigIdentityToken       `json:"identity_token"`
+	Secrets               *ConfigSecrets             `json:"secrets"`
+	Services              []*ConfigService           `json:"services"`
+}
+
+// ConfigIntrospection ...
+type ConfigIntrospection struct {
+	Endpoint     string        `json:"endpoint"`
+	ClientID     string        `json:"client_id"`
+	ClientSecret Secret        `json:"client_secret"`
+	CacheTTL     time.Duration `json:"cache_ttl"`
+	// AllowedClientIDs are clients that opaque tokens must be issued to, any client is accepted when empty.
+	AllowedClientIDs []string `json:"allowed_client_ids"`
+}
+
+// ConfigAccess allows or denies clients by IP or CIDR and by ISO country code.
+// Deny lists win, clients must match allow lists when they are set.
+type ConfigAccess struct {
+	Allow          []string `json:"allow"`
+	Deny           []string `json:"deny"`
+	AllowCountries []string `json:"allow_countries"`
+	DenyCountries  []string `json:"deny_countries"`
+}
+
+// ConfigPriority assigns priority to consumers, it is used when gateway or provider sheds load.
+type ConfigPriority struct {
+	// Claim of subject with tier of consumer, e.g. plan.
+	Claim string `json:"claim"`
+	// ClaimValues maps values of claim to priorities.
+	ClaimValues map[string]string `json:"claim_values"`
+	// APIKeys maps SHA-256 hex of API keys to priorities.
+	APIKeys map[string]string `json:"api_keys"`
+}
+
+// ConfigRateLimit configures storage of rate limits.
+type ConfigRateLimit struct {
+	// Backend is one of redis, memory, hybrid.
+	Backend string `json:"backend"`
+	// SyncPeriod is a period of adding requests counted in memory to Redis by hybrid backend.
+	SyncPeriod time.Duration `json:"sync_period"`
+	// FailOpen allows requests when limiter fails, otherwise they are rejected with 503.
+	FailOpen bool `json:"fail_open"`
+	// Overrides enables per-consumer limits and blocks stored in Redis and managed by admin endpoints.
+	Overrides bool `json:"overrides"`
+	// OverrideRefreshPeriod is a period of reloading overrides changed by other gateways.
+	OverrideRefreshPeriod time.Duration `json:"override_refresh_period"`
+	// OverrideOrgClaim is a claim with organization of subject.
+	OverrideOrgClaim string `json:"override_org_claim"`
+}
+
+// ConfigClientKey is a key to sign client assertions with.
+type ConfigClientKey struct {
+	ID             string `json:"id"`
+	PrivateKeyFile string `json:"private_key_file"`
+}
+
+// ConfigClientTLS is a client certificate for mTLS client authentication.
+type ConfigClientTLS struct {
+	CertFile string   `json:"cert_file"`
+	KeyFile  string   `json:"key_file"`
+	CAFiles  []string `json:"ca_files"`
+	// Domain overrides domain of token endpoint, e.g. for mTLS endpoint aliases.
+	Domain string `json:"domain"`
+}
+
+// ConfigSecrets configures providers of secret references.
+type ConfigSecrets struct {
+	Vault *ConfigVault `json:"vault"`
+}
+
+// ConfigVault is a Vault KV v2 secret provider.
+type ConfigVault struct {
+	Address   string        `json:"address"`
+	Token     Secret        `json:"token"`
+	Namespace string        `json:"namespace"`
+	Timeout   time.Duration `json:"timeout"`
 }
 
 // ConfigService ...
 type ConfigService struct {
-	Name             string        `json:"name"`
+	Name string `json:"name"`
+	// Kind is one of grpc, http, grpc_transcoding. Address of http service is a base URL.
+	Kind             string        `json:"kind"`
 	Address          string        `json:"address"`
 	M2MAudience      string        `json:"m2m_audience"`
 	OperationTimeout time.Duration `json:"timeout"`
+	RequireDPoP      bool          `json:"require_dpop"`
+	// TokenExchangeAudience enables exchange of user token to token for this audience.
+	TokenExchangeAudience string `json:"token_exchange_audience"`
+	// Description is a static description that replaces or tightens description fetched from provider.
+	Description *ConfigDescription `json:"description"`
+	// HTTP configures upstream of http kind.
+	HTTP *ConfigHTTPUpstream `json:"http"`
+	// Transcoding configures upstream of grpc_transcoding kind.
+	Transcoding *ConfigTranscoding `json:"transcoding"`
+	// Quotas are long-period limits counted in Redis.
+	Quotas []*ConfigQuota `json:"quotas"`
+	// Concurrency limits in-flight requests to service.
+	Concurrency *ConfigConcurrency `json:"concurrency"`
+	// MethodConcurrency limits in-flight requests to methods, they apply together with limit of service.
+	MethodConcurrency map[string]*ConfigConcurrency `json:"method_concurrency"`
+	// Access rules of service and of methods apply together with global rules.
+	Access       *ConfigAccess            `json:"access"`
+	MethodAccess map[string]*ConfigAccess `json:"method_access"`
+}
+
+// ConfigConcurrency ...
+type ConfigConcurrency struct {
+	// MaxInFlight is a limit of concurrent requests, the upper bound of adaptive limit.
+	MaxInFlight int `json:"max_in_flight"`
+	// MaxQueue is a number of requests waiting for free slot, excess requests are shed at once.
+	MaxQueue     int           `json:"max_queue"`
+	QueueTimeout time.Duration `json:"queue_timeout"`
+	// Adaptive lowers limit when latency grows above LatencyTolerance times the baseline latency.
+	Adaptive         bool    `json:"adaptive"`
+	MinInFlight      int     `json:"min_in_flight"`
+	LatencyTolerance float64 `json:"latency_tolerance"`
+}
+
+// ConfigQuota ...
+type ConfigQuota struct {
+	Name string `json:"name"`
+	// By is a key of quota as in rate limiter, e.g. subject_id, claim or api_key.
+	By string `json:"by"`
+	// Key is a name of claim, header or query parameter.
+	Key  string                `json:"key"`
+	Keys []*ConfigRateLimitKey `json:"keys"`
+	// Limit of requests per period.
+	Limit uint64 `json:"limit"`
+	// Period is one of day, month.
+	Period string `json:"period"`
+	// TimeZone is an IANA time zone of calendar, e.g. Europe/Berlin.
+	TimeZone string   `json:"time_zone"`
+	Methods  []string `json:"methods"`
+}
+
+// ConfigTranscoding ...
+type ConfigTranscoding struct {
+	// Service is a full name of gRPC service, e.g. library.v1.LibraryService.
+	Service string `json:"service"`
+	// DescriptorSet is a path to FileDescriptorSet, server reflection is used when empty.
+	DescriptorSet string `json:"descriptor_set"`
+}
+
+// ConfigHTTPUpstream ...
+type ConfigHTTPUpstream struct {
+	// PathRewrite is a template of upstream path with {method} and {path} placeholders.
+	PathRewrite         string        `json:"path_rewrite"`
+	HostHeader          string        `json:"host_header"`
+	MaxIdleConns        int           `json:"max_idle_conns"`
+	MaxIdleConnsPerHost int           `json:"max_idle_conns_per_host"`
+	IdleConnTimeout     time.Duration `json:"idle_conn_timeout"`
+}
+
+// ConfigDescription ...
+type ConfigDescription struct {
+	// Mode is one of merge, replace.
+	Mode                      string                     `json:"mode"`
+	AuditEnabled              bool                       `json:"audit_enabled"`
+	AuthenticationMode        string                     `json:"authentication_mode"`
+	CertificateAuthentication string                     `json:"certificate_authentication"`
+	RequiredPermissions       []string                   `json:"required_permissions"`
+	RateLimiter               *ConfigRateLimiter         `json:"rate_limiter"`
+	RateLimiters              []*ConfigRateLimiter       `json:"rate_limiters"`
+	Methods                   []*ConfigDescriptionMethod `json:"methods"`
+}
+
+// ConfigDescriptionMethod ...
+type ConfigDescriptionMethod struct {
+	Name                      string               `json:"name"`
+	AllowedHTTPMethods        []string             `json:"allowed_http_methods"`
+	AuditEnabled              bool                 `json:"audit_enabled"`
+	AuthenticationMode        string               `json:"authentication_mode"`
+	CertificateAuthentication string               `json:"certificate_authentication"`
+	RequiredPermissions       []string             `json:"required_permissions"`
+	RateLimiter               *ConfigRateLimiter   `json:"rate_limiter"`
+	RateLimiters              []*ConfigRateLimiter `json:"rate_limiters"`
+	Cost                      *ConfigRequestCost   `json:"cost"`
+	Priority                  string               `json:"priority"`
+}
+
+// ConfigRequestCost is a count of tokens taken by request from rate limiters.
+type ConfigRequestCost struct {
+	// Base cost, 1 when zero.
+	Base uint64 `json:"base"`
+	// QueryParam adds its integer value to cost.
+	QueryParam string `json:"query_param"`
+	// BodyBytesPerUnit adds one unit per started chunk of body.
+	BodyBytesPerUnit uint64 `json:"body_bytes_per_unit"`
+	// Max cost, unlimited when zero.
+	Max uint64 `json:"max"`
+}
+
+// ConfigRateLimiter ...
+type ConfigRateLimiter struct {
+	// By is one of ip, subject_id, claim, header, query, global, api_key, composite.
+	By string `json:"by"`
+	// Name of claim, header or query parameter.
+	Name   string                `json:"name"`
+	Keys   []*ConfigRateLimitKey `json:"keys"`
+	Rate   uint64                `json:"rate"`
+	Burst  uint64                `json:"burst"`
+	Period time.Duration         `json:"period"`
+}
+
+// ConfigRateLimitKey is a part of composite rate limiter key.
+type ConfigRateLimitKey struct {
+	By   string `json:"by"`
+	Name string `json:"name"`
+}
+
+// ConfigTLS ...
+type ConfigTLS struct {
+	CertFile      string   `json:"cert_file"`
+	KeyFile       string   `json:"key_file"`
+	ClientCAFiles []string `json:"client_ca_files"`
+	// ClientAuth is one of none, request, verify_if_given, require.
+	ClientAuth string `json:"client_auth"`
+}
+
+// ConfigClientCertificate maps client certificate to subject.
+type ConfigClientCertificate struct {
+	SubjectDN   string   `json:"subject_dn"`
+	DNSName     string   `json:"san_dns"`
+	URI         string   `json:"san_uri"`
+	Email       string   `json:"san_email"`
+	Fingerprint string   `json:"fingerprint"`
+	SubjectID   string   `json:"subject_id"`
+	Permissions []string `json:"permissions"`
+}
+
+// ConfigIdentityToken ...
+type ConfigIdentityToken struct {
+	Issuer      string                    `json:"issuer"`
+	TTL         time.Duration             `json:"ttl"`
+	ActiveKeyID string                    `json:"active_key_id"`
+	Keys        []*ConfigIdentityTokenKey `json:"keys"`
+}
+
+// ConfigIdentityTokenKey ...
+type ConfigIdentityTokenKey struct {
+	ID             string `json:"id"`
+	PrivateKeyFile string `json:"private_key_file"`
 }
 
 // SetDefaults ...
@@ -54,6 +320,36 @@ func (c *Config) SetDefaults() {
 		c.RedisAddress = "localhost:6379"
 	}
 
+	if c.Auth0ClientAuthMethod == "" {
+		c.Auth0ClientAuthMethod = ClientAuthMethodClientSecretPost.String()
+	}
+
+	if c.RateLimit == nil {
+		c.RateLimit = &ConfigRateLimit{}
+	}
+
+	c.RateLimit.SetDefaults()
+
+	if c.Introspection != nil {
+		c.Introspection.SetDefaults()
+	}
+
+	if c.DPoPProofLifetime <= 0 {
+		c.DPoPProofLifetime = defaultDPoPProofLifetime
+	}
+
+	if c.PublicTLS != nil {
+		c.PublicTLS.SetDefaults()
+	}
+
+	if c.IdentityToken != nil {
+		c.IdentityToken.SetDefaults()
+	}
+
+	if c.Secrets != nil && c.Secrets.Vault != nil {
+		c.Secrets.Vault.SetDefaults()
+	}
+
 	for _, s := range c.Services {
 		s.SetDefaults()
 	}
@@ -81,18 +377,72 @@ func (c *Config) Validate() error {
 		return errors.New("field Auth0ClientID is required")
 	}
 
-	if c.Auth0ClientSecret == "" {
-		return errors.New("field Auth0ClientSecret is required")
+	if err := c.validateClientAuth(); err != nil {
+		return err
 	}
 
 	if c.DescriptionSyncPeriod <= 0 {
 		return errors.New("field DescriptionSyncPeriod must be greater than zero")
 	}
 
-	if c.RedisAddress == "" {
+	if c.DescriptionStaleTTL < 0 {
+		return errors.New("field DescriptionStaleTTL must not be negative")
+	}
+
+	if c.RedisRequired() && c.RedisAddress == "" {
 		return errors.New("field RedisAddress is required")
 	}
 
+	if c.RateLimit != nil {
+		if err := c.RateLimit.Validate(); err != nil {
+			return fmt.Errorf("rate limit is invalid: %w", err)
+		}
+	}
+
+	if err := c.validateAccess(); err != nil {
+		return err
+	}
+
+	if c.Priority != nil {
+		if _, err := c.Priority.ToPriorityRules(); err != nil {
+			return fmt.Errorf("priority is invalid: %w", err)
+		}
+	}
+
+	if c.DPoPProofLifetime <= 0 {
+		return errors.New("field DPoPProofLifetime must be greater than zero")
+	}
+
+	if c.Introspection != nil {
+		if err := c.Introspection.Validate(); err != nil {
+			return fmt.Errorf("introspection is invalid: %w", err)
+		}
+	}
+
+	if c.PublicTLS != nil {
+		if err := c.PublicTLS.Validate(); err != nil {
+			return fmt.Errorf("public TLS is invalid: %w", err)
+		}
+	}
+
+	if c.IdentityToken != nil {
+		if err := c.IdentityToken.Validate(); err != nil {
+			return fmt.Errorf("identity token is invalid: %w", err)
+		}
+	}
+
+	if c.Secrets != nil && c.Secrets.Vault != nil {
+		if err := c.Secrets.Vault.Validate(); err != nil {
+			return fmt.Errorf("vault is invalid: %w", err)
+		}
+	}
+
+	for index, cc := range c.ClientCertificates {
+		if err := cc.Validate(); err != nil {
+			return fmt.Errorf("client certificate with index %d is invalid: %w", index, err)
+		}
+	}
+
 	for index, s := range c.Services {
 		if err := s.Validate(); err != nil {
 			name := s.Name
@@ -107,11 +457,168 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+func (c *Config) validateClientAuth() error {
+	method, err := ParseClientAuthMethod(c.Auth0ClientAuthMethod)
+	if err != nil {
+		return fmt.Errorf("field Auth0ClientAuthMethod is invalid: %w", err)
+	}
+
+	switch method {
+	case ClientAuthMethodClientSecretPost, ClientAuthMethodClientSecretBasic:
+		if c.Auth0ClientSecret == "" {
+			return errors.New("field Auth0ClientSecret is required")
+		}
+	case ClientAuthMethodPrivateKeyJwt:
+		if c.Auth0ClientKey == nil {
+			return errors.New("field Auth0ClientKey is required")
+		}
+
+		if err = c.Auth0ClientKey.Validate(); err != nil {
+			return fmt.Errorf("auth0 client key is invalid: %w", err)
+		}
+	case ClientAuthMethodTlsClientAuth:
+		if c.Auth0ClientTLS == nil {
+			return errors.New("field Auth0ClientTLS is required")
+		}
+
+		if err = c.Auth0ClientTLS.Validate(); err != nil {
+			return fmt.Errorf("auth0 client TLS is invalid: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// RedisRequired returns false when no feature stores its state in Redis, so gateway can start without it.
+func (c *Config) RedisRequired() bool {
+	if c.RevocationEnabled || c.M2MSharedCache {
+		return true
+	}
+
+	for _, service := range c.Services {
+		if len(service.Quotas) != 0 {
+			return true
+		}
+	}
+
+	return c.RateLimit == nil || c.RateLimit.Overrides || c.RateLimit.Backend != RateLimitBackendMemory.String()
+}
+
+// SetDefaults ...
+func (cr *ConfigRateLimit) SetDefaults() {
+	if cr.Backend == "" {
+		cr.Backend = RateLimitBackendRedis.String()
+	}
+
+	if cr.SyncPeriod <= 0 {
+		cr.SyncPeriod = defaultRateLimitSyncPeriod
+	}
+
+	if cr.OverrideRefreshPeriod <= 0 {
+		cr.OverrideRefreshPeriod = defaultOverrideRefreshPeriod
+	}
+
+	if cr.OverrideOrgClaim == "" {
+		cr.OverrideOrgClaim = defaultOverrideOrgClaim
+	}
+}
+
+// Validate ...
+func (cr *ConfigRateLimit) Validate() error {
+	if _, err := ParseRateLimitBackend(cr.Backend); err != nil {
+		return fmt.Errorf("field Backend is invalid: %w", err)
+	}
+
+	if cr.SyncPeriod <= 0 {
+		return errors.New("field SyncPeriod must be greater than zero")
+	}
+
+	if cr.Overrides && cr.OverrideRefreshPeriod <= 0 {
+		return errors.New("field OverrideRefreshPeriod must be greater than zero")
+	}
+
+	return nil
+}
+
+// SetDefaults ...
+func (cv *ConfigVault) SetDefaults() {
+	if cv.Timeout <= 0 {
+		cv.Timeout = defaultVaultTimeout
+	}
+}
+
+// Validate ...
+func (cv *ConfigVault) Validate() error {
+	if cv.Address == "" {
+		return errors.New("field Address is required")
+	}
+
+	if cv.Token == "" {
+		return errors.New("field Token is required")
+	}
+
+	return nil
+}
+
+// Validate ...
+func (ck *ConfigClientKey) Validate() error {
+	if ck.ID == "" {
+		return errors.New("field ID is required")
+	}
+
+	if ck.PrivateKeyFile == "" {
+		return errors.New("field PrivateKeyFile is required")
+	}
+
+	return nil
+}
+
+// Validate ...
+func (ct *ConfigClientTLS) Validate() error {
+	if ct.CertFile == "" {
+		return errors.New("field CertFile is required")
+	}
+
+	if ct.KeyFile == "" {
+		return errors.New("field KeyFile is required")
+	}
+
+	return nil
+}
+
 // SetDefaults ...
 func (cs *ConfigService) SetDefaults() {
+	if cs.Kind == "" {
+		cs.Kind = ServiceKindGrpc.String()
+	}
+
 	if cs.OperationTimeout <= 0 {
 		cs.OperationTimeout = defaultServiceOperationTimeout
 	}
+
+	if cs.Description != nil && cs.Description.Mode == "" {
+		cs.Description.Mode = DescriptionModeMerge.String()
+	}
+
+	for _, quota := range cs.Quotas {
+		if quota == nil {
+			continue
+		}
+
+		if quota.Period == "" {
+			quota.Period = QuotaPeriodMonth.String()
+		}
+
+		if quota.TimeZone == "" {
+			quota.TimeZone = time.UTC.String()
+		}
+	}
+
+	cs.Concurrency.SetDefaults()
+
+	for _, concurrency := range cs.MethodConcurrency {
+		concurrency.SetDefaults()
+	}
 }
 
 // Validate ...
@@ -128,5 +635,267 @@ func (cs *ConfigService) Validate() error {
 		return errors.New("field OperationTimeout must be greater than zero")
 	}
 
+	if cs.Description != nil {
+		if _, err := cs.Description.ToProviderDescription(); err != nil {
+			return fmt.Errorf("description is invalid: %w", err)
+		}
+	}
+
+	if _, err := ToQuotas(cs.Quotas); err != nil {
+		return err
+	}
+
+	if cs.Concurrency != nil {
+		if err := cs.Concurrency.Validate(); err != nil {
+			return fmt.Errorf("concurrency is invalid: %w", err)
+		}
+	}
+
+	for method, concurrency := range cs.MethodConcurrency {
+		if concurrency == nil {
+			return fmt.Errorf("concurrency of method %s is empty", method)
+		}
+
+		if err := concurrency.Validate(); err != nil {
+			return fmt.Errorf("concurrency of method %s is invalid: %w", method, err)
+		}
+	}
+
+	if _, err := cs.ToAccessRules(); err != nil {
+		return err
+	}
+
+	kind, err := ParseServiceKind(cs.Kind)
+	if err != nil {
+		return fmt.Errorf("field Kind is invalid: %w", err)
+	}
+
+	switch kind {
+	case ServiceKindHttp:
+		return cs.validateHTTP()
+	case ServiceKindGrpcTranscoding:
+		return cs.validateTranscoding()
+	}
+
+	return nil
+}
+
+func (c *Config) validateAccess() error {
+	if _, err := ParsePrefixes(c.TrustedProxies); err != nil {
+		return fmt.Errorf("field TrustedProxies is invalid: %w", err)
+	}
+
+	if c.Access != nil {
+		if _, err := c.Access.ToAccessRules(); err != nil {
+			return fmt.Errorf("access is invalid: %w", err)
+		}
+	}
+
+	if c.GeoIPDatabase != "" {
+		return nil
+	}
+
+	needCountry := c.Access.NeedCountry()
+
+	for _, s := range c.Services {
+		needCountry = needCountry || s.Access.NeedCountry()
+
+		for _, access := range s.MethodAccess {
+			needCountry = needCountry || access.NeedCountry()
+		}
+	}
+
+	if needCountry {
+		return errors.New("field GeoIPDatabase is required by country access rules")
+	}
+
+	return nil
+}
+
+// SetDefaults ...
+func (cc *ConfigConcurrency) SetDefaults() {
+	if cc == nil {
+		return
+	}
+
+	if cc.QueueTimeout <= 0 {
+		cc.QueueTimeout = defaultConcurrencyQueueTimeout
+	}
+
+	if cc.MinInFlight <= 0 {
+		cc.MinInFlight = defaultConcurrencyMinInFlight
+	}
+
+	if cc.LatencyTolerance == 0 {
+		cc.LatencyTolerance = defaultConcurrencyLatencyTolerance
+	}
+}
+
+// Validate ...
+func (cc *ConfigConcurrency) Validate() error {
+	if cc.MaxInFlight <= 0 {
+		return errors.New("field MaxInFlight must be greater than zero")
+	}
+
+	if cc.MaxQueue < 0 {
+		return errors.New("field MaxQueue cannot be negative")
+	}
+
+	if cc.QueueTimeout <= 0 {
+		return errors.New("field QueueTimeout must be greater than zero")
+	}
+
+	if cc.Adaptive && (cc.MinInFlight <= 0 || cc.MinInFlight > cc.MaxInFlight) {
+		return errors.New("field MinInFlight must be between 1 and MaxInFlight")
+	}
+
+	if cc.Adaptive && cc.LatencyTolerance <= 1 {
+		return errors.New("field LatencyTolerance must be greater than 1")
+	}
+
+	return nil
+}
+
+func (cs *ConfigService) validateTranscoding() error {
+	if cs.Transcoding == nil || cs.Transcoding.Service == "" {
+		return errors.New("field Transcoding.Service is required")
+	}
+
+	// native gRPC services don't serve description.
+	if cs.Description == nil || cs.Description.Mode != DescriptionModeReplace.String() {
+		return errors.New("description with replace mode is required for grpc_transcoding service")
+	}
+
+	return nil
+}
+
+func (cs *ConfigService) validateHTTP() error {
+	u, err := url.Parse(cs.Address)
+	if err != nil {
+		return fmt.Errorf("field Address is invalid: %w", err)
+	}
+
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return errors.New("field Address must be http or https URL")
+	}
+
+	// HTTP upstreams don't serve description.
+	if cs.Description == nil || cs.Description.Mode != DescriptionModeReplace.String() {
+		return errors.New("description with replace mode is required for http service")
+	}
+
+	if cs.HTTP != nil && cs.HTTP.IdleConnTimeout < 0 {
+		return errors.New("field HTTP.IdleConnTimeout must not be negative")
+	}
+
+	return nil
+}
+
+// SetDefaults ...
+func (ci *ConfigIntrospection) SetDefaults() {
+	if ci.CacheTTL <= 0 {
+		ci.CacheTTL = defaultIntrospectionCacheTTL
+	}
+}
+
+// Validate ...
+func (ci *ConfigIntrospection) Validate() error {
+	if ci.Endpoint == "" {
+		return errors.New("field Endpoint is required")
+	}
+
+	if ci.ClientID == "" {
+		return errors.New("field ClientID is required")
+	}
+
+	if ci.CacheTTL <= 0 {
+		return errors.New("field CacheTTL must be greater than zero")
+	}
+
+	return nil
+}
+
+// SetDefaults ...
+func (ct *ConfigTLS) SetDefaults() {
+	if ct.ClientAuth == "" {
+		ct.ClientAuth = TLSClientAuthNone.String()
+		if len(ct.ClientCAFiles) != 0 {
+			ct.ClientAuth = TLSClientAuthVerifyIfGiven.String()
+		}
+	}
+}
+
+// Validate ...
+func (ct *ConfigTLS) Validate() error {
+	if ct.CertFile == "" {
+		return errors.New("field CertFile is required")
+	}
+
+	if ct.KeyFile == "" {
+		return errors.New("field KeyFile is required")
+	}
+
+	clientAuth, err := ParseTLSClientAuth(ct.ClientAuth)
+	if err != nil {
+		return fmt.Errorf("field ClientAuth is invalid: %w", err)
+	}
+
+	if clientAuth >= TLSClientAuthVerifyIfGiven && len(ct.ClientCAFiles) == 0 {
+		return errors.New("field ClientCAFiles is required to verify client certificates")
+	}
+
+	return nil
+}
+
+// Validate ...
+func (cc *ConfigClientCertificate) Validate() error {
+	if cc.SubjectDN == "" && cc.DNSName == "" && cc.URI == "" && cc.Email == "" && cc.Fingerprint == "" {
+		return errors.New("at least one of fields SubjectDN, DNSName, URI, Email, Fingerprint is required")
+	}
+
+	return nil
+}
+
+// SetDefaults ...
+func (ci *ConfigIdentityToken) SetDefaults() {
+	if ci.Issuer == "" {
+		ci.Issuer = defaultIdentityTokenIssuer
+	}
+
+	if ci.TTL <= 0 {
+		ci.TTL = defaultIdentityTokenTTL
+	}
+}
+
+// Validate ...
+func (ci *ConfigIdentityToken) Validate() error {
+	if ci.Issuer == "" {
+		return errors.New("field Issuer is required")
+	}
+
+	if ci.TTL <= 0 {
+		return errors.New("field TTL must be greater than zero")
+	}
+
+	activeKeyFound := false
+	keyIDs := make(map[string]struct{}, len(ci.Keys))
+
+	for index, key := range ci.Keys {
+		if key.ID == "" || key.PrivateKeyFile == "" {
+			return fmt.Errorf("key with index %d must have ID and PrivateKeyFile", index)
+		}
+
+		if _, exists := keyIDs[key.ID]; exists {
+			return fmt.Errorf("key %s is duplicated", key.ID)
+		}
+
+		keyIDs[key.ID] = struct{}{}
+		activeKeyFound = activeKeyFound || key.ID == ci.ActiveKeyID
+	}
+
+	if !activeKeyFound {
+		return errors.New("field ActiveKeyID must refer to one of keys")
+	}
+
 	return nil
 }
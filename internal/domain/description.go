@@ -0,0 +1,412 @@
+package domain
+
+//go:generate go run github.com/abice/go-enum
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	mapset "github.com/deckarep/golang-set/v2"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/utils/slice"
+)
+
+// DescriptionMode defines how static description is combined with description fetched from provider.
+// ENUM(merge, replace)
+type DescriptionMode uint8
+
+func (cd *ConfigDescription) mode() DescriptionMode {
+	mode, _ := ParseDescriptionMode(cd.Mode) //nolint:errcheck
+	return mode
+}
+
+// NeedFetchDescription returns false if description is fully declared in config.
+func (cs *ConfigService) NeedFetchDescription() bool {
+	return cs.Description == nil || cs.Description.mode() != DescriptionModeReplace
+}
+
+// EffectiveDescription returns description of service with static description applied,
+// fetched description may be nil when it is not needed.
+func (cs *ConfigService) EffectiveDescription(fetched *ProviderDescription) (*ProviderDescription, error) {
+	if cs.Description == nil {
+		return fetched, nil
+	}
+
+	static, err := cs.Description.ToProviderDescription()
+	if err != nil {
+		return nil, err
+	}
+
+	if cs.Description.mode() == DescriptionModeReplace {
+		return static, nil
+	}
+
+	return MergeDescription(fetched, static), nil
+}
+
+var (
+	errMethodsRequired    = errors.New("field Methods is required in replace mode")
+	errMethodNameRequired = errors.New("field Name is required")
+)
+
+// ToProviderDescription converts static description from config.
+func (cd *ConfigDescription) ToProviderDescription() (*ProviderDescription, error) {
+	mode, err := ParseDescriptionMode(cd.Mode)
+	if err != nil {
+		return nil, fmt.Errorf("field Mode is invalid: %w", err)
+	}
+
+	if mode == DescriptionModeReplace && len(cd.Methods) == 0 {
+		return nil, errMethodsRequired
+	}
+
+	description := &ProviderDescription{
+		AuditEnabled:        cd.AuditEnabled,
+		RequiredPermissions: cd.RequiredPermissions,
+		DescriptionByMethod: make(map[string]*ProviderDescriptionMethod, len(cd.Methods)),
+	}
+
+	if description.AuthenticationMode, err = parseAuthenticationMode(cd.AuthenticationMode); err != nil {
+		return nil, fmt.Errorf("field AuthenticationMode is invalid: %w", err)
+	}
+
+	if description.CertificateAuthentication, err = parseCertificateAuthentication(cd.CertificateAuthentication); err != nil {
+		return nil, fmt.Errorf("field CertificateAuthentication is invalid: %w", err)
+	}
+
+	if description.RateLimiters, err = toRateLimiterDescriptions(cd.RateLimiter, cd.RateLimiters); err != nil {
+		return nil, err
+	}
+
+	for index, method := range cd.Methods {
+		methodDescription, err := method.toProviderDescriptionMethod()
+		if err != nil {
+			return nil, fmt.Errorf("method with index %d is invalid: %w", index, err)
+		}
+
+		if mode == DescriptionModeReplace && len(method.AllowedHTTPMethods) == 0 {
+			return nil, fmt.Errorf("method %s must have allowed HTTP methods in replace mode", method.Name)
+		}
+
+		if _, exists := description.DescriptionByMethod[method.Name]; exists {
+			return nil, fmt.Errorf("method %s is declared twice", method.Name)
+		}
+
+		description.DescriptionByMethod[method.Name] = methodDescription
+	}
+
+	return description, nil
+}
+
+func (cm *ConfigDescriptionMethod) toProviderDescriptionMethod() (*ProviderDescriptionMethod, error) {
+	if cm.Name == "" {
+		return nil, errMethodNameRequired
+	}
+
+	description := &ProviderDescriptionMethod{
+		Method:              cm.Name,
+		AuditEnabled:        cm.AuditEnabled,
+		RequiredPermissions: cm.RequiredPermissions,
+	}
+
+	if cm.Cost != nil {
+		description.Cost = &RequestCost{
+			Base:             cm.Cost.Base,
+			QueryParam:       cm.Cost.QueryParam,
+			BodyBytesPerUnit: cm.Cost.BodyBytesPerUnit,
+			Max:              cm.Cost.Max,
+		}
+	}
+
+	var err error
+
+	if cm.Priority != "" {
+		if description.Priority, err = parsePriority(cm.Priority); err != nil {
+			return nil, fmt.Errorf("field Priority is invalid: %w", err)
+		}
+	}
+
+	if description.AuthenticationMode, err = parseAuthenticationMode(cm.AuthenticationMode); err != nil {
+		return nil, fmt.Errorf("field AuthenticationMode is invalid: %w", err)
+	}
+
+	if description.CertificateAuthentication, err = parseCertificateAuthentication(cm.CertificateAuthentication); err != nil {
+		return nil, fmt.Errorf("field CertificateAuthentication is invalid: %w", err)
+	}
+
+	if description.RateLimiters, err = toRateLimiterDescriptions(cm.RateLimiter, cm.RateLimiters); err != nil {
+		return nil, err
+	}
+
+	description.AllowedHTTPMethods = mapset.NewThreadUnsafeSet[HTTPMethod]()
+
+	for _, method := range cm.AllowedHTTPMethods {
+		httpMethod, err := ParseHTTPMethod(strings.ToLower(method))
+		if err != nil || httpMethod == HTTPMethodUnspecified {
+			return nil, fmt.Errorf("field AllowedHTTPMethods has invalid method %q", method)
+		}
+
+		description.AllowedHTTPMethods.Add(httpMethod)
+	}
+
+	return description, nil
+}
+
+// toRateLimiterDescriptions converts single rate limiter and list of them, all of them apply.
+func toRateLimiterDescriptions(single *ConfigRateLimiter, list []*ConfigRateLimiter) ([]*RateLimiterDescription, error) {
+	if single != nil {
+		list = append([]*ConfigRateLimiter{single}, list...)
+	}
+
+	result := make([]*RateLimiterDescription, 0, len(list))
+
+	for index, limiter := range list {
+		description, err := limiter.toRateLimiterDescription()
+		if err != nil {
+			return nil, fmt.Errorf("rate limiter with index %d is invalid: %w", index, err)
+		}
+
+		result = append(result, description)
+	}
+
+	if err := ValidateRateLimiters(result); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// ValidateRateLimiters checks that limiters of one scope can be told apart, they share state otherwise.
+func ValidateRateLimiters(limiters []*RateLimiterDescription) error {
+	ids := make(map[string]struct{}, len(limiters))
+
+	for _, limiter := range limiters {
+		if _, exists := ids[limiter.ID()]; exists {
+			return fmt.Errorf("rate limiter %s is declared twice", limiter.ID())
+		}
+
+		ids[limiter.ID()] = struct{}{}
+	}
+
+	return nil
+}
+
+func (cr *ConfigRateLimiter) toRateLimiterDescription() (*RateLimiterDescription, error) {
+	if cr == nil {
+		return nil, errors.New("rate limiter is empty")
+	}
+
+	by, err := ParseRateLimitDescriptionBy(cr.By)
+	if err != nil {
+		return nil, fmt.Errorf("field By is invalid: %w", err)
+	}
+
+	if cr.Rate == 0 || cr.Burst == 0 || cr.Period <= 0 {
+		return nil, errors.New("fields Rate, Burst and Period must be greater than zero")
+	}
+
+	description := &RateLimiterDescription{
+		By:     by,
+		Name:   cr.Name,
+		Rate:   cr.Rate,
+		Burst:  cr.Burst,
+		Period: cr.Period,
+	}
+
+	for _, key := range cr.Keys {
+		keyBy, err := ParseRateLimitDescriptionBy(key.By)
+		if err != nil {
+			return nil, fmt.Errorf("key is invalid: %w", err)
+		}
+
+		description.Keys = append(description.Keys, RateLimitKey{By: keyBy, Name: key.Name})
+	}
+
+	if err = description.ValidateKey(); err != nil {
+		return nil, err
+	}
+
+	return description, nil
+}
+
+func parseAuthenticationMode(value string) (AuthenticationMode, error) {
+	if value == "" {
+		return AuthenticationModeUnspecified, nil
+	}
+
+	return ParseAuthenticationMode(value)
+}
+
+func parseCertificateAuthentication(value string) (CertificateAuthentication, error) {
+	if value == "" {
+		return CertificateAuthenticationUnspecified, nil
+	}
+
+	return ParseCertificateAuthentication(value)
+}
+
+// MergeDescription tightens fetched description with static one: audit flags and permissions are combined,
+// the strictest authentication mode wins, allowed HTTP methods are intersected, static rate limiters are added
+// to fetched ones, costs are combined into the more expensive one, the lower priority wins, and the strictest
+// certificate authentication wins. Methods declared only in static description are added.
+func MergeDescription(fetched, static *ProviderDescription) *ProviderDescription {
+	if fetched == nil {
+		return static
+	}
+
+	merged := &ProviderDescription{
+		AuditEnabled:              fetched.AuditEnabled || static.AuditEnabled,
+		RateLimiters:              mergeRateLimiters(fetched.RateLimiters, static.RateLimiters),
+		AuthenticationMode:        max(fetched.AuthenticationMode, static.AuthenticationMode),
+		CertificateAuthentication: max(fetched.CertificateAuthentication, static.CertificateAuthentication),
+		RequiredPermissions:       slice.Merge(fetched.RequiredPermissions, static.RequiredPermissions),
+		DescriptionByMethod:       make(map[string]*ProviderDescriptionMethod, len(fetched.DescriptionByMethod)),
+	}
+
+	for name, method := range fetched.DescriptionByMethod {
+		merged.DescriptionByMethod[name] = method
+	}
+
+	for name, staticMethod := range static.DescriptionByMethod {
+		method, ok := merged.DescriptionByMethod[name]
+		if !ok {
+			merged.DescriptionByMethod[name] = staticMethod
+			continue
+		}
+
+		mergedMethod := &ProviderDescriptionMethod{
+			Method:                    name,
+			AuditEnabled:              method.AuditEnabled || staticMethod.AuditEnabled,
+			RateLimiters:              mergeRateLimiters(method.RateLimiters, staticMethod.RateLimiters),
+			AuthenticationMode:        method.AuthenticationMode,
+			CertificateAuthentication: max(method.CertificateAuthentication, staticMethod.CertificateAuthentication),
+			RequiredPermissions:       slice.Merge(method.RequiredPermissions, staticMethod.RequiredPermissions),
+			AllowedHTTPMethods:        method.AllowedHTTPMethods,
+			Cost:                      mergeCost(method.Cost, staticMethod.Cost),
+			Priority:                  mergePriority(method.Priority, staticMethod.Priority),
+		}
+
+		// empty static list keeps methods allowed by provider.
+		if staticMethod.AllowedHTTPMethods.Cardinality() != 0 {
+			mergedMethod.AllowedHTTPMethods = method.AllowedHTTPMethods.Intersect(staticMethod.AllowedHTTPMethods)
+		}
+
+		merged.DescriptionByMethod[name] = mergedMethod
+	}
+
+	// method modes override service modes, so they must be at least as strict as both effective modes.
+	for name, method := range merged.DescriptionByMethod {
+		mode := max(methodAuthenticationMode(fetched, name), methodAuthenticationMode(static, name))
+		certificateAuthentication := max(methodCertificateAuthentication(fetched, name), methodCertificateAuthentication(static, name))
+
+		if mode == method.AuthenticationMode && certificateAuthentication == method.CertificateAuthentication {
+			continue
+		}
+
+		copied := *method
+		copied.AuthenticationMode = mode
+		copied.CertificateAuthentication = certificateAuthentication
+		merged.DescriptionByMethod[name] = &copied
+	}
+
+	return merged
+}
+
+// methodAuthenticationMode returns explicit mode of method or service, without defaults.
+func methodAuthenticationMode(description *ProviderDescription, method string) AuthenticationMode {
+	if desc, ok := description.DescriptionByMethod[method]; ok && desc.AuthenticationMode != AuthenticationModeUnspecified {
+		return desc.AuthenticationMode
+	}
+
+	return description.AuthenticationMode
+}
+
+// methodCertificateAuthentication returns explicit certificate authentication of method or service, without defaults.
+func methodCertificateAuthentication(description *ProviderDescription, method string) CertificateAuthentication {
+	if desc, ok := description.DescriptionByMethod[method]; ok && desc.CertificateAuthentication != CertificateAuthenticationUnspecified {
+		return desc.CertificateAuthentication
+	}
+
+	return description.CertificateAuthentication
+}
+
+// mergeRateLimiters adds static limiters to fetched ones. Limiters with the same key and period would count
+// requests under the same key, so they are replaced by one with the lower rate and burst of both.
+func mergeRateLimiters(fetched, static []*RateLimiterDescription) []*RateLimiterDescription {
+	if len(static) == 0 {
+		return fetched
+	}
+
+	staticByID := make(map[string]*RateLimiterDescription, len(static))
+	for _, limiter := range static {
+		staticByID[limiter.ID()] = limiter
+	}
+
+	merged := make([]*RateLimiterDescription, 0, len(fetched)+len(static))
+
+	for _, limiter := range fetched {
+		staticLimiter, ok := staticByID[limiter.ID()]
+		if !ok {
+			merged = append(merged, limiter)
+			continue
+		}
+
+		delete(staticByID, limiter.ID())
+
+		stricter := *limiter
+		stricter.Rate = min(limiter.Rate, staticLimiter.Rate)
+		stricter.Burst = min(limiter.Burst, staticLimiter.Burst)
+		merged = append(merged, &stricter)
+	}
+
+	for _, limiter := range static {
+		if _, ok := staticByID[limiter.ID()]; ok {
+			merged = append(merged, limiter)
+		}
+	}
+
+	return merged
+}
+
+// mergeCost returns cost that is at least as expensive as static one: the larger base and max are taken,
+// body is split into the smaller units, and static query parameter is preferred.
+func mergeCost(fetched, static *RequestCost) *RequestCost {
+	if fetched == nil || static == nil {
+		if static != nil {
+			return static
+		}
+
+		return fetched
+	}
+
+	merged := &RequestCost{
+		Base:             max(fetched.Base, static.Base),
+		QueryParam:       fetched.QueryParam,
+		BodyBytesPerUnit: fetched.BodyBytesPerUnit,
+	}
+
+	if static.QueryParam != "" {
+		merged.QueryParam = static.QueryParam
+	}
+
+	if static.BodyBytesPerUnit != 0 && (merged.BodyBytesPerUnit == 0 || static.BodyBytesPerUnit < merged.BodyBytesPerUnit) {
+		merged.BodyBytesPerUnit = static.BodyBytesPerUnit
+	}
+
+	// zero max is unlimited.
+	if fetched.Max != 0 && static.Max != 0 {
+		merged.Max = max(fetched.Max, static.Max)
+	}
+
+	return merged
+}
+
+// mergePriority returns the lower of specified priorities.
+func mergePriority(fetched, static Priority) Priority {
+	if fetched == PriorityUnspecified || static == PriorityUnspecified {
+		return max(fetched, static)
+	}
+
+	return min(fetched, static)
+}
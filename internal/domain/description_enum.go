@@ -0,0 +1,56 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// DescriptionModeMerge is a DescriptionMode of type Merge.
+	DescriptionModeMerge DescriptionMode = iota
+	// DescriptionModeReplace is a DescriptionMode of type Replace.
+	DescriptionModeReplace
+)
+
+var ErrInvalidDescriptionMode = errors.New("not a valid DescriptionMode")
+
+const _DescriptionModeName = "mergereplace"
+
+var _DescriptionModeMap = map[DescriptionMode]string{
+	DescriptionModeMerge:   _DescriptionModeName[0:5],
+	DescriptionModeReplace: _DescriptionModeName[5:12],
+}
+
+// String implements the Stringer interface.
+func (x DescriptionMode) String() string {
+	if str, ok := _DescriptionModeMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("DescriptionMode(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x DescriptionMode) IsValid() bool {
+	_, ok := _DescriptionModeMap[x]
+	return ok
+}
+
+var _DescriptionModeValue = map[string]DescriptionMode{
+	_DescriptionModeName[0:5]:  DescriptionModeMerge,
+	_DescriptionModeName[5:12]: DescriptionModeReplace,
+}
+
+// ParseDescriptionMode attempts to convert a string to a DescriptionMode.
+func ParseDescriptionMode(name string) (DescriptionMode, error) {
+	if x, ok := _DescriptionModeValue[name]; ok {
+		return x, nil
+	}
+	return DescriptionMode(0), fmt.Errorf("%s is %w", name, ErrInvalidDescriptionMode)
+}
@@ -0,0 +1,195 @@
+package domain
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEffectiveDescriptionMerge(t *testing.T) {
+	t.Parallel()
+
+	service := &ConfigService{
+		Description: &ConfigDescription{
+			Mode:                DescriptionModeMerge.String(),
+			RequiredPermissions: []string{"read:greeting"},
+			Methods: []*ConfigDescriptionMethod{
+				{
+					Name:               "hello",
+					AllowedHTTPMethods: []string{"GET"},
+					AuditEnabled:       true,
+					RateLimiter:        &ConfigRateLimiter{By: "ip", Rate: 1, Burst: 1, Period: time.Second},
+				},
+			},
+		},
+	}
+
+	fetched := &ProviderDescription{
+		AuthenticationMode: AuthenticationModeRequired,
+		DescriptionByMethod: map[string]*ProviderDescriptionMethod{
+			"hello": {
+				Method:             "hello",
+				AuthenticationMode: AuthenticationModeNone,
+				AllowedHTTPMethods: mapset.NewThreadUnsafeSet(HTTPMethodGet, HTTPMethodPost),
+			},
+		},
+	}
+
+	description, err := service.EffectiveDescription(fetched)
+	require.NoError(t, err)
+
+	hello := description.DescriptionByMethod["hello"]
+	assert.True(t, hello.AuditEnabled)
+	assert.True(t, hello.AllowedHTTPMethods.Equal(mapset.NewThreadUnsafeSet(HTTPMethodGet)))
+	require.Len(t, hello.RateLimiters, 1)
+	assert.Equal(t, uint64(1), hello.RateLimiters[0].Burst)
+	assert.Equal(t, []string{"read:greeting"}, description.Permissions("hello"))
+	assert.Equal(t, AuthenticationModeRequired, description.SelectAuthenticationMode("hello"))
+}
+
+func TestMergeDescriptionKeepsFetchedLimits(t *testing.T) {
+	t.Parallel()
+
+	providerLimiter := &RateLimiterDescription{By: RateLimitDescriptionBySubjectId, Rate: 10, Burst: 10, Period: time.Minute}
+	replacedLimiter := &RateLimiterDescription{By: RateLimitDescriptionByIp, Rate: 100, Burst: 100, Period: time.Second}
+	staticLimiter := &RateLimiterDescription{By: RateLimitDescriptionByIp, Rate: 1, Burst: 1, Period: time.Second}
+
+	fetched := &ProviderDescription{
+		RateLimiters: []*RateLimiterDescription{providerLimiter},
+		DescriptionByMethod: map[string]*ProviderDescriptionMethod{
+			"report": {
+				Method:             "report",
+				RateLimiters:       []*RateLimiterDescription{providerLimiter, replacedLimiter},
+				AllowedHTTPMethods: mapset.NewThreadUnsafeSet(HTTPMethodGet),
+				Cost:               &RequestCost{Base: 5, QueryParam: "limit", Max: 100},
+				Priority:           PriorityCritical,
+			},
+		},
+	}
+
+	static := &ProviderDescription{
+		RateLimiters: []*RateLimiterDescription{staticLimiter},
+		DescriptionByMethod: map[string]*ProviderDescriptionMethod{
+			"report": {
+				Method:             "report",
+				RateLimiters:       []*RateLimiterDescription{staticLimiter},
+				AllowedHTTPMethods: mapset.NewThreadUnsafeSet[HTTPMethod](),
+				Cost:               &RequestCost{Base: 2, BodyBytesPerUnit: 1024},
+				Priority:           PriorityLow,
+			},
+		},
+	}
+
+	merged := MergeDescription(fetched, static)
+	assert.Equal(t, []*RateLimiterDescription{providerLimiter, staticLimiter}, merged.RateLimiters)
+
+	report := merged.DescriptionByMethod["report"]
+	assert.Equal(t, []*RateLimiterDescription{providerLimiter, staticLimiter}, report.RateLimiters)
+	assert.Equal(t, &RequestCost{Base: 5, QueryParam: "limit", BodyBytesPerUnit: 1024}, report.Cost)
+	assert.Equal(t, PriorityLow, report.Priority)
+}
+
+func TestMergeDescriptionDoesNotLoosen(t *testing.T) {
+	t.Parallel()
+
+	fetched := &ProviderDescription{
+		RateLimiters:              []*RateLimiterDescription{{By: RateLimitDescriptionByIp, Rate: 10, Burst: 20, Period: time.Second}},
+		CertificateAuthentication: CertificateAuthenticationRequired,
+		DescriptionByMethod: map[string]*ProviderDescriptionMethod{
+			"upload": {Method: "upload", AllowedHTTPMethods: mapset.NewThreadUnsafeSet(HTTPMethodPost)},
+			"status": {
+				Method:                    "status",
+				AllowedHTTPMethods:        mapset.NewThreadUnsafeSet(HTTPMethodGet),
+				CertificateAuthentication: CertificateAuthenticationAccepted,
+			},
+		},
+	}
+
+	static := &ProviderDescription{
+		RateLimiters:              []*RateLimiterDescription{{By: RateLimitDescriptionByIp, Rate: 100, Burst: 5, Period: time.Second}},
+		CertificateAuthentication: CertificateAuthenticationDisabled,
+		DescriptionByMethod: map[string]*ProviderDescriptionMethod{
+			"upload": {
+				Method:                    "upload",
+				AllowedHTTPMethods:        mapset.NewThreadUnsafeSet[HTTPMethod](),
+				CertificateAuthentication: CertificateAuthenticationDisabled,
+			},
+			"status": {
+				Method:                    "status",
+				AllowedHTTPMethods:        mapset.NewThreadUnsafeSet[HTTPMethod](),
+				CertificateAuthentication: CertificateAuthenticationRequired,
+			},
+		},
+	}
+
+	merged := MergeDescription(fetched, static)
+	assert.Equal(t, []*RateLimiterDescription{{By: RateLimitDescriptionByIp, Rate: 10, Burst: 5, Period: time.Second}}, merged.RateLimiters)
+
+	assert.Equal(t, CertificateAuthenticationRequired, merged.CertificateAuthentication)
+	assert.Equal(t, CertificateAuthenticationRequired, merged.SelectCertificateAuthentication("upload"), "static method must not disable service requirement")
+	assert.Equal(t, CertificateAuthenticationRequired, merged.SelectCertificateAuthentication("status"))
+}
+
+func TestEffectiveDescriptionReplace(t *testing.T) {
+	t.Parallel()
+
+	service := &ConfigService{
+		Description: &ConfigDescription{
+			Mode:               DescriptionModeReplace.String(),
+			AuthenticationMode: AuthenticationModeOptional.String(),
+			Methods: []*ConfigDescriptionMethod{
+				{Name: "legacy", AllowedHTTPMethods: []string{"post"}},
+			},
+		},
+	}
+
+	assert.False(t, service.NeedFetchDescription())
+
+	description, err := service.EffectiveDescription(nil)
+	require.NoError(t, err)
+	assert.Equal(t, AuthenticationModeOptional, description.SelectAuthenticationMode("legacy"))
+	assert.True(t, description.DescriptionByMethod["legacy"].AllowedHTTPMethods.Contains(HTTPMethodPost))
+}
+
+func TestRateLimiterID(t *testing.T) {
+	t.Parallel()
+
+	limiter := &RateLimiterDescription{
+		By: RateLimitDescriptionByComposite,
+		Keys: []RateLimitKey{
+			{By: RateLimitDescriptionBySubjectId},
+			{By: RateLimitDescriptionByClaim, Name: "org_id"},
+		},
+		Period: time.Minute,
+	}
+
+	assert.Equal(t, "subject_id+claim=org_id:1m0s", limiter.ID())
+	assert.NoError(t, limiter.ValidateKey())
+
+	limiter.Keys = nil
+	assert.Error(t, limiter.ValidateKey())
+}
+
+func TestRequestCost(t *testing.T) {
+	t.Parallel()
+
+	cost := &RequestCost{Base: 2, QueryParam: "limit", BodyBytesPerUnit: 1024, Max: 100}
+
+	assert.Equal(t, uint64(2), cost.Cost(nil, nil))
+	assert.Equal(t, uint64(2+50+2), cost.Cost(url.Values{"limit": {"50"}}, make([]byte, 1025)))
+	assert.Equal(t, uint64(100), cost.Cost(url.Values{"limit": {"500"}}, nil))
+	assert.Equal(t, uint64(100), cost.Cost(url.Values{"limit": {"-1"}}, nil))
+	assert.Equal(t, uint64(100), cost.Cost(url.Values{"limit": {"18446744073709551615"}}, nil))
+	assert.Equal(t, uint64(100), cost.Cost(url.Values{"limit": {"9223372036854775807"}}, nil))
+	assert.Equal(t, uint64(1), (*RequestCost)(nil).Cost(nil, nil))
+
+	unlimited := &RequestCost{QueryParam: "limit", BodyBytesPerUnit: 1<<64 - 1}
+	assert.Equal(t, uint64(MaxRequestCost), unlimited.Cost(url.Values{"limit": {"18446744073709551615"}}, nil))
+	assert.Equal(t, uint64(MaxRequestCost), unlimited.Cost(url.Values{"limit": {"9223372036854775807"}}, nil))
+	assert.Equal(t, uint64(MaxRequestCost), unlimited.Cost(url.Values{"limit": {"1e9"}}, nil))
+	assert.Equal(t, uint64(2), unlimited.Cost(nil, []byte("body")))
+}
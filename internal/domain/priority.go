@@ -0,0 +1,103 @@
+package domain
+
+//go:generate go run github.com/abice/go-enum
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Priority of request under overload, higher priority is served first and shed last.
+// ENUM(unspecified, low, normal, high, critical)
+type Priority uint8
+
+// PriorityRules assign priority to consumers.
+type PriorityRules struct {
+	Claim       string
+	ClaimValues map[string]Priority
+	// APIKeys is keyed by SHA-256 hex of API key.
+	APIKeys map[string]Priority
+}
+
+// ToPriorityRules converts priority rules from config.
+func (cp *ConfigPriority) ToPriorityRules() (*PriorityRules, error) {
+	if len(cp.ClaimValues) != 0 && cp.Claim == "" {
+		return nil, errors.New("field Claim is required with ClaimValues")
+	}
+
+	rules := &PriorityRules{
+		Claim:       cp.Claim,
+		ClaimValues: make(map[string]Priority, len(cp.ClaimValues)),
+		APIKeys:     make(map[string]Priority, len(cp.APIKeys)),
+	}
+
+	for value, name := range cp.ClaimValues {
+		priority, err := parsePriority(name)
+		if err != nil {
+			return nil, fmt.Errorf("priority of claim value %s is invalid: %w", value, err)
+		}
+
+		rules.ClaimValues[value] = priority
+	}
+
+	for hash, name := range cp.APIKeys {
+		priority, err := parsePriority(name)
+		if err != nil {
+			return nil, fmt.Errorf("priority of API key %s is invalid: %w", hash, err)
+		}
+
+		rules.APIKeys[hash] = priority
+	}
+
+	return rules, nil
+}
+
+// ConsumerPriority returns the highest priority of subject claim and API key, unspecified when none matches.
+func (r *PriorityRules) ConsumerPriority(subject *SubjectInformation, apiKeyHash string) Priority {
+	if r == nil {
+		return PriorityUnspecified
+	}
+
+	priority := PriorityUnspecified
+
+	if r.Claim != "" {
+		if value, ok := subject.Claims[r.Claim].(string); ok {
+			priority = max(priority, r.ClaimValues[value])
+		}
+	}
+
+	if apiKeyHash != "" {
+		priority = max(priority, r.APIKeys[apiKeyHash])
+	}
+
+	return priority
+}
+
+// SelectPriority returns priority of request to method: the highest of consumer and method priorities,
+// normal when neither is specified.
+func (p *ProviderDescription) SelectPriority(method string, consumer Priority) Priority {
+	priority := consumer
+
+	if desc, ok := p.DescriptionByMethod[method]; ok {
+		priority = max(priority, desc.Priority)
+	}
+
+	if priority == PriorityUnspecified {
+		return PriorityNormal
+	}
+
+	return priority
+}
+
+func parsePriority(value string) (Priority, error) {
+	priority, err := ParsePriority(value)
+	if err != nil {
+		return PriorityUnspecified, err
+	}
+
+	if priority == PriorityUnspecified {
+		return PriorityUnspecified, errors.New("priority must be specified")
+	}
+
+	return priority, nil
+}
@@ -0,0 +1,68 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// PriorityUnspecified is a Priority of type Unspecified.
+	PriorityUnspecified Priority = iota
+	// PriorityLow is a Priority of type Low.
+	PriorityLow
+	// PriorityNormal is a Priority of type Normal.
+	PriorityNormal
+	// PriorityHigh is a Priority of type High.
+	PriorityHigh
+	// PriorityCritical is a Priority of type Critical.
+	PriorityCritical
+)
+
+var ErrInvalidPriority = errors.New("not a valid Priority")
+
+const _PriorityName = "unspecifiedlownormalhighcritical"
+
+var _PriorityMap = map[Priority]string{
+	PriorityUnspecified: _PriorityName[0:11],
+	PriorityLow:         _PriorityName[11:14],
+	PriorityNormal:      _PriorityName[14:20],
+	PriorityHigh:        _PriorityName[20:24],
+	PriorityCritical:    _PriorityName[24:32],
+}
+
+// String implements the Stringer interface.
+func (x Priority) String() string {
+	if str, ok := _PriorityMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("Priority(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x Priority) IsValid() bool {
+	_, ok := _PriorityMap[x]
+	return ok
+}
+
+var _PriorityValue = map[string]Priority{
+	_PriorityName[0:11]:  PriorityUnspecified,
+	_PriorityName[11:14]: PriorityLow,
+	_PriorityName[14:20]: PriorityNormal,
+	_PriorityName[20:24]: PriorityHigh,
+	_PriorityName[24:32]: PriorityCritical,
+}
+
+// ParsePriority attempts to convert a string to a Priority.
+func ParsePriority(name string) (Priority, error) {
+	if x, ok := _PriorityValue[name]; ok {
+		return x, nil
+	}
+	return Priority(0), fmt.Errorf("%s is %w", name, ErrInvalidPriority)
+}
@@ -0,0 +1,45 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSelectPriority(t *testing.T) {
+	t.Parallel()
+
+	rules, err := (&ConfigPriority{
+		Claim:       "plan",
+		ClaimValues: map[string]string{"enterprise": "high", "free": "low"},
+		APIKeys:     map[string]string{"hash": "critical"},
+	}).ToPriorityRules()
+	require.NoError(t, err)
+
+	description := &ProviderDescription{
+		DescriptionByMethod: map[string]*ProviderDescriptionMethod{
+			"export": {Method: "export", Priority: PriorityLow},
+			"login":  {Method: "login", Priority: PriorityCritical},
+		},
+	}
+
+	free := &SubjectInformation{ID: "free", Claims: map[string]any{"plan": "free"}}
+	enterprise := &SubjectInformation{ID: "enterprise", Claims: map[string]any{"plan": "enterprise"}}
+	anonymous := NewAnonymousSubject()
+
+	assert.Equal(t, PriorityNormal, description.SelectPriority("hello", rules.ConsumerPriority(anonymous, "")))
+	assert.Equal(t, PriorityLow, description.SelectPriority("export", rules.ConsumerPriority(free, "")))
+	assert.Equal(t, PriorityHigh, description.SelectPriority("export", rules.ConsumerPriority(enterprise, "")))
+	assert.Equal(t, PriorityCritical, description.SelectPriority("login", rules.ConsumerPriority(free, "")))
+	assert.Equal(t, PriorityCritical, description.SelectPriority("hello", rules.ConsumerPriority(free, "hash")))
+
+	var noRules *PriorityRules
+	assert.Equal(t, PriorityLow, description.SelectPriority("export", noRules.ConsumerPriority(enterprise, "hash")))
+
+	_, err = (&ConfigPriority{ClaimValues: map[string]string{"free": "low"}}).ToPriorityRules()
+	assert.Error(t, err)
+
+	_, err = (&ConfigPriority{APIKeys: map[string]string{"hash": "unspecified"}}).ToPriorityRules()
+	assert.Error(t, err)
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"crypto/x509"
 	"errors"
 	"net/http"
 )
@@ -11,10 +12,15 @@ type ProcessRequest struct {
 	HTTPMethod HTTPMethod
 	APIMethod  string
 	Path       string
+	URL        string
 	Query      string
 	Body       []byte
 	Headers    http.Header
 	RemoteAddr string
+	// ClientCertificate is a leaf certificate presented by caller over TLS.
+	ClientCertificate *x509.Certificate
+	// ClientCertificateVerified is true when ClientCertificate chain was verified against client CAs.
+	ClientCertificateVerified bool
 }
 
 var (
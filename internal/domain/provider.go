@@ -4,7 +4,11 @@ package domain
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	mapset "github.com/deckarep/golang-set/v2"
@@ -14,38 +18,173 @@ import (
 
 const (
 	authorizationHeaderName = "Authorization"
+	dpopHeaderName          = "DPoP"
 	contentTypeHeaderName   = "Content-Type"
 )
 
+// M2MTokenRejectedReason is a reason of error info returned by provider when it rejects M2M token,
+// so gateway refreshes token only on actual rejection and not on any unauthenticated error of handler.
+const M2MTokenRejectedReason = "M2M_TOKEN_REJECTED"
+
+// AuthenticationMode ...
+// ENUM(unspecified, none, optional, required)
+type AuthenticationMode uint8
+
+// CertificateAuthentication ...
+// ENUM(unspecified, disabled, accepted, required)
+type CertificateAuthentication uint8
+
 // RateLimitDescriptionBy ...
-// ENUM(ip, subject_id)
+// ENUM(ip, subject_id, claim, header, query, global, api_key, composite)
 type RateLimitDescriptionBy uint8
 
+// RateLimitKey is a part of composite rate limiter key.
+type RateLimitKey struct {
+	By RateLimitDescriptionBy
+	// Name of claim, header or query parameter.
+	Name string
+}
+
+func (k RateLimitKey) String() string {
+	if k.Name == "" {
+		return k.By.String()
+	}
+
+	return k.By.String() + "=" + k.Name
+}
+
+// Validate ...
+func (k RateLimitKey) Validate() error {
+	switch k.By {
+	case RateLimitDescriptionByClaim, RateLimitDescriptionByHeader, RateLimitDescriptionByQuery:
+		if k.Name == "" {
+			return fmt.Errorf("name is required for key by %s", k.By)
+		}
+	case RateLimitDescriptionByComposite:
+		return errors.New("composite key can't be nested")
+	}
+
+	return nil
+}
+
 // RateLimiterDescription ...
 type RateLimiterDescription struct {
-	By     RateLimitDescriptionBy
+	By RateLimitDescriptionBy
+	// Name of claim, header or query parameter.
+	Name string
+	// Keys of composite limiter.
+	Keys   []RateLimitKey
 	Rate   uint64
 	Burst  uint64
 	Period time.Duration
 }
 
+// KeyParts returns keys that make up limiter key.
+func (r *RateLimiterDescription) KeyParts() []RateLimitKey {
+	if r.By == RateLimitDescriptionByComposite {
+		return r.Keys
+	}
+
+	return []RateLimitKey{{By: r.By, Name: r.Name}}
+}
+
+// ValidateKey checks key parts of limiter.
+func (r *RateLimiterDescription) ValidateKey() error {
+	if r.By == RateLimitDescriptionByComposite && len(r.Keys) == 0 {
+		return errors.New("keys are required for composite limiter")
+	}
+
+	for _, key := range r.KeyParts() {
+		if err := key.Validate(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ID identifies limiter among limiters of one scope.
+func (r *RateLimiterDescription) ID() string {
+	parts := slice.ConvertFunc(r.KeyParts(), RateLimitKey.String)
+	return strings.Join(parts, "+") + ":" + r.Period.String()
+}
+
+// MaxRequestCost is an upper bound of request cost, so caller can't overflow limiters with huge values.
+const MaxRequestCost = 1_000_000
+
+// RequestCost is a count of tokens taken by request from rate limiters.
+type RequestCost struct {
+	// Base cost, 1 when zero.
+	Base uint64
+	// QueryParam adds its integer value to cost, e.g. page size.
+	QueryParam string
+	// BodyBytesPerUnit adds one unit per started chunk of body, disabled when zero.
+	BodyBytesPerUnit uint64
+	// Max cost, unlimited when zero.
+	Max uint64
+}
+
+// Cost returns cost of request with query and body.
+func (c *RequestCost) Cost(query url.Values, body []byte) uint64 {
+	if c == nil {
+		return 1
+	}
+
+	cost := min(max(c.Base, 1), MaxRequestCost)
+
+	if c.QueryParam != "" {
+		if raw := query.Get(c.QueryParam); raw != "" {
+			// invalid value is charged as the most expensive one, so it can't be used to skip limits.
+			value, err := strconv.ParseUint(raw, 10, 64)
+			if err != nil {
+				value = MaxRequestCost
+			}
+
+			cost += min(value, MaxRequestCost)
+		}
+	}
+
+	if c.BodyBytesPerUnit != 0 {
+		units := uint64(len(body)) / c.BodyBytesPerUnit
+		if uint64(len(body))%c.BodyBytesPerUnit != 0 {
+			units++
+		}
+
+		cost += min(units, MaxRequestCost)
+	}
+
+	if c.Max != 0 {
+		cost = min(cost, c.Max)
+	}
+
+	return min(cost, MaxRequestCost)
+}
+
 // ProviderDescription ...
 type ProviderDescription struct {
-	AuditEnabled          bool
-	RateLimiter           *RateLimiterDescription
-	RequireAuthentication bool
-	RequiredPermissions   []string
-	DescriptionByMethod   map[string]*ProviderDescriptionMethod
+	AuditEnabled bool
+	// RateLimiters apply to all methods together with limiters of method.
+	RateLimiters              []*RateLimiterDescription
+	AuthenticationMode        AuthenticationMode
+	CertificateAuthentication CertificateAuthentication
+	RequiredPermissions       []string
+	DescriptionByMethod       map[string]*ProviderDescriptionMethod
+	// Version changes only when description changes.
+	Version string
 }
 
 // ProviderDescriptionMethod ...
 type ProviderDescriptionMethod struct {
-	Method                 string
-	AuditEnabled           bool
-	RateLimiter            *RateLimiterDescription
-	RequiredAuthentication bool
-	RequiredPermissions    []string
-	AllowedHTTPMethods     mapset.Set[HTTPMethod]
+	Method                    string
+	AuditEnabled              bool
+	RateLimiters              []*RateLimiterDescription
+	AuthenticationMode        AuthenticationMode
+	CertificateAuthentication CertificateAuthentication
+	RequiredPermissions       []string
+	AllowedHTTPMethods        mapset.Set[HTTPMethod]
+	// Cost of request, every request costs 1 when nil.
+	Cost     *RequestCost
+	Priority Priority
 }
 
 // NeedAudit ...
@@ -61,26 +200,44 @@ func (p *ProviderDescription) NeedAudit(method string) bool {
 	return false
 }
 
-// SelectRateLimiter ...
-func (p *ProviderDescription) SelectRateLimiter(method string) (description *RateLimiterDescription, isServiceLimiter bool) {
-	if desc, ok := p.DescriptionByMethod[method]; ok && desc.RateLimiter != nil {
-		return desc.RateLimiter, false
+// SelectRateLimiters returns limiters of service and of method, request must be allowed by all of them.
+func (p *ProviderDescription) SelectRateLimiters(method string) (serviceLimiters, methodLimiters []*RateLimiterDescription) {
+	if desc, ok := p.DescriptionByMethod[method]; ok {
+		methodLimiters = desc.RateLimiters
 	}
 
-	return p.RateLimiter, true
+	return p.RateLimiters, methodLimiters
 }
 
-// NeedAuthentication ...
-func (p *ProviderDescription) NeedAuthentication(method string) bool {
-	if p.RequireAuthentication {
-		return true
+// SelectAuthenticationMode returns method mode if it is specified, otherwise service mode.
+// Authentication is always required when method has required permissions.
+func (p *ProviderDescription) SelectAuthenticationMode(method string) AuthenticationMode {
+	if len(p.Permissions(method)) != 0 {
+		return AuthenticationModeRequired
 	}
 
-	if desc, ok := p.DescriptionByMethod[method]; ok {
-		return desc.RequiredAuthentication
+	if desc, ok := p.DescriptionByMethod[method]; ok && desc.AuthenticationMode != AuthenticationModeUnspecified {
+		return desc.AuthenticationMode
 	}
 
-	return false
+	if p.AuthenticationMode != AuthenticationModeUnspecified {
+		return p.AuthenticationMode
+	}
+
+	return AuthenticationModeOptional
+}
+
+// SelectCertificateAuthentication returns method setting if it is specified, otherwise service setting.
+func (p *ProviderDescription) SelectCertificateAuthentication(method string) CertificateAuthentication {
+	if desc, ok := p.DescriptionByMethod[method]; ok && desc.CertificateAuthentication != CertificateAuthenticationUnspecified {
+		return desc.CertificateAuthentication
+	}
+
+	if p.CertificateAuthentication != CertificateAuthenticationUnspecified {
+		return p.CertificateAuthentication
+	}
+
+	return CertificateAuthenticationDisabled
 }
 
 // Permissions ...
@@ -94,8 +251,32 @@ func (p *ProviderDescription) Permissions(method string) []string {
 
 // SubjectInformation ...
 type SubjectInformation struct {
-	ID          string
-	Permissions mapset.Set[string]
+	ID           string
+	TokenID      string
+	Permissions  mapset.Set[string]
+	Anonymous    bool
+	Confirmation TokenConfirmation
+	// Claims of access token.
+	Claims map[string]any
+}
+
+// TokenConfirmation is a key binding of access token (cnf claim).
+type TokenConfirmation struct {
+	JWKThumbprint         string
+	CertificateThumbprint string
+}
+
+// IsBound returns true if token is bound to a key or certificate.
+func (c TokenConfirmation) IsBound() bool {
+	return c.JWKThumbprint != "" || c.CertificateThumbprint != ""
+}
+
+// NewAnonymousSubject returns SubjectInformation of caller without token.
+func NewAnonymousSubject() *SubjectInformation {
+	return &SubjectInformation{
+		Permissions: mapset.NewThreadUnsafeSet[string](),
+		Anonymous:   true,
+	}
 }
 
 var (
@@ -104,7 +285,7 @@ var (
 
 // Validate ...
 func (u *SubjectInformation) Validate() error {
-	if u.ID == "" {
+	if u.ID == "" && !u.Anonymous {
 		return errIDMustBeNotEmpty
 	}
 
@@ -120,11 +301,17 @@ type ProviderProcessRequest struct {
 	Body               []byte
 	Headers            http.Header
 	SubjectInformation *SubjectInformation
+	// IdentityToken is a gateway-signed token with subject information.
+	IdentityToken string
+	// UserToken is a token issued on behalf of subject for service audience by token exchange.
+	UserToken string
+	Priority  Priority
 }
 
 // Preprocess ...
 func (p *ProviderProcessRequest) Preprocess() {
 	p.Headers.Del(authorizationHeaderName)
+	p.Headers.Del(dpopHeaderName)
 }
 
 // ProviderProcessResponse ...
@@ -132,6 +319,8 @@ type ProviderProcessResponse struct {
 	Body       []byte
 	StatusCode uint32
 	Headers    http.Header
+	// ActualCost is reported by provider to charge rate limiters post-hoc, 0 means not reported.
+	ActualCost uint64
 }
 
 // SetDefaults ...
@@ -0,0 +1,62 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSelectAuthenticationMode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		serviceMode AuthenticationMode
+		methodMode  AuthenticationMode
+		service     []string
+		method      []string
+		want        AuthenticationMode
+	}{
+		{name: "default", want: AuthenticationModeOptional},
+		{name: "service mode", serviceMode: AuthenticationModeNone, want: AuthenticationModeNone},
+		{name: "method mode", methodMode: AuthenticationModeRequired, want: AuthenticationModeRequired},
+		{
+			name:        "method mode overrides service mode",
+			serviceMode: AuthenticationModeRequired,
+			methodMode:  AuthenticationModeNone,
+			want:        AuthenticationModeNone,
+		},
+		{
+			name:        "service permissions force required",
+			serviceMode: AuthenticationModeNone,
+			service:     []string{"read"},
+			want:        AuthenticationModeRequired,
+		},
+		{
+			name:       "method permissions force required",
+			methodMode: AuthenticationModeOptional,
+			method:     []string{"write"},
+			want:       AuthenticationModeRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			description := &ProviderDescription{
+				AuthenticationMode:  tt.serviceMode,
+				RequiredPermissions: tt.service,
+				DescriptionByMethod: map[string]*ProviderDescriptionMethod{
+					"hello": {Method: "hello", AuthenticationMode: tt.methodMode, RequiredPermissions: tt.method},
+				},
+			}
+
+			assert.Equal(t, tt.want, description.SelectAuthenticationMode("hello"))
+		})
+	}
+
+	// unknown method takes service mode.
+	description := &ProviderDescription{AuthenticationMode: AuthenticationModeRequired}
+	assert.Equal(t, AuthenticationModeRequired, description.SelectAuthenticationMode("unknown"))
+}
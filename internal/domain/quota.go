@@ -0,0 +1,123 @@
+package domain
+
+//go:generate go run github.com/abice/go-enum
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// QuotaPeriod ...
+// ENUM(day, month)
+type QuotaPeriod uint8
+
+// Quota is a long-period limit of requests, windows of quota are aligned with calendar of time zone.
+type Quota struct {
+	Name   string
+	Keys   []RateLimitKey
+	Limit  uint64
+	Period QuotaPeriod
+	// Location of calendar, windows start at midnight of this location.
+	Location *time.Location
+	// Methods the quota applies to, quota applies to all methods of service when empty.
+	Methods []string
+}
+
+// AppliesTo returns true if requests of method are counted by quota.
+func (q *Quota) AppliesTo(method string) bool {
+	return len(q.Methods) == 0 || slices.Contains(q.Methods, method)
+}
+
+// Window returns start and end of quota window containing t.
+func (q *Quota) Window(t time.Time) (start, end time.Time) {
+	t = t.In(q.Location)
+
+	switch q.Period {
+	case QuotaPeriodMonth:
+		start = time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, q.Location)
+		end = start.AddDate(0, 1, 0)
+	default:
+		start = time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, q.Location)
+		end = start.AddDate(0, 0, 1)
+	}
+
+	return start, end
+}
+
+// SelectQuotas returns quotas applied to method.
+func SelectQuotas(quotas []*Quota, method string) []*Quota {
+	var result []*Quota
+
+	for _, quota := range quotas {
+		if quota.AppliesTo(method) {
+			result = append(result, quota)
+		}
+	}
+
+	return result
+}
+
+// ToQuotas converts quotas of service config.
+func ToQuotas(list []*ConfigQuota) ([]*Quota, error) {
+	result := make([]*Quota, 0, len(list))
+	names := make(map[string]struct{}, len(list))
+
+	for index, cq := range list {
+		quota, err := cq.toQuota()
+		if err != nil {
+			return nil, fmt.Errorf("quota with index %d is invalid: %w", index, err)
+		}
+
+		if _, exists := names[quota.Name]; exists {
+			return nil, fmt.Errorf("quota %s is declared twice", quota.Name)
+		}
+
+		names[quota.Name] = struct{}{}
+		result = append(result, quota)
+	}
+
+	return result, nil
+}
+
+func (cq *ConfigQuota) toQuota() (*Quota, error) {
+	if cq == nil {
+		return nil, errors.New("quota is empty")
+	}
+
+	if cq.Name == "" {
+		return nil, errors.New("field Name is required")
+	}
+
+	if cq.Limit == 0 {
+		return nil, errors.New("field Limit must be greater than zero")
+	}
+
+	period, err := ParseQuotaPeriod(cq.Period)
+	if err != nil {
+		return nil, fmt.Errorf("field Period is invalid: %w", err)
+	}
+
+	location, err := time.LoadLocation(cq.TimeZone)
+	if err != nil {
+		return nil, fmt.Errorf("field TimeZone is invalid: %w", err)
+	}
+
+	// key of quota is declared as key of rate limiter.
+	key := &ConfigRateLimiter{By: cq.By, Name: cq.Key, Keys: cq.Keys, Rate: cq.Limit, Burst: cq.Limit, Period: time.Second}
+
+	limiter, err := key.toRateLimiterDescription()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Quota{
+		Name:     cq.Name,
+		Keys:     limiter.KeyParts(),
+		Limit:    cq.Limit,
+		Period:   period,
+		Location: location,
+		Methods:  cq.Methods,
+	}, nil
+}
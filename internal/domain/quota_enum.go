@@ -0,0 +1,56 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package domain
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// QuotaPeriodDay is a QuotaPeriod of type Day.
+	QuotaPeriodDay QuotaPeriod = iota
+	// QuotaPeriodMonth is a QuotaPeriod of type Month.
+	QuotaPeriodMonth
+)
+
+var ErrInvalidQuotaPeriod = errors.New("not a valid QuotaPeriod")
+
+const _QuotaPeriodName = "daymonth"
+
+var _QuotaPeriodMap = map[QuotaPeriod]string{
+	QuotaPeriodDay:   _QuotaPeriodName[0:3],
+	QuotaPeriodMonth: _QuotaPeriodName[3:8],
+}
+
+// String implements the Stringer interface.
+func (x QuotaPeriod) String() string {
+	if str, ok := _QuotaPeriodMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("QuotaPeriod(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x QuotaPeriod) IsValid() bool {
+	_, ok := _QuotaPeriodMap[x]
+	return ok
+}
+
+var _QuotaPeriodValue = map[string]QuotaPeriod{
+	_QuotaPeriodName[0:3]: QuotaPeriodDay,
+	_QuotaPeriodName[3:8]: QuotaPeriodMonth,
+}
+
+// ParseQuotaPeriod attempts to convert a string to a QuotaPeriod.
+func ParseQuotaPeriod(name string) (QuotaPeriod, error) {
+	if x, ok := _QuotaPeriodValue[name]; ok {
+		return x, nil
+	}
+	return QuotaPeriod(0), fmt.Errorf("%s is %w", name, ErrInvalidQuotaPeriod)
+}
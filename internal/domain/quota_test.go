@@ -0,0 +1,37 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuotaWindow(t *testing.T) {
+	t.Parallel()
+
+	quotas, err := ToQuotas([]*ConfigQuota{
+		{Name: "daily", By: "subject_id", Limit: 100, Period: "day", TimeZone: "Asia/Tokyo"},
+		{Name: "monthly", By: "claim", Key: "org_id", Limit: 1000, Period: "month", TimeZone: "UTC", Methods: []string{"hello"}},
+	})
+	require.NoError(t, err)
+
+	// 2024-01-31 20:00 UTC is 2024-02-01 05:00 in Tokyo.
+	at := time.Date(2024, time.January, 31, 20, 0, 0, 0, time.UTC)
+
+	start, end := quotas[0].Window(at)
+	assert.Equal(t, time.Date(2024, time.January, 31, 15, 0, 0, 0, time.UTC), start.UTC())
+	assert.Equal(t, 24*time.Hour, end.Sub(start))
+
+	start, end = quotas[1].Window(at)
+	assert.Equal(t, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), start)
+	assert.Equal(t, time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC), end)
+
+	assert.Equal(t, []RateLimitKey{{By: RateLimitDescriptionByClaim, Name: "org_id"}}, quotas[1].Keys)
+	assert.Len(t, SelectQuotas(quotas, "hello"), 2)
+	assert.Len(t, SelectQuotas(quotas, "bye"), 1)
+
+	_, err = ToQuotas([]*ConfigQuota{{Name: "daily", By: "claim", Limit: 1, Period: "day"}})
+	assert.Error(t, err, "claim key requires name")
+}
@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"encoding/json"
+	"log/slog"
+)
+
+const redacted = "[REDACTED]"
+
+// Secret is a sensitive config value, it is redacted when printed, logged or marshaled.
+// Value may be a reference like env:NAME or file:/path before it is resolved.
+type Secret string
+
+// Value returns actual value of secret.
+func (s Secret) Value() string {
+	return string(s)
+}
+
+// String ...
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+
+	return redacted
+}
+
+// GoString ...
+func (s Secret) GoString() string {
+	return s.String()
+}
+
+// LogValue ...
+func (s Secret) LogValue() slog.Value {
+	return slog.StringValue(s.String())
+}
+
+// MarshalJSON ...
+func (s Secret) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
@@ -0,0 +1,87 @@
+package exchange
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"golang.org/x/sync/singleflight"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/clients/auth0"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/utils/cache"
+)
+
+const (
+	// expirationMargin is subtracted from token lifetime, so cached token is not expired while request is in flight.
+	expirationMargin = 30 * time.Second
+	// exchangeTimeout bounds exchange shared by concurrent callers, it doesn't depend on context of any of them.
+	exchangeTimeout = 10 * time.Second
+)
+
+type tokenExchanger interface {
+	ExchangeToken(ctx context.Context, subjectToken, subjectTokenType, audience string) (*auth0.Token, error)
+}
+
+type cacheKey struct {
+	subjectID string
+	audience  string
+	// subjectTokenHash separates tokens exchanged from different subject tokens, e.g. with different scopes.
+	subjectTokenHash string
+}
+
+func (k cacheKey) String() string {
+	return k.subjectID + " " + k.audience + " " + k.subjectTokenHash
+}
+
+// Exchanger exchanges user tokens to tokens for other audiences on behalf of user (RFC 8693).
+type Exchanger struct {
+	tokenExchanger tokenExchanger
+	cache          *cache.Cache[cacheKey, string]
+	group          singleflight.Group
+}
+
+// New returns new Exchanger.
+func New(tokenExchanger tokenExchanger) *Exchanger {
+	return &Exchanger{
+		tokenExchanger: tokenExchanger,
+		cache:          cache.New[cacheKey, string](),
+	}
+}
+
+// Exchange returns access token for audience issued on behalf of subject, tokens are cached per subject token and audience.
+func (e *Exchanger) Exchange(ctx context.Context, subject *domain.SubjectInformation, subjectToken, audience string) (string, error) {
+	subjectTokenHash := sha256.Sum256([]byte(subjectToken))
+
+	key := cacheKey{
+		subjectID:        subject.ID,
+		audience:         audience,
+		subjectTokenHash: hex.EncodeToString(subjectTokenHash[:]),
+	}
+
+	if token, ok := e.cache.Get(key); ok {
+		return token, nil
+	}
+
+	token, err, _ := e.group.Do(key.String(), func() (any, error) {
+		// exchange is shared by concurrent callers, so canceled first caller must not fail others.
+		ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), exchangeTimeout)
+		defer cancel()
+
+		token, err := e.tokenExchanger.ExchangeToken(ctx, subjectToken, auth0.TokenTypeAccessToken, audience)
+		if err != nil {
+			return "", fmt.Errorf("failed to exchange token: %w", err)
+		}
+
+		e.cache.Set(key, token.AccessToken, time.Duration(token.ExpiresIn)*time.Second-expirationMargin)
+
+		return token.AccessToken, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return token.(string), nil //nolint:forcetypeassert
+}
@@ -0,0 +1,103 @@
+package exchange
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/clients/auth0"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+type fakeExchanger struct {
+	calls   atomic.Int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func (f *fakeExchanger) ExchangeToken(ctx context.Context, subjectToken, _, audience string) (*auth0.Token, error) {
+	f.calls.Add(1)
+
+	if f.started != nil {
+		f.started <- struct{}{}
+		<-f.release
+	}
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	return &auth0.Token{AccessToken: audience + ":" + subjectToken, ExpiresIn: 3600}, nil
+}
+
+func TestExchangeCachePerSubjectToken(t *testing.T) {
+	t.Parallel()
+
+	exchanger := &fakeExchanger{}
+	e := New(exchanger)
+	subject := &domain.SubjectInformation{ID: "user-1"}
+	ctx := context.Background()
+
+	token, err := e.Exchange(ctx, subject, "read-token", "orders")
+	require.NoError(t, err)
+	assert.Equal(t, "orders:read-token", token)
+
+	token, err = e.Exchange(ctx, subject, "read-token", "orders")
+	require.NoError(t, err)
+	assert.Equal(t, "orders:read-token", token)
+	assert.Equal(t, int32(1), exchanger.calls.Load())
+
+	// the same subject with another token, e.g. with wider scopes, gets its own exchanged token.
+	token, err = e.Exchange(ctx, subject, "write-token", "orders")
+	require.NoError(t, err)
+	assert.Equal(t, "orders:write-token", token)
+	assert.Equal(t, int32(2), exchanger.calls.Load())
+}
+
+func TestExchangeSharedCallIgnoresCallerCancel(t *testing.T) {
+	t.Parallel()
+
+	exchanger := &fakeExchanger{started: make(chan struct{}, 2), release: make(chan struct{})}
+	e := New(exchanger)
+	subject := &domain.SubjectInformation{ID: "user-1"}
+
+	firstCtx, cancel := context.WithCancel(context.Background())
+
+	var (
+		wg      sync.WaitGroup
+		results [2]string
+		errs    [2]error
+	)
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		results[0], errs[0] = e.Exchange(firstCtx, subject, "token", "orders")
+	}()
+
+	<-exchanger.started
+
+	wg.Add(1)
+
+	go func() {
+		defer wg.Done()
+		results[1], errs[1] = e.Exchange(context.Background(), subject, "token", "orders")
+	}()
+
+	// second caller joins call started by the first one, which is canceled then.
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	close(exchanger.release)
+	wg.Wait()
+
+	for i := range results {
+		require.NoError(t, errs[i])
+		assert.Equal(t, "orders:token", results[i])
+	}
+}
@@ -0,0 +1,46 @@
+package geoip
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// Reader looks up countries of IPs in offline MaxMind database, e.g. GeoLite2-Country or GeoIP2-City.
+type Reader struct {
+	db *maxminddb.Reader
+}
+
+type countryRecord struct {
+	Country struct {
+		ISOCode string `maxminddb:"iso_code"`
+	} `maxminddb:"country"`
+}
+
+// Open returns new Reader of mmdb file.
+func Open(path string) (*Reader, error) {
+	db, err := maxminddb.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open GeoIP database: %w", err)
+	}
+
+	return &Reader{db: db}, nil
+}
+
+// Country returns ISO code of country of IP, empty when it is unknown.
+func (r *Reader) Country(addr netip.Addr) string {
+	var record countryRecord
+
+	if err := r.db.Lookup(net.IP(addr.AsSlice()), &record); err != nil {
+		return ""
+	}
+
+	return record.Country.ISOCode
+}
+
+// Close ...
+func (r *Reader) Close() error {
+	return r.db.Close()
+}
@@ -1,14 +1,31 @@
 package admin
 
 import (
+	"encoding/json"
 	"net/http"
 	"net/http/pprof"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gopkg.in/go-jose/go-jose.v2"
 )
 
+type jwksProvider interface {
+	JWKS() jose.JSONWebKeySet
+}
+
+// HandlerOptions ...
+type HandlerOptions struct {
+	RevocationList revocationList
+	JWKSProvider   jwksProvider
+	// QuotaStore and QuotaUsage enable usage reports of quotas.
+	QuotaStore quotaStore
+	QuotaUsage quotaUsage
+	// OverrideTable enables management of rate limit overrides.
+	OverrideTable overrideTable
+}
+
 // Handler returns admin handler.
-func Handler() http.Handler {
+func Handler(opts HandlerOptions) http.Handler {
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("GET /health", func(w http.ResponseWriter, _ *http.Request) {
@@ -23,5 +40,47 @@ func Handler() http.Handler {
 	mux.HandleFunc("/debug/pprof/{action}", pprof.Index)
 	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
 
+	if opts.JWKSProvider != nil {
+		mux.HandleFunc("GET /.well-known/jwks.json", func(w http.ResponseWriter, _ *http.Request) {
+			writeJSON(w, http.StatusOK, opts.JWKSProvider.JWKS())
+		})
+	}
+
+	if opts.RevocationList != nil {
+		registerRevocationHandlers(mux, opts.RevocationList)
+	}
+
+	if opts.QuotaStore != nil && opts.QuotaUsage != nil {
+		registerQuotaHandlers(mux, opts.QuotaStore, opts.QuotaUsage)
+	}
+
+	if opts.OverrideTable != nil {
+		registerOverrideHandlers(mux, opts.OverrideTable)
+	}
+
 	return mux
 }
+
+type jsonError struct {
+	ErrorMsg string `json:"error_msg,omitempty"`
+}
+
+func writeJSON(w http.ResponseWriter, statusCode int, value any) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		writeJSONError(w, http.StatusInternalServerError, "Failed to marshal response: "+err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+	_, _ = w.Write(data) //nolint:errcheck
+}
+
+func writeJSONError(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(statusCode)
+
+	data, _ := json.Marshal(jsonError{ErrorMsg: message}) //nolint:errcheck
+	_, _ = w.Write(data)                                  //nolint:errcheck
+}
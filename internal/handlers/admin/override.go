@@ -0,0 +1,172 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/override"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/ratelimit"
+)
+
+type overrideTable interface {
+	Set(ctx context.Context, entry override.Entry, ttl time.Duration) error
+	Delete(ctx context.Context, service string, kind override.Kind, value string) error
+	Entries(ctx context.Context) ([]override.Entry, error)
+}
+
+type overrideEntry struct {
+	Kind      string     `json:"kind"`
+	Value     string     `json:"value"`
+	Service   string     `json:"service,omitempty"`
+	Action    string     `json:"action"`
+	Rate      uint64     `json:"rate,omitempty"`
+	Burst     uint64     `json:"burst,omitempty"`
+	Period    string     `json:"period,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type setOverrideRequest struct {
+	Kind    string `json:"kind"`
+	Value   string `json:"value"`
+	Service string `json:"service"`
+	Action  string `json:"action"`
+	Rate    uint64 `json:"rate"`
+	Burst   uint64 `json:"burst"`
+	Period  string `json:"period"`
+	TTL     string `json:"ttl"`
+}
+
+func registerOverrideHandlers(mux *http.ServeMux, table overrideTable) {
+	mux.HandleFunc("GET /rate-limit-overrides", func(w http.ResponseWriter, r *http.Request) {
+		entries, err := table.Entries(r.Context())
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, "Failed to get overrides: "+err.Error())
+			return
+		}
+
+		result := make([]overrideEntry, 0, len(entries))
+		for _, entry := range entries {
+			result = append(result, overrideEntryFromDomain(entry))
+		}
+
+		writeJSON(w, http.StatusOK, result)
+	})
+
+	mux.HandleFunc("POST /rate-limit-overrides", func(w http.ResponseWriter, r *http.Request) {
+		var req setOverrideRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeJSONError(w, http.StatusBadRequest, "Failed to decode body: "+err.Error())
+			return
+		}
+
+		entry, ttl, err := req.toEntry()
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if err = table.Set(r.Context(), entry, ttl); err != nil {
+			writeJSONError(w, http.StatusBadRequest, "Failed to set override: "+err.Error())
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, struct{}{})
+	})
+
+	// value is the last segment, because CIDR contains slash.
+	mux.HandleFunc("DELETE /rate-limit-overrides/{kind}/{value...}", func(w http.ResponseWriter, r *http.Request) {
+		kind, value, err := parseOverrideKey(r.PathValue("kind"), r.PathValue("value"))
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		if err = table.Delete(r.Context(), r.URL.Query().Get("service"), kind, value); err != nil {
+			writeJSONError(w, http.StatusInternalServerError, "Failed to delete override: "+err.Error())
+			return
+		}
+
+		writeJSON(w, http.StatusOK, struct{}{})
+	})
+}
+
+func (req *setOverrideRequest) toEntry() (override.Entry, time.Duration, error) {
+	kind, value, err := parseOverrideKey(req.Kind, req.Value)
+	if err != nil {
+		return override.Entry{}, 0, err
+	}
+
+	action, err := override.ParseAction(req.Action)
+	if err != nil {
+		return override.Entry{}, 0, fmt.Errorf("invalid action: %w", err)
+	}
+
+	entry := override.Entry{
+		Kind:    kind,
+		Value:   value,
+		Service: req.Service,
+		Action:  action,
+		Limit:   ratelimit.Limit{Rate: req.Rate, Burst: req.Burst},
+	}
+
+	if req.Period != "" {
+		if entry.Limit.Period, err = time.ParseDuration(req.Period); err != nil {
+			return override.Entry{}, 0, fmt.Errorf("invalid period: %w", err)
+		}
+	}
+
+	var ttl time.Duration
+	if req.TTL != "" {
+		if ttl, err = time.ParseDuration(req.TTL); err != nil || ttl < 0 {
+			return override.Entry{}, 0, errors.New("invalid ttl")
+		}
+	}
+
+	return entry, ttl, nil
+}
+
+// parseOverrideKey normalizes value of kind: API key is hashed and IP is stored as CIDR.
+func parseOverrideKey(kindName, value string) (override.Kind, string, error) {
+	kind, err := override.ParseKind(kindName)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid kind: %w", err)
+	}
+
+	switch kind {
+	case override.KindApiKey:
+		value = override.HashAPIKey(value)
+	case override.KindIp:
+		if value, err = override.NormalizeIP(value); err != nil {
+			return 0, "", fmt.Errorf("invalid value: %w", err)
+		}
+	}
+
+	return kind, value, nil
+}
+
+func overrideEntryFromDomain(entry override.Entry) overrideEntry {
+	result := overrideEntry{
+		Kind:      entry.Kind.String(),
+		Value:     entry.Value,
+		Service:   entry.Service,
+		Action:    entry.Action.String(),
+		CreatedAt: entry.CreatedAt,
+	}
+
+	if entry.Action == override.ActionLimit {
+		result.Rate = entry.Limit.Rate
+		result.Burst = entry.Limit.Burst
+		result.Period = entry.Limit.Period.String()
+	}
+
+	if !entry.ExpiresAt.IsZero() {
+		result.ExpiresAt = &entry.ExpiresAt
+	}
+
+	return result
+}
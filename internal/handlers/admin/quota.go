@@ -0,0 +1,94 @@
+package admin
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/quota"
+)
+
+type quotaStore interface {
+	Get(service string) ([]*domain.Quota, bool)
+}
+
+type quotaUsage interface {
+	Usage(ctx context.Context, service, quota string, windowStart time.Time) ([]quota.Usage, error)
+}
+
+type quotaReport struct {
+	Service     string               `json:"service"`
+	Quota       string               `json:"quota"`
+	Limit       uint64               `json:"limit"`
+	Period      string               `json:"period"`
+	WindowStart time.Time            `json:"window_start"`
+	WindowEnd   time.Time            `json:"window_end"`
+	Consumers   []quotaConsumerUsage `json:"consumers"`
+}
+
+type quotaConsumerUsage struct {
+	Entity    string `json:"entity"`
+	Used      uint64 `json:"used"`
+	Remaining uint64 `json:"remaining"`
+}
+
+func registerQuotaHandlers(mux *http.ServeMux, store quotaStore, usage quotaUsage) {
+	// window containing time in at parameter is reported, e.g. previous month for billing, current window by default.
+	mux.HandleFunc("GET /quotas/{service}/{quota}", func(w http.ResponseWriter, r *http.Request) {
+		q, ok := findQuota(store, r.PathValue("service"), r.PathValue("quota"))
+		if !ok {
+			writeJSONError(w, http.StatusNotFound, "Quota not found")
+			return
+		}
+
+		at := time.Now()
+		if value := r.URL.Query().Get("at"); value != "" {
+			var err error
+			if at, err = time.Parse(time.RFC3339, value); err != nil {
+				writeJSONError(w, http.StatusBadRequest, "Invalid at: "+err.Error())
+				return
+			}
+		}
+
+		windowStart, windowEnd := q.Window(at)
+
+		entries, err := usage.Usage(r.Context(), r.PathValue("service"), q.Name, windowStart)
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, "Failed to get usage: "+err.Error())
+			return
+		}
+
+		report := quotaReport{
+			Service:     r.PathValue("service"),
+			Quota:       q.Name,
+			Limit:       q.Limit,
+			Period:      q.Period.String(),
+			WindowStart: windowStart,
+			WindowEnd:   windowEnd,
+			Consumers:   make([]quotaConsumerUsage, 0, len(entries)),
+		}
+
+		for _, entry := range entries {
+			report.Consumers = append(report.Consumers, quotaConsumerUsage{
+				Entity:    entry.Entity,
+				Used:      entry.Used,
+				Remaining: q.Limit - min(entry.Used, q.Limit),
+			})
+		}
+
+		writeJSON(w, http.StatusOK, report)
+	})
+}
+
+func findQuota(store quotaStore, service, name string) (*domain.Quota, bool) {
+	quotas, _ := store.Get(service)
+
+	for _, q := range quotas {
+		if q.Name == name {
+			return q, true
+		}
+	}
+
+	return nil, false
+}
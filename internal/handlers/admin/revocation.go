@@ -0,0 +1,104 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/revocation"
+)
+
+type revocationList interface {
+	Revoke(ctx context.Context, kind revocation.Kind, id string, ttl time.Duration) error
+	Restore(ctx context.Context, kind revocation.Kind, id string) error
+	Entries(ctx context.Context) ([]revocation.Entry, error)
+}
+
+type revocationEntry struct {
+	Kind      string     `json:"kind"`
+	ID        string     `json:"id"`
+	RevokedAt time.Time  `json:"revoked_at"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+type revokeRequest struct {
+	Kind string `json:"kind"`
+	ID   string `json:"id"`
+	TTL  string `json:"ttl"`
+}
+
+func registerRevocationHandlers(mux *http.ServeMux, list revocationList) {
+	mux.HandleFunc("GET /revocations", func(w http.ResponseWriter, r *http.Request) {
+		entries, err := list.Entries(r.Context())
+		if err != nil {
+			writeJSONError(w, http.StatusInternalServerError, "Failed to get revocations: "+err.Error())
+			return
+		}
+
+		result := make([]revocationEntry, 0, len(entries))
+		for _, entry := range entries {
+			result = append(result, revocationEntryFromDomain(entry))
+		}
+
+		writeJSON(w, http.StatusOK, result)
+	})
+
+	mux.HandleFunc("POST /revocations", func(w http.ResponseWriter, r *http.Request) {
+		var req revokeRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeJSONError(w, http.StatusBadRequest, "Failed to decode body: "+err.Error())
+			return
+		}
+
+		kind, err := revocation.ParseKind(req.Kind)
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "Invalid kind: "+err.Error())
+			return
+		}
+
+		var ttl time.Duration
+		if req.TTL != "" {
+			if ttl, err = time.ParseDuration(req.TTL); err != nil || ttl < 0 {
+				writeJSONError(w, http.StatusBadRequest, "Invalid ttl")
+				return
+			}
+		}
+
+		if err = list.Revoke(r.Context(), kind, req.ID, ttl); err != nil {
+			writeJSONError(w, http.StatusBadRequest, "Failed to revoke: "+err.Error())
+			return
+		}
+
+		writeJSON(w, http.StatusCreated, struct{}{})
+	})
+
+	mux.HandleFunc("DELETE /revocations/{kind}/{id}", func(w http.ResponseWriter, r *http.Request) {
+		kind, err := revocation.ParseKind(r.PathValue("kind"))
+		if err != nil {
+			writeJSONError(w, http.StatusBadRequest, "Invalid kind: "+err.Error())
+			return
+		}
+
+		if err = list.Restore(r.Context(), kind, r.PathValue("id")); err != nil {
+			writeJSONError(w, http.StatusInternalServerError, "Failed to restore: "+err.Error())
+			return
+		}
+
+		writeJSON(w, http.StatusOK, struct{}{})
+	})
+}
+
+func revocationEntryFromDomain(entry revocation.Entry) revocationEntry {
+	result := revocationEntry{
+		Kind:      entry.Kind.String(),
+		ID:        entry.ID,
+		RevokedAt: entry.RevokedAt,
+	}
+
+	if !entry.ExpiresAt.IsZero() {
+		result.ExpiresAt = &entry.ExpiresAt
+	}
+
+	return result
+}
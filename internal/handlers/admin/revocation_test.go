@@ -0,0 +1,71 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/revocation"
+)
+
+func TestRevocationHandlers(t *testing.T) {
+	t.Parallel()
+
+	srv := miniredis.RunT(t)
+	list := revocation.NewRedis(redis.NewClient(&redis.Options{Addr: srv.Addr()}))
+
+	mux := http.NewServeMux()
+	registerRevocationHandlers(mux, list)
+
+	do := func(method, target, body string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		mux.ServeHTTP(rec, httptest.NewRequest(method, target, strings.NewReader(body)))
+
+		return rec
+	}
+
+	assert.Equal(t, http.StatusCreated, do(http.MethodPost, "/revocations", `{"kind":"token","id":"jti-1","ttl":"1h"}`).Code)
+	assert.Equal(t, http.StatusCreated, do(http.MethodPost, "/revocations", `{"kind":"subject","id":"user-1"}`).Code)
+
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/revocations", `{"kind":"device","id":"1"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/revocations", `{"kind":"token","id":"jti-2","ttl":"-1h"}`).Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodPost, "/revocations", `{"kind":"token","ttl":"1h"}`).Code)
+
+	rec := do(http.MethodGet, "/revocations", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var entries []revocationEntry
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &entries))
+	require.Len(t, entries, 2)
+
+	for _, entry := range entries {
+		switch entry.Kind {
+		case "token":
+			assert.Equal(t, "jti-1", entry.ID)
+			require.NotNil(t, entry.ExpiresAt)
+			assert.WithinDuration(t, time.Now().Add(time.Hour), *entry.ExpiresAt, time.Minute)
+		case "subject":
+			assert.Equal(t, "user-1", entry.ID)
+			assert.Nil(t, entry.ExpiresAt)
+		default:
+			t.Errorf("unexpected kind %s", entry.Kind)
+		}
+	}
+
+	assert.Equal(t, http.StatusOK, do(http.MethodDelete, "/revocations/subject/user-1", "").Code)
+	assert.Equal(t, http.StatusBadRequest, do(http.MethodDelete, "/revocations/device/1", "").Code)
+
+	revoked, err := list.IsRevoked(context.Background(), &domain.SubjectInformation{ID: "user-1"})
+	require.NoError(t, err)
+	assert.False(t, revoked)
+}
@@ -4,19 +4,27 @@ import (
 	"encoding/json"
 	"io"
 	"net/http"
+	"net/netip"
+	"net/url"
 	"strings"
 
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/processor"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/utils/netutil"
 )
 
 const (
 	maxBodySize = 1 << 20 // 10mb
 )
 
-// Handler is a api-gateway handler.
-func Handler(processor processor.Processor) http.HandlerFunc { //revive:disable:import-shadowing
+// Handler is a api-gateway handler. X-Forwarded-Proto and X-Forwarded-Host are honored only from trustedProxies.
+func Handler(processor processor.Processor, trustedProxies []netip.Prefix) http.HandlerFunc { //revive:disable:import-shadowing
 	return func(w http.ResponseWriter, r *http.Request) {
+		if !validPath(r.URL) {
+			writeJSONError(w, http.StatusBadRequest, "Invalid path")
+			return
+		}
+
 		splitedPath := strings.SplitN(strings.TrimPrefix(r.URL.Path, "/"), "/", 3)
 		if len(splitedPath) <= 2 {
 			writeJSONError(w, http.StatusBadRequest, "Invalid path")
@@ -37,16 +45,24 @@ func Handler(processor processor.Processor) http.HandlerFunc { //revive:disable:
 			return
 		}
 
-		resp := processor.Process(r.Context(), &domain.ProcessRequest{
+		processRequest := &domain.ProcessRequest{
 			Service:    serviceName,
 			HTTPMethod: httpMethodToDomain(r.Method),
 			APIMethod:  method,
 			Path:       path,
+			URL:        requestURL(r, trustedProxies),
 			Query:      r.URL.RawQuery,
 			Body:       body,
 			Headers:    r.Header,
 			RemoteAddr: r.RemoteAddr,
-		})
+		}
+
+		if r.TLS != nil && len(r.TLS.PeerCertificates) != 0 {
+			processRequest.ClientCertificate = r.TLS.PeerCertificates[0]
+			processRequest.ClientCertificateVerified = len(r.TLS.VerifiedChains) != 0
+		}
+
+		resp := processor.Process(r.Context(), processRequest)
 		for name, values := range resp.Headers {
 			for _, value := range values {
 				w.Header().Add(name, value)
@@ -58,8 +74,53 @@ func Handler(processor processor.Processor) http.HandlerFunc { //revive:disable:
 	}
 }
 
+// requestURL returns URL that was used by caller, respecting headers of trusted reverse proxies.
+func requestURL(r *http.Request, trustedProxies []netip.Prefix) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+
+	host := r.Host
+
+	if netutil.FromTrustedProxy(r.RemoteAddr, trustedProxies) {
+		// chained proxies append their values, the first one is set by proxy that caller connected to.
+		if proto := firstForwardedValue(r.Header.Get("X-Forwarded-Proto")); proto != "" {
+			scheme = proto
+		}
+
+		if forwardedHost := firstForwardedValue(r.Header.Get("X-Forwarded-Host")); forwardedHost != "" {
+			host = forwardedHost
+		}
+	}
+
+	return (&url.URL{Scheme: scheme, Host: host, Path: r.URL.Path}).String()
+}
+
+func firstForwardedValue(value string) string {
+	first, _, _ := strings.Cut(value, ",")
+	return strings.TrimSpace(first)
+}
+
+// validPath reports whether path has no dot segments and no encoded slashes,
+// so service, method and upstream path can't be smuggled past permission checks.
+func validPath(u *url.URL) bool {
+	escaped := strings.ToLower(u.EscapedPath())
+	if strings.Contains(escaped, "%2f") || strings.Contains(escaped, "%5c") {
+		return false
+	}
+
+	for _, segment := range strings.Split(u.Path, "/") {
+		if segment == "." || segment == ".." {
+			return false
+		}
+	}
+
+	return true
+}
+
 func readBody(w http.ResponseWriter, r *http.Request) ([]byte, error) {
-	if r.Body != nil {
+	if r.Body == nil {
 		return nil, nil
 	}
 
@@ -0,0 +1,53 @@
+package gateway
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestURL(t *testing.T) {
+	t.Parallel()
+
+	trustedProxies := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		proto      string
+		tls        bool
+		want       string
+	}{
+		{name: "trusted proxy", remoteAddr: "10.0.0.1:1234", want: "https://api.example.com/svc/method"},
+		{name: "chained proxies", remoteAddr: "10.0.0.1:1234", proto: " https , http", want: "https://api.example.com/svc/method"},
+		{name: "untrusted caller", remoteAddr: "203.0.113.7:1234", want: "http://gateway.internal/svc/method"},
+		{name: "untrusted caller with tls", remoteAddr: "203.0.113.7:1234", tls: true, want: "https://gateway.internal/svc/method"},
+		{name: "invalid remote address", remoteAddr: "pipe", want: "http://gateway.internal/svc/method"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := httptest.NewRequest(http.MethodGet, "http://gateway.internal/svc/method", nil)
+			r.RemoteAddr = tt.remoteAddr
+			proto := "https"
+			if tt.proto != "" {
+				proto = tt.proto
+			}
+
+			r.Header.Set("X-Forwarded-Proto", proto)
+			r.Header.Set("X-Forwarded-Host", "api.example.com")
+
+			if tt.tls {
+				r.TLS = &tls.ConnectionState{}
+			}
+
+			assert.Equal(t, tt.want, requestURL(r, trustedProxies))
+		})
+	}
+}
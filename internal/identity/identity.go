@@ -0,0 +1,114 @@
+package identity
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+
+	"gopkg.in/go-jose/go-jose.v2"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+)
+
+// MetadataKey is a gRPC metadata key of identity token.
+const MetadataKey = "x-identity-token"
+
+// Claims of identity token.
+type Claims struct {
+	jwt.Claims
+	Permissions []string `json:"permissions,omitempty"`
+	Anonymous   bool     `json:"anonymous,omitempty"`
+	// TokenClaims are claims of original access token.
+	TokenClaims map[string]any `json:"token_claims,omitempty"`
+}
+
+// Key is a signing key of identity tokens.
+type Key struct {
+	ID         string
+	PrivateKey crypto.Signer
+}
+
+// Algorithm returns signature algorithm for key type.
+func (k Key) Algorithm() (jose.SignatureAlgorithm, error) {
+	switch key := k.PrivateKey.(type) {
+	case *rsa.PrivateKey:
+		return jose.RS256, nil
+	case *ecdsa.PrivateKey:
+		switch key.Curve {
+		case elliptic.P256():
+			return jose.ES256, nil
+		case elliptic.P384():
+			return jose.ES384, nil
+		case elliptic.P521():
+			return jose.ES512, nil
+		}
+	case ed25519.PrivateKey:
+		return jose.EdDSA, nil
+	}
+
+	return "", fmt.Errorf("unsupported key type %T", k.PrivateKey)
+}
+
+func (k Key) publicJWK() (jose.JSONWebKey, error) {
+	algorithm, err := k.Algorithm()
+	if err != nil {
+		return jose.JSONWebKey{}, err
+	}
+
+	return jose.JSONWebKey{
+		Key:       k.PrivateKey.Public(),
+		KeyID:     k.ID,
+		Algorithm: string(algorithm),
+		Use:       "sig",
+	}, nil
+}
+
+var (
+	errNoPEMBlock = errors.New("no PEM block found")
+)
+
+// LoadKey reads PEM encoded PKCS#8, PKCS#1 or SEC 1 private key from file.
+func LoadKey(id, path string) (Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Key{}, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return Key{}, errNoPEMBlock
+	}
+
+	var privateKey any
+
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		privateKey, err = x509.ParseECPrivateKey(block.Bytes)
+	default:
+		privateKey, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	}
+
+	if err != nil {
+		return Key{}, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return Key{}, fmt.Errorf("unsupported key type %T", privateKey)
+	}
+
+	key := Key{ID: id, PrivateKey: signer}
+	if _, err = key.Algorithm(); err != nil {
+		return Key{}, err
+	}
+
+	return key, nil
+}
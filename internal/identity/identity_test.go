@@ -0,0 +1,80 @@
+package identity
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+func TestSignAndVerify(t *testing.T) {
+	t.Parallel()
+
+	newKey := func(id string) Key {
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		return Key{ID: id, PrivateKey: privateKey}
+	}
+
+	oldKey, newKeyValue := newKey("old"), newKey("new")
+
+	signer, err := NewSigner("gateway", time.Minute, []Key{oldKey}, "old")
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(signer.JWKS()) //nolint:errcheck
+	}))
+	defer srv.Close()
+
+	verifier := NewVerifier(VerifierOptions{
+		JWKSURL:  srv.URL,
+		Issuer:   "gateway",
+		Audience: "greeting",
+	})
+
+	subject := &domain.SubjectInformation{
+		ID:          "auth0|1",
+		Permissions: mapset.NewThreadUnsafeSet("read:test"),
+		Claims:      map[string]any{"org_id": "org"},
+	}
+
+	token, err := signer.Sign("greeting", subject)
+	require.NoError(t, err)
+
+	claims, err := verifier.Verify(context.Background(), token)
+	require.NoError(t, err)
+	assert.Equal(t, "auth0|1", claims.Subject)
+	assert.Equal(t, []string{"read:test"}, claims.Permissions)
+	assert.Equal(t, "org", claims.TokenClaims["org_id"])
+
+	_, err = verifier.Verify(context.Background(), mustSign(t, signer, "other", subject))
+	assert.Error(t, err)
+
+	require.NoError(t, signer.SetKeys([]Key{oldKey, newKeyValue}, "new"))
+	verifier.refreshedAt = time.Time{} // skip refresh interval
+
+	claims, err = verifier.Verify(context.Background(), mustSign(t, signer, "greeting", subject))
+	require.NoError(t, err)
+	assert.Equal(t, "auth0|1", claims.Subject)
+}
+
+func mustSign(t *testing.T, signer *Signer, audience string, subject *domain.SubjectInformation) string {
+	t.Helper()
+
+	token, err := signer.Sign(audience, subject)
+	require.NoError(t, err)
+
+	return token
+}
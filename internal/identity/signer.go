@@ -0,0 +1,127 @@
+package identity
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"gopkg.in/go-jose/go-jose.v2"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+type keySet struct {
+	signer jose.Signer
+	jwks   jose.JSONWebKeySet
+}
+
+// Signer issues short-lived identity tokens for providers.
+type Signer struct {
+	issuer string
+	ttl    time.Duration
+	keys   atomic.Pointer[keySet]
+}
+
+// NewSigner returns new Signer, tokens are signed with active key, all keys are published in JWKS.
+func NewSigner(issuer string, ttl time.Duration, keys []Key, activeKeyID string) (*Signer, error) {
+	s := &Signer{
+		issuer: issuer,
+		ttl:    ttl,
+	}
+
+	if err := s.SetKeys(keys, activeKeyID); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+var (
+	errActiveKeyNotFound = errors.New("active key not found")
+)
+
+// SetKeys replaces signing keys, it can be used to rotate keys without restart.
+// New key should be published for a while before it becomes active, so providers can fetch it.
+func (s *Signer) SetKeys(keys []Key, activeKeyID string) error {
+	set := &keySet{
+		jwks: jose.JSONWebKeySet{Keys: make([]jose.JSONWebKey, 0, len(keys))},
+	}
+
+	for _, key := range keys {
+		jwk, err := key.publicJWK()
+		if err != nil {
+			return fmt.Errorf("key %s: %w", key.ID, err)
+		}
+
+		set.jwks.Keys = append(set.jwks.Keys, jwk)
+
+		if key.ID != activeKeyID {
+			continue
+		}
+
+		set.signer, err = jose.NewSigner(
+			jose.SigningKey{
+				Algorithm: jose.SignatureAlgorithm(jwk.Algorithm),
+				Key:       jose.JSONWebKey{Key: key.PrivateKey, KeyID: key.ID},
+			},
+			(&jose.SignerOptions{}).WithType("JWT"),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to create signer for key %s: %w", key.ID, err)
+		}
+	}
+
+	if set.signer == nil {
+		return errActiveKeyNotFound
+	}
+
+	s.keys.Store(set)
+
+	return nil
+}
+
+// JWKS returns public keys that can be used to verify tokens.
+func (s *Signer) JWKS() jose.JSONWebKeySet {
+	return s.keys.Load().jwks
+}
+
+// Sign returns identity token of subject for provided audience.
+func (s *Signer) Sign(audience string, subject *domain.SubjectInformation) (string, error) {
+	now := time.Now()
+
+	claims := Claims{
+		Claims: jwt.Claims{
+			Issuer:    s.issuer,
+			Subject:   subject.ID,
+			Audience:  jwt.Audience{audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Expiry:    jwt.NewNumericDate(now.Add(s.ttl)),
+			ID:        newTokenID(),
+		},
+		Anonymous:   subject.Anonymous,
+		TokenClaims: subject.Claims,
+	}
+
+	if subject.Permissions != nil {
+		claims.Permissions = subject.Permissions.ToSlice()
+	}
+
+	token, err := jwt.Signed(s.keys.Load().signer).Claims(claims).CompactSerialize()
+	if err != nil {
+		return "", fmt.Errorf("failed to sign identity token: %w", err)
+	}
+
+	return token, nil
+}
+
+func newTokenID() string {
+	data := make([]byte, 16)
+	_, _ = rand.Read(data) //nolint:errcheck
+
+	return hex.EncodeToString(data)
+}
@@ -0,0 +1,152 @@
+package identity
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"gopkg.in/go-jose/go-jose.v2"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+)
+
+const (
+	defaultJWKSRefreshPeriod = 5 * time.Minute
+	minJWKSRefreshInterval   = 10 * time.Second
+	verifyLeeway             = 5 * time.Second
+)
+
+// Verifier validates identity tokens using JWKS published by gateway.
+type Verifier struct {
+	jwksURL    string
+	issuer     string
+	audience   string
+	httpClient *http.Client
+
+	mux         sync.Mutex
+	jwks        *jose.JSONWebKeySet
+	refreshedAt time.Time
+}
+
+// VerifierOptions ...
+type VerifierOptions struct {
+	JWKSURL    string
+	Issuer     string
+	Audience   string
+	HTTPClient *http.Client
+}
+
+// NewVerifier returns new Verifier.
+func NewVerifier(opts VerifierOptions) *Verifier {
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+
+	return &Verifier{
+		jwksURL:    opts.JWKSURL,
+		issuer:     opts.Issuer,
+		audience:   opts.Audience,
+		httpClient: opts.HTTPClient,
+	}
+}
+
+var (
+	errUnknownKey       = errors.New("unknown identity token key")
+	errAudienceRequired = errors.New("identity token audience is not configured")
+)
+
+// Verify returns claims of valid identity token. Audience is always checked, so token signed for
+// one service is not accepted by another.
+func (v *Verifier) Verify(ctx context.Context, token string) (*Claims, error) {
+	if v.audience == "" {
+		return nil, errAudienceRequired
+	}
+
+	parsed, err := jwt.ParseSigned(token)
+	if err != nil {
+		return nil, fmt.Errorf("parse identity token: %w", err)
+	}
+
+	if len(parsed.Headers) != 1 {
+		return nil, errors.New("identity token must have exactly one signature")
+	}
+
+	key, err := v.key(ctx, parsed.Headers[0].KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var claims Claims
+	if err = parsed.Claims(key, &claims); err != nil {
+		return nil, fmt.Errorf("verify identity token: %w", err)
+	}
+
+	expected := jwt.Expected{Issuer: v.issuer, Audience: jwt.Audience{v.audience}, Time: time.Now()}
+	if err = claims.ValidateWithLeeway(expected, verifyLeeway); err != nil {
+		return nil, fmt.Errorf("validate identity token: %w", err)
+	}
+
+	return &claims, nil
+}
+
+// key returns key by id, JWKS is refreshed periodically and when unknown key is requested to support rotation.
+func (v *Verifier) key(ctx context.Context, keyID string) (*jose.JSONWebKey, error) {
+	v.mux.Lock()
+	defer v.mux.Unlock()
+
+	if v.jwks != nil && time.Since(v.refreshedAt) < defaultJWKSRefreshPeriod {
+		if keys := v.jwks.Key(keyID); len(keys) != 0 {
+			return &keys[0], nil
+		}
+	}
+
+	if v.jwks == nil || time.Since(v.refreshedAt) >= minJWKSRefreshInterval {
+		jwks, err := v.fetch(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		v.jwks = jwks
+		v.refreshedAt = time.Now()
+	}
+
+	if keys := v.jwks.Key(keyID); len(keys) != 0 {
+		return &keys[0], nil
+	}
+
+	return nil, errUnknownKey
+}
+
+func (v *Verifier) fetch(ctx context.Context) (*jose.JSONWebKeySet, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.jwksURL, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create new request: %w", err)
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JWKS: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("got JWKS response with unexpected status %d", resp.StatusCode)
+	}
+
+	var jwks jose.JSONWebKeySet
+	if err = json.Unmarshal(data, &jwks); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JWKS: %w", err)
+	}
+
+	return &jwks, nil
+}
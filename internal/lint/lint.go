@@ -0,0 +1,258 @@
+package lint
+
+//go:generate go run github.com/abice/go-enum --marshal
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+// Severity of finding.
+// ENUM(error, warning)
+type Severity uint8
+
+// Finding is a single problem found in config or descriptions.
+type Finding struct {
+	Severity Severity `json:"severity"`
+	Service  string   `json:"service,omitempty"`
+	Method   string   `json:"method,omitempty"`
+	Message  string   `json:"message"`
+}
+
+// Report of validation.
+type Report struct {
+	Findings []Finding `json:"findings"`
+}
+
+// HasErrors returns true if report contains at least one error.
+func (r *Report) HasErrors() bool {
+	for _, f := range r.Findings {
+		if f.Severity == SeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *Report) add(severity Severity, service, method, format string, args ...any) {
+	r.Findings = append(r.Findings, Finding{
+		Severity: severity,
+		Service:  service,
+		Method:   method,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// Options ...
+type Options struct {
+	Config *domain.Config
+	// FetchDescription returns description of service, description is not checked when function or result is nil.
+	FetchDescription func(ctx context.Context, service *domain.ConfigService) (*domain.ProviderDescription, error)
+	// CheckAudience obtains M2M token for audience, audiences are not checked when nil.
+	CheckAudience func(ctx context.Context, audience string) error
+}
+
+// Run checks config and, optionally, providers and their descriptions.
+func Run(ctx context.Context, opts Options) *Report {
+	report := &Report{Findings: make([]Finding, 0)}
+
+	checkConfig(report, opts.Config)
+
+	if opts.CheckAudience != nil {
+		checkAudiences(ctx, report, opts.Config, opts.CheckAudience)
+	}
+
+	if opts.FetchDescription != nil {
+		for _, service := range opts.Config.Services {
+			description, err := opts.FetchDescription(ctx, service)
+			if err != nil {
+				report.add(SeverityError, service.Name, "", "provider is unreachable: %s", err)
+				continue
+			}
+
+			if description == nil {
+				continue
+			}
+
+			CheckDescription(report, service, description)
+		}
+	}
+
+	return report
+}
+
+func checkConfig(report *Report, cfg *domain.Config) {
+	names := make(map[string]struct{}, len(cfg.Services))
+
+	for _, service := range cfg.Services {
+		if _, exists := names[service.Name]; exists {
+			report.add(SeverityError, service.Name, "", "service name is not unique")
+		}
+
+		names[service.Name] = struct{}{}
+
+		// base URL of http service is checked by config validation.
+		if service.Kind != domain.ServiceKindHttp.String() {
+			if err := validateAddress(service.Address); err != nil {
+				report.add(SeverityError, service.Name, "", "address %q is invalid: %s", service.Address, err)
+			}
+		}
+
+		if service.TokenExchangeAudience != "" && service.TokenExchangeAudience == service.M2MAudience {
+			report.add(SeverityWarning, service.Name, "", "token exchange audience equals M2M audience, provider can't distinguish user and gateway calls by audience")
+		}
+	}
+}
+
+// validateAddress checks host:port or gRPC target URI like dns:///host:port.
+func validateAddress(address string) error {
+	hostPort := address
+
+	if strings.Contains(address, "://") {
+		u, err := url.Parse(address)
+		if err != nil {
+			return err
+		}
+
+		hostPort = u.Host
+		if hostPort == "" {
+			hostPort = strings.TrimPrefix(u.Path, "/")
+		}
+	}
+
+	host, port, err := net.SplitHostPort(hostPort)
+	if err != nil {
+		return err
+	}
+
+	if host == "" && port == "" {
+		return fmt.Errorf("host and port are empty")
+	}
+
+	if port == "" {
+		return fmt.Errorf("port is empty")
+	}
+
+	return nil
+}
+
+func checkAudiences(ctx context.Context, report *Report, cfg *domain.Config, check func(ctx context.Context, audience string) error) {
+	checked := make(map[string]error)
+
+	for _, service := range cfg.Services {
+		if service.M2MAudience == "" {
+			continue
+		}
+
+		err, ok := checked[service.M2MAudience]
+		if !ok {
+			err = check(ctx, service.M2MAudience)
+			checked[service.M2MAudience] = err
+		}
+
+		if err != nil {
+			report.add(SeverityError, service.Name, "", "M2M audience %q is unreachable: %s", service.M2MAudience, err)
+		}
+	}
+}
+
+// CheckDescription adds findings about conflicting rules of description.
+func CheckDescription(report *Report, service *domain.ConfigService, description *domain.ProviderDescription) {
+	checkRateLimiters(report, service.Name, "", description.RateLimiters)
+
+	if description.AuthenticationMode == domain.AuthenticationModeNone && len(description.RequiredPermissions) != 0 {
+		report.add(SeverityWarning, service.Name, "", "service has required permissions, authentication mode none is ignored")
+	}
+
+	methods := make([]string, 0, len(description.DescriptionByMethod))
+	for method := range description.DescriptionByMethod {
+		methods = append(methods, method)
+	}
+
+	sort.Strings(methods)
+
+	for _, method := range methods {
+		methodDescription := description.DescriptionByMethod[method]
+
+		checkRateLimiters(report, service.Name, method, methodDescription.RateLimiters)
+
+		if methodDescription.AuthenticationMode == domain.AuthenticationModeNone && len(description.Permissions(method)) != 0 {
+			report.add(SeverityWarning, service.Name, method, "method has required permissions, authentication mode none is ignored")
+		}
+
+		if methodDescription.AllowedHTTPMethods == nil || methodDescription.AllowedHTTPMethods.Cardinality() == 0 {
+			report.add(SeverityError, service.Name, method, "method has no allowed HTTP methods and is unreachable")
+		}
+
+		mode := description.SelectAuthenticationMode(method)
+
+		serviceLimiters, methodLimiters := description.SelectRateLimiters(method)
+		checkCost(report, service.Name, method, methodDescription.Cost, slices.Concat(serviceLimiters, methodLimiters))
+
+		if mode == domain.AuthenticationModeNone && slices.ContainsFunc(slices.Concat(serviceLimiters, methodLimiters), isBySubject) {
+			report.add(SeverityWarning, service.Name, method, "rate limiter by subject id or claim is used without authentication, callers are limited by IP")
+		}
+
+		if service.RequireDPoP && mode == domain.AuthenticationModeNone {
+			report.add(SeverityWarning, service.Name, method, "service requires DPoP, but method doesn't authenticate callers")
+		}
+	}
+}
+
+// isBySubject returns true if limiter key depends on token of caller.
+func isBySubject(rateLimiter *domain.RateLimiterDescription) bool {
+	return slices.ContainsFunc(rateLimiter.KeyParts(), func(key domain.RateLimitKey) bool {
+		return key.By == domain.RateLimitDescriptionBySubjectId || key.By == domain.RateLimitDescriptionByClaim
+	})
+}
+
+func checkRateLimiters(report *Report, service, method string, rateLimiters []*domain.RateLimiterDescription) {
+	for _, rateLimiter := range rateLimiters {
+		checkRateLimiter(report, service, method, rateLimiter)
+	}
+
+	if err := domain.ValidateRateLimiters(rateLimiters); err != nil {
+		report.add(SeverityError, service, method, "%s", err)
+	}
+}
+
+func checkCost(report *Report, service, method string, cost *domain.RequestCost, rateLimiters []*domain.RateLimiterDescription) {
+	if cost == nil {
+		return
+	}
+
+	// cost of request without query and body is the smallest one.
+	minCost := cost.Cost(nil, nil)
+
+	for _, rateLimiter := range rateLimiters {
+		if minCost > rateLimiter.Burst {
+			report.add(SeverityError, service, method, "request cost %d exceeds burst %d of rate limiter %s, all requests are rejected", minCost, rateLimiter.Burst, rateLimiter.ID())
+		}
+	}
+}
+
+func checkRateLimiter(report *Report, service, method string, rateLimiter *domain.RateLimiterDescription) {
+	if rateLimiter.Burst == 0 {
+		report.add(SeverityError, service, method, "rate limiter has zero burst and rejects all requests")
+	}
+
+	if rateLimiter.Rate == 0 {
+		report.add(SeverityError, service, method, "rate limiter has zero rate")
+	}
+
+	if rateLimiter.Period <= 0 {
+		report.add(SeverityError, service, method, "rate limiter period must be greater than zero")
+	}
+
+	if err := rateLimiter.ValidateKey(); err != nil {
+		report.add(SeverityError, service, method, "rate limiter key is invalid: %s", err)
+	}
+}
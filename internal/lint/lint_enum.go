@@ -0,0 +1,72 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package lint
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// SeverityError is a Severity of type Error.
+	SeverityError Severity = iota
+	// SeverityWarning is a Severity of type Warning.
+	SeverityWarning
+)
+
+var ErrInvalidSeverity = errors.New("not a valid Severity")
+
+const _SeverityName = "errorwarning"
+
+var _SeverityMap = map[Severity]string{
+	SeverityError:   _SeverityName[0:5],
+	SeverityWarning: _SeverityName[5:12],
+}
+
+// String implements the Stringer interface.
+func (x Severity) String() string {
+	if str, ok := _SeverityMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("Severity(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x Severity) IsValid() bool {
+	_, ok := _SeverityMap[x]
+	return ok
+}
+
+var _SeverityValue = map[string]Severity{
+	_SeverityName[0:5]:  SeverityError,
+	_SeverityName[5:12]: SeverityWarning,
+}
+
+// ParseSeverity attempts to convert a string to a Severity.
+func ParseSeverity(name string) (Severity, error) {
+	if x, ok := _SeverityValue[name]; ok {
+		return x, nil
+	}
+	return Severity(0), fmt.Errorf("%s is %w", name, ErrInvalidSeverity)
+}
+
+// MarshalText implements the text marshaller method.
+func (x Severity) MarshalText() ([]byte, error) {
+	return []byte(x.String()), nil
+}
+
+// UnmarshalText implements the text unmarshaller method.
+func (x *Severity) UnmarshalText(text []byte) error {
+	name := string(text)
+	tmp, err := ParseSeverity(name)
+	if err != nil {
+		return err
+	}
+	*x = tmp
+	return nil
+}
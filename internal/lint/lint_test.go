@@ -0,0 +1,58 @@
+package lint
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+
+	cfg := &domain.Config{
+		Services: []*domain.ConfigService{
+			{Name: "greeting", Address: "127.0.0.1:8001"},
+			{Name: "greeting", Address: "dns:///greeting:8001"},
+			{Name: "orders", Address: "orders"},
+		},
+	}
+
+	description := &domain.ProviderDescription{
+		RateLimiters: []*domain.RateLimiterDescription{{Rate: 1, Burst: 0, Period: time.Second}},
+		DescriptionByMethod: map[string]*domain.ProviderDescriptionMethod{
+			"hello": {
+				AuthenticationMode:  domain.AuthenticationModeNone,
+				RequiredPermissions: []string{"read:greeting"},
+				AllowedHTTPMethods:  mapset.NewSet(domain.HTTPMethodGet),
+			},
+			"hidden": {},
+		},
+	}
+
+	report := Run(context.Background(), Options{
+		Config: cfg,
+		FetchDescription: func(_ context.Context, _ *domain.ConfigService) (*domain.ProviderDescription, error) {
+			return description, nil
+		},
+	})
+
+	assert.True(t, report.HasErrors())
+	assert.Contains(t, report.Findings, Finding{Severity: SeverityError, Service: "greeting", Message: "service name is not unique"})
+	assert.Contains(t, report.Findings, Finding{Severity: SeverityError, Service: "greeting", Message: "rate limiter has zero burst and rejects all requests"})
+	assert.Contains(t, report.Findings, Finding{Severity: SeverityWarning, Service: "greeting", Method: "hello", Message: "method has required permissions, authentication mode none is ignored"})
+	assert.Contains(t, report.Findings, Finding{Severity: SeverityError, Service: "greeting", Method: "hidden", Message: "method has no allowed HTTP methods and is unreachable"})
+
+	var addressErrors int
+	for _, f := range report.Findings {
+		if f.Service == "orders" && f.Method == "" && f.Severity == SeverityError && f.Message != "rate limiter has zero burst and rejects all requests" {
+			addressErrors++
+		}
+	}
+
+	assert.Equal(t, 1, addressErrors, "address without port is invalid")
+}
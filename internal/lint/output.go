@@ -0,0 +1,41 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteText writes human-readable report.
+func WriteText(w io.Writer, report *Report) error {
+	if len(report.Findings) == 0 {
+		_, err := fmt.Fprintln(w, "config is valid")
+		return err
+	}
+
+	for _, f := range report.Findings {
+		location := f.Service
+		if f.Method != "" {
+			location += "/" + f.Method
+		}
+
+		if location == "" {
+			location = "config"
+		}
+
+		if _, err := fmt.Fprintf(w, "%-7s %s: %s\n", strings.ToUpper(f.Severity.String()), location, f.Message); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// WriteJSON writes report as JSON for CI.
+func WriteJSON(w io.Writer, report *Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "    ")
+
+	return encoder.Encode(report)
+}
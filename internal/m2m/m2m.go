@@ -2,14 +2,29 @@ package m2m
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math/rand/v2"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/clients/auth0"
 )
 
+const (
+	defaultRefreshBefore = 10 * time.Minute
+	defaultMinBackoff    = time.Second
+	defaultMaxBackoff    = time.Minute
+
+	// minForcedRefreshInterval protects token endpoint when provider keeps rejecting fresh tokens.
+	minForcedRefreshInterval = 10 * time.Second
+
+	sharedLockTTL      = 10 * time.Second
+	sharedPollInterval = 200 * time.Millisecond
+)
+
 type tokenIssuer interface {
 	Token(ctx context.Context, audience string) (*auth0.Token, error)
 }
@@ -17,19 +32,81 @@ type tokenIssuer interface {
 // Source of M2M token.
 type Source interface {
 	Token() string
+	// Refresh obtains new token, it should be called when current token was rejected.
+	Refresh(ctx context.Context) error
+}
+
+// CachedToken is a token with absolute issue and expiration times.
+type CachedToken struct {
+	AccessToken string    `json:"access_token"`
+	IssuedAt    time.Time `json:"issued_at"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// SharedCache shares tokens between gateway instances, so only one of them requests token endpoint.
+type SharedCache interface {
+	// Load returns nil token if there is no token for audience.
+	Load(ctx context.Context, audience string) (*CachedToken, error)
+	Store(ctx context.Context, audience string, token *CachedToken) error
+	// Lock returns empty token if lock is held by somebody else, otherwise token to pass to Unlock.
+	Lock(ctx context.Context, audience string, ttl time.Duration) (string, error)
+	// Unlock releases lock only if it is still held with token, e.g. not expired and taken by another instance.
+	Unlock(ctx context.Context, audience, token string) error
 }
 
 type impl struct {
-	tokenIssuer  tokenIssuer
-	currentToken atomic.Pointer[auth0.Token]
-	audience     string
+	tokenIssuer   tokenIssuer
+	sharedCache   SharedCache
+	audience      string
+	refreshBefore time.Duration
+	minBackoff    time.Duration
+	maxBackoff    time.Duration
+
+	currentToken atomic.Pointer[CachedToken]
+	refreshMux   sync.Mutex
+	// refreshedAt is a time of last update attempt, failed attempts count too.
+	refreshedAt time.Time
+	now         func() time.Time
+}
+
+// CreateOptions ...
+type CreateOptions struct {
+	TokenIssuer tokenIssuer
+	Audience    string
+	// SharedCache is optional.
+	SharedCache SharedCache
+	// RefreshBefore is how long before expiration token is refreshed, half of lifetime is used for short-lived tokens.
+	RefreshBefore time.Duration
+	MinBackoff    time.Duration
+	MaxBackoff    time.Duration
+}
+
+func (opts *CreateOptions) setDefaults() {
+	if opts.RefreshBefore <= 0 {
+		opts.RefreshBefore = defaultRefreshBefore
+	}
+
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = defaultMinBackoff
+	}
+
+	if opts.MaxBackoff < opts.MinBackoff {
+		opts.MaxBackoff = max(defaultMaxBackoff, opts.MinBackoff)
+	}
 }
 
 // Create M2M Source for provided audience.
-func Create(ctx context.Context, issuer tokenIssuer, audience string) (Source, error) {
+func Create(ctx context.Context, opts CreateOptions) (Source, error) {
+	opts.setDefaults()
+
 	src := &impl{
-		tokenIssuer: issuer,
-		audience:    audience,
+		tokenIssuer:   opts.TokenIssuer,
+		sharedCache:   opts.SharedCache,
+		audience:      opts.Audience,
+		refreshBefore: opts.RefreshBefore,
+		minBackoff:    opts.MinBackoff,
+		maxBackoff:    opts.MaxBackoff,
+		now:           time.Now,
 	}
 
 	if err := src.updater(ctx); err != nil {
@@ -48,44 +125,224 @@ func (s *impl) Token() string {
 	return loadedToken.AccessToken
 }
 
+var (
+	errRefreshTooOften = errors.New("m2m token was refreshed recently")
+)
+
+func (s *impl) Refresh(ctx context.Context) error {
+	rejected := s.currentToken.Load()
+
+	s.refreshMux.Lock()
+	defer s.refreshMux.Unlock()
+
+	if current := s.currentToken.Load(); current != rejected {
+		// token was already refreshed by concurrent call.
+		return nil
+	}
+
+	if s.now().Sub(s.refreshedAt) < minForcedRefreshInterval {
+		return errRefreshTooOften
+	}
+
+	return s.update(ctx, rejected)
+}
+
 func (s *impl) updater(ctx context.Context) error {
-	if err := s.update(ctx); err != nil {
+	if err := s.update(ctx, nil); err != nil {
 		return fmt.Errorf("first updating m2m source: %w", err)
 	}
 
 	go func() {
-		timer := time.NewTimer(calculateNextUpdate(s.currentToken.Load().ExpiresIn))
+		timer := time.NewTimer(s.nextUpdate())
 		defer timer.Stop()
 
+		var failures int
+
 		for {
 			select {
 			case <-ctx.Done():
 				return
 			case <-timer.C:
-				if err := s.update(ctx); err != nil {
-					slog.Error("Failed to update m2m source", slog.String("err", err.Error()))
-					timer.Reset(time.Minute) // let's retry after one minute
-					return
-				}
+			}
+
+			// token may have been refreshed by Refresh in the meantime.
+			if next := s.nextUpdate(); next > 0 {
+				failures = 0
+				timer.Reset(next)
+				continue
+			}
+
+			s.refreshMux.Lock()
+			err := s.update(ctx, nil)
+			s.refreshMux.Unlock()
+
+			if err != nil {
+				failures++
+				retryAfter := s.backoff(failures)
+
+				slog.Error("Failed to update m2m source",
+					slog.String("audience", s.audience),
+					slog.Int("failures", failures),
+					slog.Duration("retry_after", retryAfter),
+					slog.String("err", err.Error()),
+				)
 
-				timer.Reset(calculateNextUpdate(s.currentToken.Load().ExpiresIn))
+				timer.Reset(retryAfter)
+				continue
 			}
+
+			failures = 0
+			timer.Reset(max(s.nextUpdate(), s.minBackoff))
 		}
 	}()
 
 	return nil
 }
 
-func (s *impl) update(ctx context.Context) error {
-	newToken, err := s.tokenIssuer.Token(ctx, s.audience)
+// update stores new token, rejected token is never taken from shared cache. Must be called with refreshMux locked
+// or before updater is started.
+func (s *impl) update(ctx context.Context, rejected *CachedToken) error {
+	// attempt is recorded before obtaining, so rejected tokens can't make every request hit token endpoint
+	// while it fails.
+	s.refreshedAt = s.now()
+
+	newToken, err := s.obtain(ctx, rejected)
 	if err != nil {
+		tokenRefreshFailures.WithLabelValues(s.audience).Inc()
 		return fmt.Errorf("updating m2m token: %w", err)
 	}
 
 	s.currentToken.Store(newToken)
+
+	tokenIssuedAt.WithLabelValues(s.audience).Set(float64(newToken.IssuedAt.Unix()))
+	tokenExpiresAt.WithLabelValues(s.audience).Set(float64(newToken.ExpiresAt.Unix()))
+
 	return nil
 }
 
-func calculateNextUpdate(expiresIn uint) time.Duration {
-	return time.Duration(expiresIn)*time.Second - time.Minute*10
+func (s *impl) obtain(ctx context.Context, rejected *CachedToken) (*CachedToken, error) {
+	if s.sharedCache == nil {
+		return s.issue(ctx)
+	}
+
+	if cached := s.loadShared(ctx, rejected); cached != nil {
+		return cached, nil
+	}
+
+	lockToken, err := s.sharedCache.Lock(ctx, s.audience, sharedLockTTL)
+	if err != nil {
+		slog.Warn("Failed to lock shared m2m cache, requesting token directly",
+			slog.String("audience", s.audience), slog.String("err", err.Error()))
+
+		return s.issue(ctx)
+	}
+
+	if lockToken == "" {
+		// another instance is requesting token, wait for it.
+		if cached := s.waitShared(ctx, rejected); cached != nil {
+			return cached, nil
+		}
+
+		return s.issue(ctx)
+	}
+
+	defer s.sharedCache.Unlock(ctx, s.audience, lockToken) //nolint:errcheck
+
+	newToken, err := s.issue(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.sharedCache.Store(ctx, s.audience, newToken); err != nil {
+		slog.Warn("Failed to store m2m token to shared cache",
+			slog.String("audience", s.audience), slog.String("err", err.Error()))
+	}
+
+	return newToken, nil
+}
+
+func (s *impl) issue(ctx context.Context) (*CachedToken, error) {
+	token, err := s.tokenIssuer.Token(ctx, s.audience)
+	if err != nil {
+		return nil, err
+	}
+
+	issuedAt := s.now()
+	tokenIssued.WithLabelValues(s.audience).Inc()
+
+	return &CachedToken{
+		AccessToken: token.AccessToken,
+		IssuedAt:    issuedAt,
+		ExpiresAt:   issuedAt.Add(time.Duration(token.ExpiresIn) * time.Second),
+	}, nil
+}
+
+// loadShared returns token from shared cache if it is fresh enough and was not rejected.
+func (s *impl) loadShared(ctx context.Context, rejected *CachedToken) *CachedToken {
+	cached, err := s.sharedCache.Load(ctx, s.audience)
+	if err != nil {
+		slog.Warn("Failed to load m2m token from shared cache",
+			slog.String("audience", s.audience), slog.String("err", err.Error()))
+
+		return nil
+	}
+
+	if cached == nil || s.refreshAt(cached).Before(s.now()) {
+		return nil
+	}
+
+	if rejected != nil && cached.AccessToken == rejected.AccessToken {
+		return nil
+	}
+
+	return cached
+}
+
+func (s *impl) waitShared(ctx context.Context, rejected *CachedToken) *CachedToken {
+	ticker := time.NewTicker(sharedPollInterval)
+	defer ticker.Stop()
+
+	deadline := time.NewTimer(sharedLockTTL)
+	defer deadline.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-deadline.C:
+			return nil
+		case <-ticker.C:
+			if cached := s.loadShared(ctx, rejected); cached != nil {
+				return cached
+			}
+		}
+	}
+}
+
+// refreshAt returns time when token must be refreshed proactively.
+func (s *impl) refreshAt(token *CachedToken) time.Time {
+	refreshBefore := s.refreshBefore
+	if lifetime := token.ExpiresAt.Sub(token.IssuedAt); lifetime < 2*refreshBefore {
+		refreshBefore = lifetime / 2
+	}
+
+	return token.ExpiresAt.Add(-refreshBefore)
+}
+
+func (s *impl) nextUpdate() time.Duration {
+	return s.refreshAt(s.currentToken.Load()).Sub(s.now())
+}
+
+// backoff returns exponential delay with jitter for provided number of consecutive failures.
+func (s *impl) backoff(failures int) time.Duration {
+	delay := s.minBackoff
+	for i := 1; i < failures && delay < s.maxBackoff; i++ {
+		delay *= 2
+	}
+
+	delay = min(delay, s.maxBackoff)
+
+	half := delay / 2
+
+	return half + rand.N(half+1) //nolint:gosec
 }
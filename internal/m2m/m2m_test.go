@@ -0,0 +1,81 @@
+package m2m
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/clients/auth0"
+)
+
+type fakeIssuer struct {
+	mux    sync.Mutex
+	issued int
+	err    error
+}
+
+func (f *fakeIssuer) Token(_ context.Context, _ string) (*auth0.Token, error) {
+	f.mux.Lock()
+	defer f.mux.Unlock()
+
+	if f.err != nil {
+		return nil, f.err
+	}
+
+	f.issued++
+
+	return &auth0.Token{AccessToken: "token-" + strconv.Itoa(f.issued), ExpiresIn: 3600}, nil
+}
+
+func TestRefresh(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	issuer := &fakeIssuer{}
+
+	src, err := Create(ctx, CreateOptions{TokenIssuer: issuer, Audience: "api"})
+	require.NoError(t, err)
+	assert.Equal(t, "token-1", src.Token())
+
+	// refreshing right after token was obtained is not allowed.
+	require.ErrorIs(t, src.Refresh(ctx), errRefreshTooOften)
+
+	s := src.(*impl) //nolint:forcetypeassert
+	s.now = func() time.Time { return time.Now().Add(time.Minute) }
+
+	require.NoError(t, src.Refresh(ctx))
+	assert.Equal(t, "token-2", src.Token())
+
+	issuer.err = errors.New("unavailable")
+	s.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	require.Error(t, src.Refresh(ctx))
+	assert.Equal(t, "token-2", src.Token(), "previous token is kept on failure")
+
+	// failed attempt counts too, so failing token endpoint isn't requested on every rejected call.
+	require.ErrorIs(t, src.Refresh(ctx), errRefreshTooOften)
+}
+
+func TestBackoff(t *testing.T) {
+	t.Parallel()
+
+	s := &impl{minBackoff: time.Second, maxBackoff: time.Minute}
+
+	for failures, maxDelay := range map[int]time.Duration{
+		1:   time.Second,
+		2:   2 * time.Second,
+		4:   8 * time.Second,
+		100: time.Minute,
+	} {
+		delay := s.backoff(failures)
+		assert.GreaterOrEqual(t, delay, maxDelay/2)
+		assert.LessOrEqual(t, delay, maxDelay)
+	}
+}
@@ -0,0 +1,40 @@
+package m2m
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	tokenIssued = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "m2m_token_issued_count",
+			Help: "The total number of m2m tokens requested from token endpoint",
+		},
+		[]string{"audience"},
+	)
+
+	tokenRefreshFailures = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "m2m_token_refresh_failures_count",
+			Help: "The total number of failed m2m token refreshes",
+		},
+		[]string{"audience"},
+	)
+
+	tokenIssuedAt = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "m2m_token_issued_at_seconds",
+			Help: "Unix time when current m2m token was issued, token age is time() - m2m_token_issued_at_seconds",
+		},
+		[]string{"audience"},
+	)
+
+	tokenExpiresAt = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "m2m_token_expires_at_seconds",
+			Help: "Unix time when current m2m token expires",
+		},
+		[]string{"audience"},
+	)
+)
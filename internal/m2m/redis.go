@@ -0,0 +1,102 @@
+package m2m
+
+import (
+	"context"
+	"crypto/rand"
+	_ "embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+//go:embed unlock.lua
+var unlockScript string
+
+var unlock = redis.NewScript(unlockScript)
+
+type redisCache struct {
+	client *redis.Client
+}
+
+// NewRedisCache returns new SharedCache stored in redis.
+func NewRedisCache(client *redis.Client) SharedCache {
+	return &redisCache{
+		client: client,
+	}
+}
+
+func (r *redisCache) Load(ctx context.Context, audience string) (*CachedToken, error) {
+	data, err := r.client.Get(ctx, tokenKey(audience)).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+
+		return nil, fmt.Errorf("redis m2m cache: %w", err)
+	}
+
+	var token CachedToken
+	if err = json.Unmarshal(data, &token); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal m2m token: %w", err)
+	}
+
+	return &token, nil
+}
+
+func (r *redisCache) Store(ctx context.Context, audience string, token *CachedToken) error {
+	ttl := time.Until(token.ExpiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(token)
+	if err != nil {
+		return fmt.Errorf("failed to marshal m2m token: %w", err)
+	}
+
+	if err = r.client.Set(ctx, tokenKey(audience), data, ttl).Err(); err != nil {
+		return fmt.Errorf("redis m2m cache: %w", err)
+	}
+
+	return nil
+}
+
+func (r *redisCache) Lock(ctx context.Context, audience string, ttl time.Duration) (string, error) {
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to generate lock token: %w", err)
+	}
+
+	token := hex.EncodeToString(random)
+
+	locked, err := r.client.SetNX(ctx, lockKey(audience), token, ttl).Result()
+	if err != nil {
+		return "", fmt.Errorf("redis m2m cache: %w", err)
+	}
+
+	if !locked {
+		return "", nil
+	}
+
+	return token, nil
+}
+
+func (r *redisCache) Unlock(ctx context.Context, audience, token string) error {
+	if err := unlock.Run(ctx, r.client, []string{lockKey(audience)}, token).Err(); err != nil {
+		return fmt.Errorf("redis m2m cache: %w", err)
+	}
+
+	return nil
+}
+
+func tokenKey(audience string) string {
+	return "m2m_token:" + audience
+}
+
+func lockKey(audience string) string {
+	return "m2m_lock:" + audience
+}
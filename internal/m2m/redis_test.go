@@ -0,0 +1,42 @@
+package m2m
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisLock(t *testing.T) {
+	t.Parallel()
+
+	srv := miniredis.RunT(t)
+	cache := NewRedisCache(redis.NewClient(&redis.Options{Addr: srv.Addr()}))
+	ctx := context.Background()
+
+	token, err := cache.Lock(ctx, "api", time.Second)
+	require.NoError(t, err)
+	require.NotEmpty(t, token)
+
+	busy, err := cache.Lock(ctx, "api", time.Second)
+	require.NoError(t, err)
+	assert.Empty(t, busy)
+
+	// lock expired and was taken by another instance, so previous owner must not release it.
+	srv.FastForward(2 * time.Second)
+
+	other, err := cache.Lock(ctx, "api", time.Second)
+	require.NoError(t, err)
+	require.NotEmpty(t, other)
+	assert.NotEqual(t, token, other)
+
+	require.NoError(t, cache.Unlock(ctx, "api", token))
+	assert.True(t, srv.Exists(lockKey("api")))
+
+	require.NoError(t, cache.Unlock(ctx, "api", other))
+	assert.False(t, srv.Exists(lockKey("api")))
+}
@@ -0,0 +1,6 @@
+-- Deletes lock only if it is held with token, so instance whose lock expired can't release lock of another one.
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+  return redis.call("DEL", KEYS[1])
+end
+
+return 0
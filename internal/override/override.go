@@ -0,0 +1,97 @@
+package override
+
+//go:generate go run github.com/abice/go-enum
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/netip"
+	"time"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/ratelimit"
+)
+
+// Kind of overridden consumer.
+// ENUM(subject, api_key, org, ip)
+type Kind uint8
+
+// Action of override.
+// ENUM(limit, block)
+type Action uint8
+
+// Entry of override table.
+type Entry struct {
+	Kind Kind
+	// Value is subject ID, hash of API key, organization ID, IP or CIDR.
+	Value string
+	// Service the override applies to, all services when empty.
+	Service string
+	Action  Action
+	// Limit replaces limiters of service and method for limit action.
+	Limit     ratelimit.Limit
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+// Validate ...
+func (e *Entry) Validate() error {
+	if e.Value == "" {
+		return errors.New("value cannot be empty")
+	}
+
+	if !e.Kind.IsValid() {
+		return fmt.Errorf("invalid kind %s", e.Kind)
+	}
+
+	if !e.Action.IsValid() {
+		return fmt.Errorf("invalid action %s", e.Action)
+	}
+
+	if e.Action == ActionLimit && (e.Limit.Rate == 0 || e.Limit.Burst == 0 || e.Limit.Period <= 0) {
+		return errors.New("limit requires rate, burst and period greater than zero")
+	}
+
+	return nil
+}
+
+// NormalizeIP returns IP or CIDR as prefix, so single IP and its /32 are the same entry.
+func NormalizeIP(value string) (string, error) {
+	if addr, err := netip.ParseAddr(value); err == nil {
+		return netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()).String(), nil
+	}
+
+	prefix, err := netip.ParsePrefix(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid IP or CIDR %q", value)
+	}
+
+	return prefix.Masked().String(), nil
+}
+
+// HashAPIKey returns hash of API key, keys are secrets and are not stored as is.
+func HashAPIKey(apiKey string) string {
+	hash := sha256.Sum256([]byte(apiKey))
+	return hex.EncodeToString(hash[:])
+}
+
+// Consumer is a caller matched against overrides, empty fields are not matched.
+type Consumer struct {
+	SubjectID  string
+	APIKeyHash string
+	Org        string
+	IP         string
+}
+
+// Table of overrides.
+type Table interface {
+	// Match returns override of consumer for service. Block wins over limit, otherwise entries of service win
+	// over entries of all services and kinds are matched in order subject, api key, org, ip.
+	Match(service string, consumer Consumer) (Entry, bool)
+	// Set stores entry, zero ttl means that entry is kept until deleted.
+	Set(ctx context.Context, entry Entry, ttl time.Duration) error
+	Delete(ctx context.Context, service string, kind Kind, value string) error
+	Entries(ctx context.Context) ([]Entry, error)
+}
@@ -0,0 +1,108 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package override
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// ActionLimit is a Action of type Limit.
+	ActionLimit Action = iota
+	// ActionBlock is a Action of type Block.
+	ActionBlock
+)
+
+var ErrInvalidAction = errors.New("not a valid Action")
+
+const _ActionName = "limitblock"
+
+var _ActionMap = map[Action]string{
+	ActionLimit: _ActionName[0:5],
+	ActionBlock: _ActionName[5:10],
+}
+
+// String implements the Stringer interface.
+func (x Action) String() string {
+	if str, ok := _ActionMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("Action(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x Action) IsValid() bool {
+	_, ok := _ActionMap[x]
+	return ok
+}
+
+var _ActionValue = map[string]Action{
+	_ActionName[0:5]:  ActionLimit,
+	_ActionName[5:10]: ActionBlock,
+}
+
+// ParseAction attempts to convert a string to a Action.
+func ParseAction(name string) (Action, error) {
+	if x, ok := _ActionValue[name]; ok {
+		return x, nil
+	}
+	return Action(0), fmt.Errorf("%s is %w", name, ErrInvalidAction)
+}
+
+const (
+	// KindSubject is a Kind of type Subject.
+	KindSubject Kind = iota
+	// KindApiKey is a Kind of type Api_key.
+	KindApiKey
+	// KindOrg is a Kind of type Org.
+	KindOrg
+	// KindIp is a Kind of type Ip.
+	KindIp
+)
+
+var ErrInvalidKind = errors.New("not a valid Kind")
+
+const _KindName = "subjectapi_keyorgip"
+
+var _KindMap = map[Kind]string{
+	KindSubject: _KindName[0:7],
+	KindApiKey:  _KindName[7:14],
+	KindOrg:     _KindName[14:17],
+	KindIp:      _KindName[17:19],
+}
+
+// String implements the Stringer interface.
+func (x Kind) String() string {
+	if str, ok := _KindMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("Kind(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x Kind) IsValid() bool {
+	_, ok := _KindMap[x]
+	return ok
+}
+
+var _KindValue = map[string]Kind{
+	_KindName[0:7]:   KindSubject,
+	_KindName[7:14]:  KindApiKey,
+	_KindName[14:17]: KindOrg,
+	_KindName[17:19]: KindIp,
+}
+
+// ParseKind attempts to convert a string to a Kind.
+func ParseKind(name string) (Kind, error) {
+	if x, ok := _KindValue[name]; ok {
+		return x, nil
+	}
+	return Kind(0), fmt.Errorf("%s is %w", name, ErrInvalidKind)
+}
@@ -0,0 +1,303 @@
+package override
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/netip"
+	"slices"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/ratelimit"
+)
+
+const (
+	keyPrefix = "rate_limit_override_"
+	scanCount = 100
+
+	// allServices is stored instead of empty service.
+	allServices = "*"
+)
+
+// kindOrder is an order of matching kinds, from the most specific one.
+var kindOrder = []Kind{KindSubject, KindApiKey, KindOrg, KindIp}
+
+type storedEntry struct {
+	Action    string        `json:"action"`
+	Rate      uint64        `json:"rate,omitempty"`
+	Burst     uint64        `json:"burst,omitempty"`
+	Period    time.Duration `json:"period,omitempty"`
+	CreatedAt time.Time     `json:"created_at"`
+}
+
+// snapshot of table matched by requests without calls to Redis.
+type snapshot struct {
+	exact map[string]Entry
+	// ips are sorted from the longest prefix.
+	ips []ipEntry
+}
+
+type ipEntry struct {
+	prefix netip.Prefix
+	entry  Entry
+}
+
+type redisTable struct {
+	client   *redis.Client
+	snapshot atomic.Pointer[snapshot]
+}
+
+// NewRedis returns new Table stored in redis. Entries are matched from memory and reloaded every refresh period,
+// so changes made by other gateways apply within the period.
+func NewRedis(ctx context.Context, client *redis.Client, refreshPeriod time.Duration) Table {
+	t := &redisTable{
+		client: client,
+	}
+
+	t.snapshot.Store(&snapshot{exact: make(map[string]Entry)})
+
+	go func() {
+		ticker := time.NewTicker(refreshPeriod)
+		defer ticker.Stop()
+
+		for {
+			t.refresh(ctx)
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	return t
+}
+
+func key(service string, kind Kind, value string) string {
+	return fmt.Sprintf("%s%s:%s:%s", keyPrefix, storedService(service), kind.String(), value)
+}
+
+func storedService(service string) string {
+	if service == "" {
+		return allServices
+	}
+
+	return service
+}
+
+func (r *redisTable) Match(service string, consumer Consumer) (Entry, bool) {
+	s := r.snapshot.Load()
+
+	var addr netip.Addr
+	if consumer.IP != "" {
+		addr, _ = netip.ParseAddr(consumer.IP) //nolint:errcheck
+		addr = addr.Unmap()
+	}
+
+	values := map[Kind]string{
+		KindSubject: consumer.SubjectID,
+		KindApiKey:  consumer.APIKeyHash,
+		KindOrg:     consumer.Org,
+	}
+
+	var matched []Entry
+
+	now := time.Now()
+
+	for _, svc := range []string{service, allServices} {
+		for _, kind := range kindOrder {
+			if kind == KindIp {
+				for _, ip := range s.ips {
+					if addr.IsValid() && storedService(ip.entry.Service) == svc && ip.prefix.Contains(addr) {
+						matched = append(matched, ip.entry)
+					}
+				}
+
+				continue
+			}
+
+			if values[kind] == "" {
+				continue
+			}
+
+			if entry, ok := s.exact[key(svc, kind, values[kind])]; ok {
+				matched = append(matched, entry)
+			}
+		}
+	}
+
+	// entries expired since last refresh are skipped.
+	matched = slices.DeleteFunc(matched, func(entry Entry) bool {
+		return !entry.ExpiresAt.IsZero() && !now.Before(entry.ExpiresAt)
+	})
+
+	for _, entry := range matched {
+		if entry.Action == ActionBlock {
+			return entry, true
+		}
+	}
+
+	if len(matched) == 0 {
+		return Entry{}, false
+	}
+
+	return matched[0], true
+}
+
+func (r *redisTable) Set(ctx context.Context, entry Entry, ttl time.Duration) error {
+	if err := entry.Validate(); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(storedEntry{
+		Action:    entry.Action.String(),
+		Rate:      entry.Limit.Rate,
+		Burst:     entry.Limit.Burst,
+		Period:    entry.Limit.Period,
+		CreatedAt: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal override: %w", err)
+	}
+
+	if err = r.client.Set(ctx, key(entry.Service, entry.Kind, entry.Value), data, ttl).Err(); err != nil {
+		return fmt.Errorf("redis override table: %w", err)
+	}
+
+	r.refresh(ctx)
+
+	return nil
+}
+
+func (r *redisTable) Delete(ctx context.Context, service string, kind Kind, value string) error {
+	if err := r.client.Del(ctx, key(service, kind, value)).Err(); err != nil {
+		return fmt.Errorf("redis override table: %w", err)
+	}
+
+	r.refresh(ctx)
+
+	return nil
+}
+
+func (r *redisTable) Entries(ctx context.Context) ([]Entry, error) {
+	var keys []string
+
+	iter := r.client.Scan(ctx, 0, keyPrefix+"*", scanCount).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("redis override table: %w", err)
+	}
+
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	pipe := r.client.Pipeline()
+	getCmds := make([]*redis.StringCmd, 0, len(keys))
+	ttlCmds := make([]*redis.DurationCmd, 0, len(keys))
+
+	for _, redisKey := range keys {
+		getCmds = append(getCmds, pipe.Get(ctx, redisKey))
+		ttlCmds = append(ttlCmds, pipe.TTL(ctx, redisKey))
+	}
+
+	// entry can expire between scan and get, so errors of commands are checked one by one.
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, fmt.Errorf("redis override table: %w", err)
+	}
+
+	entries := make([]Entry, 0, len(keys))
+
+	for i, redisKey := range keys {
+		entry, ok := parseEntry(redisKey, getCmds[i], ttlCmds[i])
+		if !ok {
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func parseEntry(redisKey string, getCmd *redis.StringCmd, ttlCmd *redis.DurationCmd) (Entry, bool) {
+	parts := strings.SplitN(strings.TrimPrefix(redisKey, keyPrefix), ":", 3)
+	if len(parts) != 3 || getCmd.Err() != nil {
+		return Entry{}, false
+	}
+
+	kind, err := ParseKind(parts[1])
+	if err != nil {
+		return Entry{}, false
+	}
+
+	var stored storedEntry
+	if err = json.Unmarshal([]byte(getCmd.Val()), &stored); err != nil {
+		return Entry{}, false
+	}
+
+	action, err := ParseAction(stored.Action)
+	if err != nil {
+		return Entry{}, false
+	}
+
+	entry := Entry{
+		Kind:      kind,
+		Value:     parts[2],
+		Action:    action,
+		Limit:     ratelimit.Limit{Rate: stored.Rate, Burst: stored.Burst, Period: stored.Period},
+		CreatedAt: stored.CreatedAt,
+	}
+
+	if parts[0] != allServices {
+		entry.Service = parts[0]
+	}
+
+	if ttl := ttlCmd.Val(); ttl > 0 {
+		entry.ExpiresAt = time.Now().Add(ttl)
+	}
+
+	return entry, true
+}
+
+// refresh reloads snapshot, previous snapshot is kept when Redis fails.
+func (r *redisTable) refresh(ctx context.Context) {
+	entries, err := r.Entries(ctx)
+	if err != nil {
+		slog.Warn("Failed to refresh rate limit overrides", slog.String("err", err.Error()))
+		return
+	}
+
+	s := &snapshot{exact: make(map[string]Entry, len(entries))}
+
+	for _, entry := range entries {
+		if entry.Kind != KindIp {
+			s.exact[key(entry.Service, entry.Kind, entry.Value)] = entry
+			continue
+		}
+
+		prefix, err := netip.ParsePrefix(entry.Value)
+		if err != nil {
+			continue
+		}
+
+		s.ips = append(s.ips, ipEntry{prefix: prefix, entry: entry})
+	}
+
+	sort.SliceStable(s.ips, func(i, j int) bool {
+		return s.ips[i].prefix.Bits() > s.ips[j].prefix.Bits()
+	})
+
+	r.snapshot.Store(s)
+}
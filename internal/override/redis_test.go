@@ -0,0 +1,93 @@
+package override
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/ratelimit"
+)
+
+func newTestTable(t *testing.T) Table {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	srv := miniredis.RunT(t)
+
+	// table is refreshed after every change, so ticker is not needed.
+	return NewRedis(ctx, redis.NewClient(&redis.Options{Addr: srv.Addr()}), time.Hour)
+}
+
+func TestRedisMatch(t *testing.T) {
+	t.Parallel()
+
+	table := newTestTable(t)
+	ctx := context.Background()
+	limit := ratelimit.Limit{Rate: 100, Burst: 100, Period: time.Second}
+
+	require.NoError(t, table.Set(ctx, Entry{Kind: KindOrg, Value: "acme", Action: ActionLimit, Limit: limit}, 0))
+	require.NoError(t, table.Set(ctx, Entry{Kind: KindSubject, Value: "partner", Service: "svc", Action: ActionLimit, Limit: limit}, 0))
+	require.NoError(t, table.Set(ctx, Entry{Kind: KindIp, Value: "10.0.0.0/8", Action: ActionBlock}, time.Minute))
+
+	entry, ok := table.Match("svc", Consumer{SubjectID: "partner", Org: "acme"})
+	require.True(t, ok)
+	assert.Equal(t, KindSubject, entry.Kind)
+	assert.Equal(t, limit, entry.Limit)
+
+	// subject entry applies only to its service.
+	entry, ok = table.Match("other", Consumer{SubjectID: "partner", Org: "acme"})
+	require.True(t, ok)
+	assert.Equal(t, KindOrg, entry.Kind)
+
+	// block wins over limit.
+	entry, ok = table.Match("svc", Consumer{SubjectID: "partner", IP: "10.1.2.3"})
+	require.True(t, ok)
+	assert.Equal(t, ActionBlock, entry.Action)
+	assert.False(t, entry.ExpiresAt.IsZero())
+
+	_, ok = table.Match("svc", Consumer{SubjectID: "other", IP: "192.168.0.1"})
+	assert.False(t, ok)
+
+	require.NoError(t, table.Delete(ctx, "", KindIp, "10.0.0.0/8"))
+
+	entry, ok = table.Match("svc", Consumer{SubjectID: "partner", IP: "10.1.2.3"})
+	require.True(t, ok)
+	assert.Equal(t, ActionLimit, entry.Action)
+
+	entries, err := table.Entries(ctx)
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestRedisSetInvalid(t *testing.T) {
+	t.Parallel()
+
+	table := newTestTable(t)
+
+	err := table.Set(context.Background(), Entry{Kind: KindSubject, Value: "partner", Action: ActionLimit}, 0)
+	assert.Error(t, err)
+}
+
+func TestNormalizeIP(t *testing.T) {
+	t.Parallel()
+
+	for value, expected := range map[string]string{
+		"10.1.2.3":    "10.1.2.3/32",
+		"10.1.2.3/8":  "10.0.0.0/8",
+		"2001:db8::1": "2001:db8::1/128",
+	} {
+		actual, err := NormalizeIP(value)
+		require.NoError(t, err)
+		assert.Equal(t, expected, actual)
+	}
+
+	_, err := NormalizeIP("invalid")
+	assert.Error(t, err)
+}
@@ -0,0 +1,76 @@
+package processor
+
+import (
+	"net/netip"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/utils/netutil"
+)
+
+type accessStore interface {
+	Get(service string) (*domain.ServiceAccessRules, bool)
+}
+
+type geoIP interface {
+	Country(addr netip.Addr) string
+}
+
+// accessDenial is a client rejected by access rules.
+type accessDenial struct {
+	clientIP string
+	country  string
+	err      error
+}
+
+// checkAccess checks client against global rules and rules of service and method, all of them must allow it.
+func (p *impl) checkAccess(request *domain.ProcessRequest) *accessDenial {
+	rules := make([]*domain.AccessRules, 0, 3)
+
+	if p.accessRules != nil {
+		rules = append(rules, p.accessRules)
+	}
+
+	if p.accessStore != nil {
+		if serviceRules, ok := p.accessStore.Get(request.Service); ok {
+			rules = append(rules, serviceRules.Select(request.APIMethod)...)
+		}
+	}
+
+	if len(rules) == 0 {
+		return nil
+	}
+
+	addr := netutil.ClientAddr(request.RemoteAddr, request.Headers, p.trustedProxies)
+
+	var country string
+
+	for _, r := range rules {
+		if r.NeedCountry() && p.geoIP != nil && addr.IsValid() {
+			country = p.geoIP.Country(addr)
+			break
+		}
+	}
+
+	for _, r := range rules {
+		if err := r.Check(addr, country); err != nil {
+			denial := &accessDenial{country: country, err: err}
+			if addr.IsValid() {
+				denial.clientIP = addr.String()
+			}
+
+			return denial
+		}
+	}
+
+	return nil
+}
+
+// clientIP returns address of client for rate limits, quotas and overrides, empty when it is unknown.
+func (p *impl) clientIP(request *domain.ProcessRequest) string {
+	addr := netutil.ClientAddr(request.RemoteAddr, request.Headers, p.trustedProxies)
+	if !addr.IsValid() {
+		return ""
+	}
+
+	return addr.String()
+}
@@ -0,0 +1,51 @@
+package processor
+
+import (
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/utils/store"
+)
+
+type testGeoIP map[string]string
+
+func (g testGeoIP) Country(addr netip.Addr) string {
+	return g[addr.String()]
+}
+
+func TestCheckAccess(t *testing.T) {
+	t.Parallel()
+
+	global, err := (&domain.ConfigAccess{DenyCountries: []string{"XX"}}).ToAccessRules()
+	require.NoError(t, err)
+
+	serviceRules, err := (&domain.ConfigService{
+		MethodAccess: map[string]*domain.ConfigAccess{"admin": {Allow: []string{"10.8.0.0/16"}}},
+	}).ToAccessRules()
+	require.NoError(t, err)
+
+	p := &impl{
+		accessRules: global,
+		accessStore: store.New[string, *domain.ServiceAccessRules](map[string]*domain.ServiceAccessRules{"svc": serviceRules}),
+		geoIP:       testGeoIP{"198.51.100.1": "XX"},
+	}
+
+	check := func(method, remoteAddr string) *accessDenial {
+		return p.checkAccess(&domain.ProcessRequest{Service: "svc", APIMethod: method, RemoteAddr: remoteAddr})
+	}
+
+	assert.Nil(t, check("hello", "203.0.113.7:5000"))
+	assert.Nil(t, check("admin", "10.8.3.4:5000"))
+
+	denial := check("admin", "203.0.113.7:5000")
+	require.NotNil(t, denial)
+	assert.Equal(t, "203.0.113.7", denial.clientIP)
+
+	denial = check("hello", "198.51.100.1:5000")
+	require.NotNil(t, denial)
+	assert.Equal(t, "XX", denial.country)
+}
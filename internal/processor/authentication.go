@@ -0,0 +1,178 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/auth"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+const (
+	authorizationHeader   = "Authorization"
+	dpopHeader            = "DPoP"
+	wwwAuthenticateHeader = "WWW-Authenticate"
+
+	bearerScheme = "Bearer"
+	dpopScheme   = "DPoP"
+)
+
+var (
+	errMissingToken        = errors.New("missing token")
+	errDPoPRequired        = errors.New("DPoP bound token is required")
+	errDPoPNotSupported    = errors.New("DPoP is not supported")
+	errInvalidDPoPProofNum = errors.New("exactly one DPoP proof is required")
+
+	errCertificateRequired     = errors.New("verified client certificate is required")
+	errCertificateNotSupported = errors.New("client certificate authentication is not supported")
+)
+
+type dpopError struct {
+	err error
+}
+
+func (e *dpopError) Error() string {
+	return e.err.Error()
+}
+
+func (e *dpopError) Unwrap() error {
+	return e.err
+}
+
+// authenticate returns subject of request, anonymous subject is returned when authentication is
+// disabled or when optional token is missing. Invalid token is always rejected.
+func (p *impl) authenticate(
+	ctx context.Context,
+	request *domain.ProcessRequest,
+	description *domain.ProviderDescription,
+	serviceConfig *domain.ConfigService,
+) (*domain.SubjectInformation, error) {
+	scheme, token := getAuthorization(request.Headers)
+
+	switch description.SelectCertificateAuthentication(request.APIMethod) {
+	case domain.CertificateAuthenticationRequired:
+		return p.authenticateCertificate(request)
+	case domain.CertificateAuthenticationAccepted:
+		if token == "" && request.ClientCertificate != nil {
+			return p.authenticateCertificate(request)
+		}
+	}
+
+	mode := description.SelectAuthenticationMode(request.APIMethod)
+	if mode == domain.AuthenticationModeNone {
+		return domain.NewAnonymousSubject(), nil
+	}
+
+	if token == "" {
+		if mode == domain.AuthenticationModeRequired {
+			return nil, errMissingToken
+		}
+
+		return domain.NewAnonymousSubject(), nil
+	}
+
+	subjectInformation, err := p.tokenParser.ParseToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = auth.ValidateCertificateBinding(request.ClientCertificate, subjectInformation.Confirmation); err != nil {
+		return nil, err
+	}
+
+	requireDPoP := serviceConfig != nil && serviceConfig.RequireDPoP
+	isDPoP := strings.EqualFold(scheme, dpopScheme)
+
+	if isDPoP || requireDPoP || subjectInformation.Confirmation.JWKThumbprint != "" {
+		if err = p.validateDPoP(ctx, request, token, subjectInformation, isDPoP); err != nil {
+			return nil, &dpopError{err: err}
+		}
+	}
+
+	return subjectInformation, nil
+}
+
+func (p *impl) authenticateCertificate(request *domain.ProcessRequest) (*domain.SubjectInformation, error) {
+	if request.ClientCertificate == nil || !request.ClientCertificateVerified {
+		return nil, errCertificateRequired
+	}
+
+	if p.certificateMapper == nil {
+		return nil, errCertificateNotSupported
+	}
+
+	subjectInformation, err := p.certificateMapper.Map(request.ClientCertificate)
+	if err != nil {
+		return nil, fmt.Errorf("map client certificate: %w", err)
+	}
+
+	return subjectInformation, nil
+}
+
+func (p *impl) validateDPoP(
+	ctx context.Context,
+	request *domain.ProcessRequest,
+	token string,
+	subjectInformation *domain.SubjectInformation,
+	isDPoP bool,
+) error {
+	if !isDPoP {
+		return errDPoPRequired
+	}
+
+	if p.dpopValidator == nil {
+		return errDPoPNotSupported
+	}
+
+	proofs := request.Headers.Values(dpopHeader)
+	if len(proofs) != 1 {
+		return errInvalidDPoPProofNum
+	}
+
+	err := p.dpopValidator.Validate(ctx, auth.DPoPRequest{
+		Proof:        proofs[0],
+		HTTPMethod:   strings.ToUpper(request.HTTPMethod.String()),
+		URL:          request.URL,
+		AccessToken:  token,
+		Confirmation: subjectInformation.Confirmation,
+	})
+	if err != nil {
+		return fmt.Errorf("validate DPoP proof: %w", err)
+	}
+
+	return nil
+}
+
+func authenticateHeaders(err error, serviceConfig *domain.ConfigService) http.Header {
+	var dErr *dpopError
+
+	switch {
+	case errors.As(err, &dErr):
+		return http.Header{
+			wwwAuthenticateHeader: {fmt.Sprintf(`DPoP error="invalid_dpop_proof", algs="%s"`, strings.Join(auth.DPoPSigningAlgorithms, " "))},
+		}
+	case serviceConfig != nil && serviceConfig.RequireDPoP:
+		return http.Header{
+			wwwAuthenticateHeader: {fmt.Sprintf(`DPoP algs="%s"`, strings.Join(auth.DPoPSigningAlgorithms, " "))},
+		}
+	}
+
+	return http.Header{
+		wwwAuthenticateHeader: {bearerScheme},
+	}
+}
+
+// getAuthorization returns authorization scheme and token, scheme defaults to Bearer.
+func getAuthorization(headers http.Header) (scheme, token string) {
+	value := strings.TrimSpace(headers.Get(authorizationHeader))
+
+	scheme, token, found := strings.Cut(value, " ")
+	if !found {
+		return bearerScheme, value
+	}
+
+	return scheme, strings.TrimSpace(token)
+}
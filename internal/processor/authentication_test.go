@@ -0,0 +1,158 @@
+package processor
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+var errInvalidToken = errors.New("invalid token")
+
+type fakeTokenParser map[string]*domain.SubjectInformation
+
+func (f fakeTokenParser) ParseToken(_ context.Context, token string) (*domain.SubjectInformation, error) {
+	subject, ok := f[token]
+	if !ok {
+		return nil, errInvalidToken
+	}
+
+	return subject, nil
+}
+
+type fakeCertificateMapper struct {
+	subject *domain.SubjectInformation
+}
+
+func (f fakeCertificateMapper) Map(*x509.Certificate) (*domain.SubjectInformation, error) {
+	return f.subject, nil
+}
+
+func TestAuthenticate(t *testing.T) {
+	t.Parallel()
+
+	user := &domain.SubjectInformation{ID: "user"}
+
+	p := &impl{tokenParser: fakeTokenParser{"valid": user}}
+
+	tests := []struct {
+		name          string
+		serviceMode   domain.AuthenticationMode
+		methodMode    domain.AuthenticationMode
+		permissions   []string
+		authorization string
+		want          *domain.SubjectInformation
+		err           error
+	}{
+		{name: "none ignores invalid token", serviceMode: domain.AuthenticationModeNone, authorization: "Bearer broken", want: domain.NewAnonymousSubject()},
+		{name: "optional without token", serviceMode: domain.AuthenticationModeOptional, want: domain.NewAnonymousSubject()},
+		{name: "optional with valid token", serviceMode: domain.AuthenticationModeOptional, authorization: "Bearer valid", want: user},
+		{name: "optional with invalid token", serviceMode: domain.AuthenticationModeOptional, authorization: "Bearer broken", err: errInvalidToken},
+		{name: "required without token", serviceMode: domain.AuthenticationModeRequired, err: errMissingToken},
+		{name: "required with token without scheme", serviceMode: domain.AuthenticationModeRequired, authorization: "valid", want: user},
+		{
+			name:        "method mode overrides service mode",
+			serviceMode: domain.AuthenticationModeRequired,
+			methodMode:  domain.AuthenticationModeOptional,
+			want:        domain.NewAnonymousSubject(),
+		},
+		{
+			name:        "permissions require token",
+			serviceMode: domain.AuthenticationModeNone,
+			permissions: []string{"read"},
+			err:         errMissingToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			description := &domain.ProviderDescription{
+				AuthenticationMode: tt.serviceMode,
+				DescriptionByMethod: map[string]*domain.ProviderDescriptionMethod{
+					"hello": {Method: "hello", AuthenticationMode: tt.methodMode, RequiredPermissions: tt.permissions},
+				},
+			}
+
+			request := &domain.ProcessRequest{APIMethod: "hello", Headers: http.Header{}}
+			if tt.authorization != "" {
+				request.Headers.Set(authorizationHeader, tt.authorization)
+			}
+
+			subject, err := p.authenticate(context.Background(), request, description, nil)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, subject)
+		})
+	}
+}
+
+func TestAuthenticateCertificate(t *testing.T) {
+	t.Parallel()
+
+	user := &domain.SubjectInformation{ID: "user"}
+	service := &domain.SubjectInformation{ID: "billing"}
+
+	withMapper := &impl{tokenParser: fakeTokenParser{"valid": user}, certificateMapper: fakeCertificateMapper{subject: service}}
+	withoutMapper := &impl{tokenParser: fakeTokenParser{"valid": user}}
+
+	tests := []struct {
+		name          string
+		processor     *impl
+		mode          domain.CertificateAuthentication
+		certificate   bool
+		verified      bool
+		authorization string
+		want          *domain.SubjectInformation
+		err           error
+	}{
+		{name: "required with verified certificate", processor: withMapper, mode: domain.CertificateAuthenticationRequired, certificate: true, verified: true, want: service},
+		{name: "required ignores token", processor: withMapper, mode: domain.CertificateAuthenticationRequired, authorization: "Bearer valid", err: errCertificateRequired},
+		{name: "required with unverified chain", processor: withMapper, mode: domain.CertificateAuthenticationRequired, certificate: true, err: errCertificateRequired},
+		{name: "required without mapper", processor: withoutMapper, mode: domain.CertificateAuthenticationRequired, certificate: true, verified: true, err: errCertificateNotSupported},
+		{name: "accepted without token", processor: withMapper, mode: domain.CertificateAuthenticationAccepted, certificate: true, verified: true, want: service},
+		{name: "accepted prefers token", processor: withMapper, mode: domain.CertificateAuthenticationAccepted, certificate: true, verified: true, authorization: "Bearer valid", want: user},
+		{name: "accepted with unverified chain", processor: withMapper, mode: domain.CertificateAuthenticationAccepted, certificate: true, err: errCertificateRequired},
+		{name: "accepted without certificate falls back to token mode", processor: withMapper, mode: domain.CertificateAuthenticationAccepted, err: errMissingToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			description := &domain.ProviderDescription{
+				AuthenticationMode:        domain.AuthenticationModeRequired,
+				CertificateAuthentication: tt.mode,
+			}
+
+			request := &domain.ProcessRequest{APIMethod: "hello", Headers: http.Header{}, ClientCertificateVerified: tt.verified}
+			if tt.certificate {
+				request.ClientCertificate = &x509.Certificate{}
+			}
+
+			if tt.authorization != "" {
+				request.Headers.Set(authorizationHeader, tt.authorization)
+			}
+
+			subject, err := tt.processor.authenticate(context.Background(), request, description, nil)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, subject)
+		})
+	}
+}
@@ -2,25 +2,25 @@ package processor
 
 import (
 	"context"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
-	"net"
 	"net/http"
+	"net/netip"
 	"strconv"
-	"strings"
 
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/audit"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/auth"
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/clients/provider"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/concurrency"
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/override"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/quota"
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/ratelimit"
 )
 
-const (
-	authorizationHeader = "Authorization"
-	forwardedForHeader  = "X-Forwarded-For"
-)
-
 type descriptionStore interface {
 	Get(service string) (*domain.ProviderDescription, bool)
 }
@@ -38,24 +38,115 @@ type Processor interface {
 	Process(ctx context.Context, request *domain.ProcessRequest) *domain.ProviderProcessResponse
 }
 
+type serviceConfigStore interface {
+	Get(service string) (*domain.ConfigService, bool)
+}
+
+type dpopValidator interface {
+	Validate(ctx context.Context, req auth.DPoPRequest) error
+}
+
+type certificateMapper interface {
+	Map(cert *x509.Certificate) (*domain.SubjectInformation, error)
+}
+
+type identitySigner interface {
+	Sign(audience string, subject *domain.SubjectInformation) (string, error)
+}
+
+type tokenExchanger interface {
+	Exchange(ctx context.Context, subject *domain.SubjectInformation, subjectToken, audience string) (string, error)
+}
+
+// methodResolver is implemented by clients that route requests by path, e.g. transcoded gRPC services.
+type methodResolver interface {
+	ResolveMethod(ctx context.Context, httpMethod domain.HTTPMethod, apiMethod, path string) (string, error)
+}
+
+type concurrencyStore interface {
+	Get(service string) (*concurrency.Group, bool)
+}
+
 type impl struct {
-	descriptionStore descriptionStore
-	clientStore      clientStore
-	tokenParser      tokenParser
+	descriptionStore   descriptionStore
+	clientStore        clientStore
+	serviceConfigStore serviceConfigStore
+	tokenParser        tokenParser
+	dpopValidator      dpopValidator
+	certificateMapper  certificateMapper
+	identitySigner     identitySigner
+	tokenExchanger     tokenExchanger
+
+	auditor          audit.Auditor
+	rateLimiter      ratelimit.Limiter
+	overrideTable    overrideTable
+	overrideOrgClaim string
+	quotaStore       quotaStore
+	quotaCounter     quota.Counter
+	concurrencyStore concurrencyStore
+	priorityRules    *domain.PriorityRules
+	accessRules      *domain.AccessRules
+	accessStore      accessStore
+	geoIP            geoIP
+	trustedProxies   []netip.Prefix
+}
 
-	auditor     audit.Auditor
-	reteLimiter ratelimit.Limiter
+// NewOptions ...
+type NewOptions struct {
+	DescriptionStore   descriptionStore
+	ClientStore        clientStore
+	ServiceConfigStore serviceConfigStore
+	TokenParser        tokenParser
+	DPoPValidator      dpopValidator
+	CertificateMapper  certificateMapper
+	IdentitySigner     identitySigner
+	TokenExchanger     tokenExchanger
+	Auditor            audit.Auditor
+	RateLimiter        ratelimit.Limiter
+	// OverrideTable replaces limiters of description for consumers, optional.
+	OverrideTable overrideTable
+	// OverrideOrgClaim is a claim with organization of subject matched against org overrides.
+	OverrideOrgClaim string
+	// QuotaStore returns quotas of service, quotas are not counted when QuotaStore or QuotaCounter is nil.
+	QuotaStore   quotaStore
+	QuotaCounter quota.Counter
+	// ConcurrencyStore returns limiters of in-flight requests of service, optional.
+	ConcurrencyStore concurrencyStore
+	// PriorityRules assign priority to consumers, priority of method applies when nil.
+	PriorityRules *domain.PriorityRules
+	// AccessRules apply to all services together with rules of service and method from AccessStore.
+	AccessRules *domain.AccessRules
+	AccessStore accessStore
+	// GeoIP resolves countries for country rules, countries are unknown when nil.
+	GeoIP geoIP
+	// TrustedProxies are allowed to set X-Forwarded-For for access rules.
+	TrustedProxies []netip.Prefix
 }
 
 // New returns new Processor.
-func New(descriptionStore descriptionStore, clientStore clientStore, tokenParser tokenParser, auditor audit.Auditor, reteLimiter ratelimit.Limiter) Processor {
+func New(opts NewOptions) Processor {
 	return &impl{
-		descriptionStore: descriptionStore,
-		clientStore:      clientStore,
-		tokenParser:      tokenParser,
-
-		auditor:     auditor,
-		reteLimiter: reteLimiter,
+		descriptionStore:   opts.DescriptionStore,
+		clientStore:        opts.ClientStore,
+		serviceConfigStore: opts.ServiceConfigStore,
+		tokenParser:        opts.TokenParser,
+		dpopValidator:      opts.DPoPValidator,
+		certificateMapper:  opts.CertificateMapper,
+		identitySigner:     opts.IdentitySigner,
+		tokenExchanger:     opts.TokenExchanger,
+
+		auditor:          opts.Auditor,
+		rateLimiter:      opts.RateLimiter,
+		overrideTable:    opts.OverrideTable,
+		overrideOrgClaim: opts.OverrideOrgClaim,
+		quotaStore:       opts.QuotaStore,
+		quotaCounter:     opts.QuotaCounter,
+		concurrencyStore: opts.ConcurrencyStore,
+		priorityRules:    opts.PriorityRules,
+		accessRules:      opts.AccessRules,
+		accessStore:      opts.AccessStore,
+		geoIP:            opts.GeoIP,
+		trustedProxies:   opts.TrustedProxies,
 	}
 }
 
@@ -70,6 +161,19 @@ func (p *impl) Process(ctx context.Context, request *domain.ProcessRequest) *dom
 		return newErrorResponse(http.StatusNotFound, fmt.Sprintf("description for service %s not found", request.Service), nil)
 	}
 
+	client, exists := p.clientStore.Get(request.Service)
+	if !exists {
+		return newErrorResponse(http.StatusNotFound, fmt.Sprintf("client for service %s not found", request.Service), nil)
+	}
+
+	// routeMethod is forwarded to provider, rules are looked up by method that serves request.
+	routeMethod := request.APIMethod
+
+	request, errResp := p.resolveMethod(ctx, client, request)
+	if errResp != nil {
+		return errResp
+	}
+
 	methodDescription, exists := description.DescriptionByMethod[request.APIMethod]
 	if !exists {
 		return newErrorResponse(http.StatusNotFound, fmt.Sprintf("description for method %s of service %s not found", request.APIMethod, request.Service), nil)
@@ -79,29 +183,32 @@ func (p *impl) Process(ctx context.Context, request *domain.ProcessRequest) *dom
 		return newErrorResponse(http.StatusMethodNotAllowed, fmt.Sprintf("http method %s not allowed", request.HTTPMethod.String()), nil)
 	}
 
-	client, exists := p.clientStore.Get(request.Service)
-	if !exists {
-		return newErrorResponse(http.StatusNotFound, fmt.Sprintf("client for service %s not found", request.Service), nil)
+	if denial := p.checkAccess(request); denial != nil {
+		// denials are always audited, subject is unknown before authentication.
+		p.auditor.Write(ctx, audit.Fields{
+			Service:  request.Service,
+			Method:   request.APIMethod,
+			Result:   audit.ResultDenied,
+			ClientIP: denial.clientIP,
+			Country:  denial.country,
+		})
+
+		return newErrorResponse(http.StatusForbidden, fmt.Sprintf("access denied: %s", denial.err), nil)
 	}
 
-	requiredPermissions := description.Permissions(request.APIMethod)
-	needAuthentication := description.NeedAuthentication(request.APIMethod)
+	serviceConfig, _ := p.serviceConfigStore.Get(request.Service)
 
-	needAuthentication = needAuthentication || len(requiredPermissions) != 0 // handle case when we don't need auth, but permissions was passed
-
-	subjectInformation, err := p.tokenParser.ParseToken(ctx, getAuthorizationHeaderValue(request.Headers))
-	if needAuthentication && err != nil {
-		return newErrorResponse(http.StatusUnauthorized, fmt.Sprintf("failed to auntificate: %s", err), nil)
+	subjectInformation, err := p.authenticate(ctx, request, description, serviceConfig)
+	if err != nil {
+		return newErrorResponse(http.StatusUnauthorized, fmt.Sprintf("failed to authenticate: %s", err), authenticateHeaders(err, serviceConfig))
 	}
 
-	if needAuthentication {
-		for _, permission := range requiredPermissions {
-			if subjectInformation.Permissions.ContainsOne(permission) {
-				continue
-			}
-
-			return newErrorResponse(http.StatusForbidden, fmt.Sprintf("subject don't have required permission %s", permission), nil)
+	for _, permission := range description.Permissions(request.APIMethod) {
+		if subjectInformation.Permissions.ContainsOne(permission) {
+			continue
 		}
+
+		return newErrorResponse(http.StatusForbidden, fmt.Sprintf("subject don't have required permission %s", permission), nil)
 	}
 
 	auditFields := audit.Fields{
@@ -119,61 +226,105 @@ func (p *impl) Process(ctx context.Context, request *domain.ProcessRequest) *dom
 		p.auditor.Write(ctx, auditFields)
 	}()
 
-	rateLimiterDescription, isServiceRateLimiter := description.SelectRateLimiter(request.APIMethod)
-	if rateLimiterDescription != nil {
-		var entity string
+	cost := requestCost(request, methodDescription.Cost)
 
-		switch rateLimiterDescription.By {
-		case domain.RateLimitDescriptionByIp:
-			entity = getRealIP(request.RemoteAddr, request.Headers)
-		case domain.RateLimitDescriptionBySubjectId:
-			if subjectInformation != nil {
-				entity = subjectInformation.ID
-			}
-		}
+	rateLimitRequests, errResp := p.newRateLimitRequests(request, subjectInformation, description, cost)
+	if errResp != nil {
+		auditFields.Result = audit.ResultError
+		return errResp
+	}
 
-		isAllowed, retryAfter, err := p.reteLimiter.Allow(
-			ctx,
-			ratelimit.Key{
-				Service:          request.Service,
-				IsServiceLimiter: isServiceRateLimiter,
-				Method:           request.APIMethod,
-				Entity:           entity,
-			},
-			ratelimit.Limit{
-				Rate:   rateLimiterDescription.Rate,
-				Burst:  rateLimiterDescription.Burst,
-				Period: rateLimiterDescription.Period,
-			},
-		)
+	var rateLimitHeaders http.Header
+
+	if len(rateLimitRequests) != 0 {
+		result, err := p.rateLimiter.Allow(ctx, rateLimitRequests)
 		if err != nil {
-			return newErrorResponse(http.StatusInternalServerError, fmt.Sprintf("internal server error with ratelimiter: %s", err), nil)
+			// limiter fails closed unless it is wrapped by ratelimit.WithFailOpen.
+			auditFields.Result = audit.ResultError
+			return newErrorResponse(http.StatusServiceUnavailable, fmt.Sprintf("rate limiter is unavailable: %s", err), nil)
 		}
 
-		if !isAllowed {
+		rateLimitHeaders = newRateLimitHeaders(rateLimitRequests, result.Statuses)
+
+		if !result.Allowed {
+			headers := rateLimitHeaders.Clone()
+			if headers == nil {
+				headers = make(http.Header)
+			}
+
+			headers.Set("Retry-After", strconv.Itoa(int(math.Ceil(result.RetryAfter.Seconds()))))
+
+			auditFields.Result = audit.ResultDenied
+
 			return newErrorResponse(
 				http.StatusTooManyRequests,
-				fmt.Sprintf("rate limit exceeded, retry after %s", retryAfter.String()),
-				map[string][]string{
-					"Retry-After": {strconv.Itoa(int(math.Ceil(retryAfter.Seconds())))},
-				},
+				fmt.Sprintf("rate limit exceeded, retry after %s", result.RetryAfter.String()),
+				headers,
 			)
 		}
 	}
 
+	priority := p.requestPriority(request, subjectInformation, description)
+
+	// rate limits are checked before slots are taken, so rejected requests don't hold places in queue,
+	// and cost of shed requests is refunded.
+	slots, err := p.acquireConcurrency(ctx, request, priority)
+	if err != nil {
+		p.refundRateLimits(ctx, rateLimitRequests)
+
+		auditFields.Result = audit.ResultError
+		return newErrorResponse(http.StatusServiceUnavailable, fmt.Sprintf("service is overloaded: %s", err), nil)
+	}
+
+	// requests rejected before reaching provider free slots without affecting adaptive limit.
+	defer slots.Cancel()
+
+	quotaHeaders, errResp := p.consumeQuotas(ctx, request, subjectInformation)
+	if errResp != nil {
+		auditFields.Result = audit.ResultError
+		if errResp.StatusCode == http.StatusTooManyRequests {
+			auditFields.Result = audit.ResultDenied
+		}
+
+		return errResp
+	}
+
 	processRequest := &domain.ProviderProcessRequest{
-		APIMethod:          request.APIMethod,
+		APIMethod:          routeMethod,
 		HTTPMethod:         request.HTTPMethod,
 		Path:               request.Path,
 		Query:              request.Query,
 		Body:               request.Body,
 		Headers:            request.Headers,
 		SubjectInformation: subjectInformation,
+		Priority:           priority,
 	}
 
+	// token must be taken before preprocessing, because authorization headers are removed.
+	_, userToken := getAuthorization(request.Headers)
+
 	processRequest.Preprocess()
 
+	if p.identitySigner != nil && !subjectInformation.Anonymous {
+		processRequest.IdentityToken, err = p.identitySigner.Sign(request.Service, subjectInformation)
+		if err != nil {
+			auditFields.Result = audit.ResultError
+			return newErrorResponse(http.StatusInternalServerError, fmt.Sprintf("failed to sign identity token: %s", err), nil)
+		}
+	}
+
+	if p.tokenExchanger != nil && serviceConfig != nil && serviceConfig.TokenExchangeAudience != "" &&
+		!subjectInformation.Anonymous && userToken != "" {
+		processRequest.UserToken, err = p.tokenExchanger.Exchange(ctx, subjectInformation, userToken, serviceConfig.TokenExchangeAudience)
+		if err != nil {
+			auditFields.Result = audit.ResultError
+			return newErrorResponse(http.StatusInternalServerError, fmt.Sprintf("failed to exchange user token: %s", err), nil)
+		}
+	}
+
 	processResp, err := client.Process(ctx, processRequest)
+	slots.Release(err != nil)
+
 	if err != nil {
 		auditFields.Result = audit.ResultError
 		return newErrorResponse(http.StatusInternalServerError, fmt.Sprintf("failed to process request: %s", err), nil)
@@ -181,20 +332,85 @@ func (p *impl) Process(ctx context.Context, request *domain.ProcessRequest) *dom
 
 	processResp.SetDefaults()
 
+	if actualCost := min(processResp.ActualCost, domain.MaxRequestCost); actualCost > cost {
+		p.chargeActualCost(ctx, rateLimitRequests, actualCost-cost)
+	}
+
+	// limit headers of gateway replace headers of provider with the same names.
+	for _, headers := range []http.Header{rateLimitHeaders, quotaHeaders} {
+		if len(headers) == 0 {
+			continue
+		}
+
+		if processResp.Headers == nil {
+			processResp.Headers = make(http.Header)
+		}
+
+		for name, values := range headers {
+			processResp.Headers[name] = values
+		}
+	}
+
 	return processResp
 }
 
-func getAuthorizationHeaderValue(headers http.Header) string {
-	return strings.TrimPrefix(headers.Get(authorizationHeader), "Bearer ")
+// resolveMethod returns request with name of method that serves it when client routes requests by path,
+// so annotated routes of transcoded services get rules of their gRPC method and not of first path segment.
+func (p *impl) resolveMethod(
+	ctx context.Context,
+	client provider.Client,
+	request *domain.ProcessRequest,
+) (*domain.ProcessRequest, *domain.ProviderProcessResponse) {
+	resolver, ok := client.(methodResolver)
+	if !ok {
+		return request, nil
+	}
+
+	method, err := resolver.ResolveMethod(ctx, request.HTTPMethod, request.APIMethod, request.Path)
+	if errors.Is(err, provider.ErrMethodNotFound) {
+		return nil, newErrorResponse(http.StatusNotFound, fmt.Sprintf("method %s of service %s not found", request.APIMethod, request.Service), nil)
+	}
+
+	if err != nil {
+		return nil, newErrorResponse(http.StatusInternalServerError, fmt.Sprintf("failed to resolve method: %s", err), nil)
+	}
+
+	resolved := *request
+	resolved.APIMethod = method
+
+	return &resolved, nil
+}
+
+// requestPriority returns priority of request from consumer and method.
+func (p *impl) requestPriority(
+	request *domain.ProcessRequest,
+	subjectInformation *domain.SubjectInformation,
+	description *domain.ProviderDescription,
+) domain.Priority {
+	var apiKeyHash string
+	if apiKey := request.Headers.Get(defaultAPIKeyHeader); apiKey != "" && p.priorityRules != nil {
+		apiKeyHash = override.HashAPIKey(apiKey)
+	}
+
+	return description.SelectPriority(request.APIMethod, p.priorityRules.ConsumerPriority(subjectInformation, apiKeyHash))
 }
 
-func getRealIP(remoteAddr string, headers http.Header) string {
-	realIP := headers.Get(forwardedForHeader)
-	if realIP == "" {
-		realIP, _, _ = net.SplitHostPort(remoteAddr) //nolint:errcheck
+// acquireConcurrency takes slots of service and method, requests of services without limits are not bounded.
+func (p *impl) acquireConcurrency(
+	ctx context.Context,
+	request *domain.ProcessRequest,
+	priority domain.Priority,
+) (*concurrency.Slots, error) {
+	if p.concurrencyStore == nil {
+		return nil, nil
+	}
+
+	group, ok := p.concurrencyStore.Get(request.Service)
+	if !ok {
+		return nil, nil
 	}
 
-	return realIP
+	return group.Acquire(ctx, request.APIMethod, priority)
 }
 
 type jsonError struct {
@@ -0,0 +1,133 @@
+package processor
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	mapset "github.com/deckarep/golang-set/v2"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/audit"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/clients/provider"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/concurrency"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/ratelimit"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/utils/store"
+)
+
+type fakeClient struct {
+	provider.Client
+}
+
+func (fakeClient) Process(context.Context, *domain.ProviderProcessRequest) (*domain.ProviderProcessResponse, error) {
+	return &domain.ProviderProcessResponse{StatusCode: http.StatusOK}, nil
+}
+
+type fakeAuditor struct {
+	results []audit.Result
+}
+
+func (a *fakeAuditor) Write(_ context.Context, fields audit.Fields) {
+	a.results = append(a.results, fields.Result)
+}
+
+type fakeResolvingClient struct {
+	provider.Client
+	// routes are names of methods by first path segment and path.
+	routes map[string]string
+	err    error
+}
+
+func (c fakeResolvingClient) ResolveMethod(_ context.Context, _ domain.HTTPMethod, apiMethod, path string) (string, error) {
+	if c.err != nil {
+		return "", c.err
+	}
+
+	method, ok := c.routes[apiMethod+"/"+path]
+	if !ok {
+		return "", provider.ErrMethodNotFound
+	}
+
+	return method, nil
+}
+
+func TestResolveMethod(t *testing.T) {
+	t.Parallel()
+
+	p := &impl{}
+	request := &domain.ProcessRequest{Service: "library", HTTPMethod: domain.HTTPMethodGet, APIMethod: "v1", Path: "books/1"}
+
+	resolved, errResp := p.resolveMethod(context.Background(), fakeClient{}, request)
+	require.Nil(t, errResp)
+	assert.Same(t, request, resolved, "requests of clients without routing keep method of first segment")
+
+	client := fakeResolvingClient{routes: map[string]string{"v1/books/1": "GetBook"}}
+
+	resolved, errResp = p.resolveMethod(context.Background(), client, request)
+	require.Nil(t, errResp)
+	assert.Equal(t, "GetBook", resolved.APIMethod)
+	assert.Equal(t, "books/1", resolved.Path)
+	assert.Equal(t, "v1", request.APIMethod, "request of caller must not be changed")
+
+	_, errResp = p.resolveMethod(context.Background(), client, &domain.ProcessRequest{Service: "library", APIMethod: "v1", Path: "books"})
+	require.NotNil(t, errResp)
+	assert.Equal(t, uint32(http.StatusNotFound), errResp.StatusCode)
+
+	_, errResp = p.resolveMethod(context.Background(), fakeResolvingClient{err: errors.New("reflection failed")}, request)
+	require.NotNil(t, errResp)
+	assert.Equal(t, uint32(http.StatusInternalServerError), errResp.StatusCode)
+}
+
+func TestProcessChecksRateLimitsBeforeConcurrency(t *testing.T) {
+	t.Parallel()
+
+	description := &domain.ProviderDescription{
+		AuditEnabled:       true,
+		AuthenticationMode: domain.AuthenticationModeNone,
+		RateLimiters:       []*domain.RateLimiterDescription{{By: domain.RateLimitDescriptionByGlobal, Rate: 1, Burst: 1, Period: time.Hour}},
+		DescriptionByMethod: map[string]*domain.ProviderDescriptionMethod{
+			"hello": {Method: "hello", AllowedHTTPMethods: mapset.NewThreadUnsafeSet(domain.HTTPMethodGet)},
+		},
+	}
+
+	// no queue, so requests are shed at once while slot is held.
+	group := concurrency.NewGroup(concurrency.New(concurrency.Options{Service: "greeting", MaxInFlight: 1}), nil)
+	auditor := &fakeAuditor{}
+
+	p := New(NewOptions{
+		DescriptionStore:   store.New(map[string]*domain.ProviderDescription{"greeting": description}),
+		ClientStore:        store.New(map[string]provider.Client{"greeting": fakeClient{}}),
+		ServiceConfigStore: store.New(map[string]*domain.ConfigService{}),
+		Auditor:            auditor,
+		RateLimiter:        ratelimit.NewMemory(),
+		ConcurrencyStore:   store.New(map[string]*concurrency.Group{"greeting": group}),
+	})
+
+	ctx := context.Background()
+	process := func() uint32 {
+		return p.Process(ctx, &domain.ProcessRequest{Service: "greeting", APIMethod: "hello", HTTPMethod: domain.HTTPMethodGet}).StatusCode
+	}
+
+	held, err := group.Acquire(ctx, "hello", domain.PriorityNormal)
+	require.NoError(t, err)
+
+	// shed request returns its token to rate limiter.
+	assert.Equal(t, uint32(http.StatusServiceUnavailable), process())
+
+	held.Cancel()
+	assert.Equal(t, uint32(http.StatusOK), process())
+
+	held, err = group.Acquire(ctx, "hello", domain.PriorityNormal)
+	require.NoError(t, err)
+	t.Cleanup(held.Cancel)
+
+	// rate-limited request is rejected before it could be shed.
+	assert.Equal(t, uint32(http.StatusTooManyRequests), process())
+
+	assert.Equal(t, []audit.Result{audit.ResultError, audit.ResultOk, audit.ResultDenied}, auditor.results)
+}
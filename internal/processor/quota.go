@@ -0,0 +1,106 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/quota"
+)
+
+const (
+	quotaLimitHeader     = "X-Quota-Limit"
+	quotaRemainingHeader = "X-Quota-Remaining"
+	quotaResetHeader     = "X-Quota-Reset"
+)
+
+type quotaStore interface {
+	Get(service string) ([]*domain.Quota, bool)
+}
+
+// quotaStatus is a state of one quota after request.
+type quotaStatus struct {
+	limit     uint64
+	remaining uint64
+	reset     time.Duration
+}
+
+// consumeQuotas counts request by quotas of method. It returns headers of the most exhausted quota
+// or error response when any quota is exhausted.
+func (p *impl) consumeQuotas(
+	ctx context.Context,
+	request *domain.ProcessRequest,
+	subjectInformation *domain.SubjectInformation,
+) (http.Header, *domain.ProviderProcessResponse) {
+	if p.quotaStore == nil || p.quotaCounter == nil {
+		return nil, nil
+	}
+
+	quotas, _ := p.quotaStore.Get(request.Service)
+
+	quotas = domain.SelectQuotas(quotas, request.APIMethod)
+	if len(quotas) == 0 {
+		return nil, nil
+	}
+
+	now := time.Now()
+	requests := make([]quota.Request, 0, len(quotas))
+
+	for _, q := range quotas {
+		windowStart, windowEnd := q.Window(now)
+
+		requests = append(requests, quota.Request{
+			Key: quota.Key{
+				Service:     request.Service,
+				Quota:       q.Name,
+				WindowStart: windowStart,
+				Entity:      p.rateLimitEntities(request, subjectInformation, q.Keys),
+			},
+			Limit:     q.Limit,
+			WindowEnd: windowEnd,
+		})
+	}
+
+	result, err := p.quotaCounter.Consume(ctx, requests)
+	if err != nil {
+		return nil, newErrorResponse(http.StatusServiceUnavailable, fmt.Sprintf("quota counter is unavailable: %s", err), nil)
+	}
+
+	var status *quotaStatus
+
+	for i, request := range requests {
+		current := &quotaStatus{
+			limit:     request.Limit,
+			remaining: request.Limit - min(result.Used[i], request.Limit),
+			reset:     request.WindowEnd.Sub(now),
+		}
+
+		// the most exhausted quota is reported, the one that resets later wins a tie.
+		if status == nil || current.remaining < status.remaining ||
+			current.remaining == status.remaining && current.reset > status.reset {
+			status = current
+		}
+	}
+
+	headers := status.headers()
+
+	if !result.Allowed {
+		headers.Set("Retry-After", headers.Get(quotaResetHeader))
+		return nil, newErrorResponse(http.StatusTooManyRequests, fmt.Sprintf("quota exceeded, retry after %s", status.reset.Round(time.Second)), headers)
+	}
+
+	return headers, nil
+}
+
+func (s *quotaStatus) headers() http.Header {
+	headers := make(http.Header)
+	headers.Set(quotaLimitHeader, strconv.FormatUint(s.limit, 10))
+	headers.Set(quotaRemainingHeader, strconv.FormatUint(s.remaining, 10))
+	headers.Set(quotaResetHeader, strconv.Itoa(int(math.Ceil(s.reset.Seconds()))))
+
+	return headers
+}
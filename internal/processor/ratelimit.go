@@ -0,0 +1,259 @@
+package processor
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/override"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/ratelimit"
+)
+
+const (
+	anonymousEntityPrefix = "anonymous:"
+	// missingEntityPrefix is used when request has no value of key, such callers are limited by IP.
+	missingEntityPrefix = "missing:"
+	globalEntity        = "global"
+
+	rateLimitLimitHeader     = "RateLimit-Limit"
+	rateLimitRemainingHeader = "RateLimit-Remaining"
+	rateLimitResetHeader     = "RateLimit-Reset"
+	rateLimitPolicyHeader    = "RateLimit-Policy"
+
+	defaultAPIKeyHeader = "X-API-Key"
+
+	overrideLimiterPrefix = "override:"
+)
+
+type overrideTable interface {
+	Match(service string, consumer override.Consumer) (override.Entry, bool)
+}
+
+// newRateLimitRequests returns requests to limiters of description, or to limit of override when consumer has one.
+// Blocked consumer gets error response.
+func (p *impl) newRateLimitRequests(
+	request *domain.ProcessRequest,
+	subjectInformation *domain.SubjectInformation,
+	description *domain.ProviderDescription,
+	cost uint64,
+) ([]ratelimit.Request, *domain.ProviderProcessResponse) {
+	if p.overrideTable != nil {
+		entry, ok := p.overrideTable.Match(request.Service, p.consumer(request, subjectInformation))
+		if ok && entry.Action == override.ActionBlock {
+			return nil, newErrorResponse(http.StatusForbidden, "consumer is blocked", nil)
+		}
+
+		if ok {
+			// consumer of override shares one limit, e.g. all callers of CIDR.
+			return []ratelimit.Request{{
+				Key: ratelimit.Key{
+					Service:          request.Service,
+					IsServiceLimiter: true,
+					Limiter:          overrideLimiterPrefix + entry.Kind.String(),
+					Entity:           entry.Value,
+				},
+				Limit: entry.Limit,
+				Cost:  cost,
+			}}, nil
+		}
+	}
+
+	serviceLimiters, methodLimiters := description.SelectRateLimiters(request.APIMethod)
+
+	requests := make([]ratelimit.Request, 0, len(serviceLimiters)+len(methodLimiters))
+	for _, limiter := range serviceLimiters {
+		requests = append(requests, p.newRateLimitRequest(request, subjectInformation, limiter, true, cost))
+	}
+
+	for _, limiter := range methodLimiters {
+		requests = append(requests, p.newRateLimitRequest(request, subjectInformation, limiter, false, cost))
+	}
+
+	return requests, nil
+}
+
+func (p *impl) consumer(request *domain.ProcessRequest, subjectInformation *domain.SubjectInformation) override.Consumer {
+	consumer := override.Consumer{
+		IP: p.clientIP(request),
+	}
+
+	if !subjectInformation.Anonymous {
+		consumer.SubjectID = subjectInformation.ID
+	}
+
+	if org, ok := subjectInformation.Claims[p.overrideOrgClaim]; ok && org != nil {
+		consumer.Org = fmt.Sprint(org)
+	}
+
+	if apiKey := request.Headers.Get(defaultAPIKeyHeader); apiKey != "" {
+		consumer.APIKeyHash = override.HashAPIKey(apiKey)
+	}
+
+	return consumer
+}
+
+func (p *impl) newRateLimitRequest(
+	request *domain.ProcessRequest,
+	subjectInformation *domain.SubjectInformation,
+	limiter *domain.RateLimiterDescription,
+	isServiceLimiter bool,
+	cost uint64,
+) ratelimit.Request {
+	return ratelimit.Request{
+		Key: ratelimit.Key{
+			Service:          request.Service,
+			IsServiceLimiter: isServiceLimiter,
+			Method:           request.APIMethod,
+			Limiter:          limiter.ID(),
+			Entity:           p.rateLimitEntities(request, subjectInformation, limiter.KeyParts()),
+		},
+		Limit: ratelimit.Limit{
+			Rate:   limiter.Rate,
+			Burst:  limiter.Burst,
+			Period: limiter.Period,
+		},
+		Cost: cost,
+	}
+}
+
+func requestCost(request *domain.ProcessRequest, cost *domain.RequestCost) uint64 {
+	if cost == nil {
+		return 1
+	}
+
+	query, _ := url.ParseQuery(request.Query) //nolint:errcheck
+
+	return cost.Cost(query, request.Body)
+}
+
+// refundRateLimits returns cost of requests shed after rate limits allowed them. Failure is only logged,
+// so limits stay charged until tokens are emitted.
+func (p *impl) refundRateLimits(ctx context.Context, requests []ratelimit.Request) {
+	if len(requests) == 0 {
+		return
+	}
+
+	if err := p.rateLimiter.Refund(ctx, requests); err != nil {
+		slog.Warn("Failed to refund rate limits of shed request", slog.String("err", err.Error()))
+	}
+}
+
+// chargeActualCost takes extra cost reported by provider from limiters of request. Response is already received,
+// so failure is only logged, and next requests are limited by cost estimated before processing.
+func (p *impl) chargeActualCost(ctx context.Context, requests []ratelimit.Request, extra uint64) {
+	if len(requests) == 0 {
+		return
+	}
+
+	charged := make([]ratelimit.Request, 0, len(requests))
+	for _, request := range requests {
+		request.Cost = extra
+		charged = append(charged, request)
+	}
+
+	if err := p.rateLimiter.Charge(ctx, charged); err != nil {
+		slog.Warn("Failed to charge actual cost of request", slog.String("err", err.Error()))
+	}
+}
+
+// rateLimitEntities returns entity of request joined from values of all key parts.
+func (p *impl) rateLimitEntities(request *domain.ProcessRequest, subjectInformation *domain.SubjectInformation, keyParts []domain.RateLimitKey) string {
+	var query url.Values
+
+	entities := make([]string, 0, len(keyParts))
+
+	for _, key := range keyParts {
+		if query == nil && key.By == domain.RateLimitDescriptionByQuery {
+			query, _ = url.ParseQuery(request.Query) //nolint:errcheck
+		}
+
+		entities = append(entities, p.rateLimitEntity(request, subjectInformation, query, key))
+	}
+
+	return strings.Join(entities, "|")
+}
+
+func (p *impl) rateLimitEntity(
+	request *domain.ProcessRequest,
+	subjectInformation *domain.SubjectInformation,
+	query url.Values,
+	key domain.RateLimitKey,
+) string {
+	var value string
+
+	switch key.By {
+	case domain.RateLimitDescriptionByIp:
+		return p.clientIP(request)
+	case domain.RateLimitDescriptionByGlobal:
+		return globalEntity
+	case domain.RateLimitDescriptionBySubjectId:
+		if subjectInformation.Anonymous {
+			// all anonymous callers must not share one limit.
+			return anonymousEntityPrefix + p.clientIP(request)
+		}
+
+		value = subjectInformation.ID
+	case domain.RateLimitDescriptionByClaim:
+		if claim, ok := subjectInformation.Claims[key.Name]; ok && claim != nil {
+			value = fmt.Sprint(claim)
+		}
+	case domain.RateLimitDescriptionByHeader:
+		value = request.Headers.Get(key.Name)
+	case domain.RateLimitDescriptionByQuery:
+		value = query.Get(key.Name)
+	case domain.RateLimitDescriptionByApiKey:
+		name := key.Name
+		if name == "" {
+			name = defaultAPIKeyHeader
+		}
+
+		if apiKey := request.Headers.Get(name); apiKey != "" {
+			// API key is a secret and must not be stored in Redis as is.
+			value = override.HashAPIKey(apiKey)
+		}
+	}
+
+	if value == "" {
+		return missingEntityPrefix + p.clientIP(request)
+	}
+
+	return value
+}
+
+// newRateLimitHeaders returns RateLimit headers of the most exhausted limit, policy header lists all limits.
+func newRateLimitHeaders(requests []ratelimit.Request, statuses []ratelimit.Status) http.Header {
+	if len(statuses) != len(requests) || len(statuses) == 0 {
+		return nil
+	}
+
+	current := statuses[0]
+	policies := make([]string, 0, len(statuses))
+
+	for _, status := range statuses {
+		// the limit that resets later wins a tie.
+		if status.Remaining < current.Remaining || status.Remaining == current.Remaining && status.Reset > current.Reset {
+			current = status
+		}
+
+		policy := fmt.Sprintf("%d;w=%d", status.Limit.Rate, int(math.Ceil(status.Limit.Period.Seconds())))
+		if status.Limit.Burst != status.Limit.Rate {
+			policy += fmt.Sprintf(";burst=%d", status.Limit.Burst)
+		}
+
+		policies = append(policies, policy)
+	}
+
+	headers := make(http.Header)
+	headers.Set(rateLimitLimitHeader, strconv.FormatUint(current.Limit.Burst, 10))
+	headers.Set(rateLimitRemainingHeader, strconv.FormatUint(current.Remaining, 10))
+	headers.Set(rateLimitResetHeader, strconv.Itoa(int(math.Ceil(current.Reset.Seconds()))))
+	headers.Set(rateLimitPolicyHeader, strings.Join(policies, ", "))
+
+	return headers
+}
@@ -0,0 +1,233 @@
+package processor
+
+import (
+	"net/http"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/override"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/ratelimit"
+)
+
+func TestNewRateLimitRequestEntity(t *testing.T) {
+	t.Parallel()
+
+	request := &domain.ProcessRequest{
+		Service:    "greeting",
+		APIMethod:  "hello",
+		Query:      "tenant=acme",
+		Headers:    http.Header{"X-Api-Key": {"secret"}, "X-Client": {"mobile"}},
+		RemoteAddr: "10.0.0.1:5000",
+	}
+
+	subject := &domain.SubjectInformation{
+		ID:     "user",
+		Claims: map[string]any{"org_id": "org-1"},
+	}
+
+	tests := []struct {
+		name    string
+		limiter *domain.RateLimiterDescription
+		subject *domain.SubjectInformation
+		entity  string
+	}{
+		{
+			name:    "ip",
+			limiter: &domain.RateLimiterDescription{By: domain.RateLimitDescriptionByIp},
+			subject: subject,
+			entity:  "10.0.0.1",
+		},
+		{
+			name:    "anonymous subject",
+			limiter: &domain.RateLimiterDescription{By: domain.RateLimitDescriptionBySubjectId},
+			subject: domain.NewAnonymousSubject(),
+			entity:  "anonymous:10.0.0.1",
+		},
+		{
+			name:    "claim",
+			limiter: &domain.RateLimiterDescription{By: domain.RateLimitDescriptionByClaim, Name: "org_id"},
+			subject: subject,
+			entity:  "org-1",
+		},
+		{
+			name:    "missing claim",
+			limiter: &domain.RateLimiterDescription{By: domain.RateLimitDescriptionByClaim, Name: "team_id"},
+			subject: subject,
+			entity:  "missing:10.0.0.1",
+		},
+		{
+			name:    "query",
+			limiter: &domain.RateLimiterDescription{By: domain.RateLimitDescriptionByQuery, Name: "tenant"},
+			subject: subject,
+			entity:  "acme",
+		},
+		{
+			name:    "global",
+			limiter: &domain.RateLimiterDescription{By: domain.RateLimitDescriptionByGlobal},
+			subject: subject,
+			entity:  "global",
+		},
+		{
+			name:    "api key",
+			limiter: &domain.RateLimiterDescription{By: domain.RateLimitDescriptionByApiKey},
+			subject: subject,
+			entity:  "2bb80d537b1da3e38bd30361aa855686bde0eacd7162fef6a25fe97bf527a25b",
+		},
+		{
+			name: "composite",
+			limiter: &domain.RateLimiterDescription{
+				By: domain.RateLimitDescriptionByComposite,
+				Keys: []domain.RateLimitKey{
+					{By: domain.RateLimitDescriptionBySubjectId},
+					{By: domain.RateLimitDescriptionByHeader, Name: "X-Client"},
+				},
+			},
+			subject: subject,
+			entity:  "user|mobile",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.limiter.Period = time.Second
+
+			req := (&impl{}).newRateLimitRequest(request, tt.subject, tt.limiter, false, 1)
+			assert.Equal(t, tt.entity, req.Key.Entity)
+		})
+	}
+}
+
+func TestNewRateLimitHeaders(t *testing.T) {
+	t.Parallel()
+
+	perSecond := ratelimit.Limit{Rate: 10, Burst: 20, Period: time.Second}
+	perDay := ratelimit.Limit{Rate: 1000, Burst: 1000, Period: 24 * time.Hour}
+
+	headers := newRateLimitHeaders(make([]ratelimit.Request, 2), []ratelimit.Status{
+		{Limit: perSecond, Remaining: 15, Reset: 500 * time.Millisecond},
+		{Limit: perDay, Remaining: 3, Reset: 23 * time.Hour},
+	})
+
+	assert.Equal(t, "1000", headers.Get("RateLimit-Limit"))
+	assert.Equal(t, "3", headers.Get("RateLimit-Remaining"))
+	assert.Equal(t, "82800", headers.Get("RateLimit-Reset"))
+	assert.Equal(t, "10;w=1;burst=20, 1000;w=86400", headers.Get("RateLimit-Policy"))
+
+	assert.Nil(t, newRateLimitHeaders(make([]ratelimit.Request, 1), nil), "fail open limiter reports no status")
+}
+
+type testOverrideTable map[string]override.Entry
+
+func (t testOverrideTable) Match(_ string, consumer override.Consumer) (override.Entry, bool) {
+	entry, ok := t[consumer.Org]
+	return entry, ok
+}
+
+func TestNewRateLimitRequestsOverride(t *testing.T) {
+	t.Parallel()
+
+	limit := ratelimit.Limit{Rate: 1000, Burst: 1000, Period: time.Minute}
+	p := &impl{
+		overrideTable: testOverrideTable{
+			"partner": {Kind: override.KindOrg, Value: "partner", Action: override.ActionLimit, Limit: limit},
+			"abuser":  {Kind: override.KindOrg, Value: "abuser", Action: override.ActionBlock},
+		},
+		overrideOrgClaim: "org_id",
+	}
+
+	request := &domain.ProcessRequest{Service: "greeting", APIMethod: "hello", RemoteAddr: "10.0.0.1:5000"}
+	description := &domain.ProviderDescription{
+		RateLimiters: []*domain.RateLimiterDescription{
+			{By: domain.RateLimitDescriptionBySubjectId, Rate: 10, Burst: 10, Period: time.Second},
+		},
+	}
+
+	requests, errResp := p.newRateLimitRequests(request, &domain.SubjectInformation{
+		ID:     "user",
+		Claims: map[string]any{"org_id": "partner"},
+	}, description, 1)
+	assert.Nil(t, errResp)
+	assert.Equal(t, []ratelimit.Request{{
+		Key:   ratelimit.Key{Service: "greeting", IsServiceLimiter: true, Limiter: "override:org", Entity: "partner"},
+		Limit: limit,
+		Cost:  1,
+	}}, requests)
+
+	_, errResp = p.newRateLimitRequests(request, &domain.SubjectInformation{
+		ID:     "user",
+		Claims: map[string]any{"org_id": "abuser"},
+	}, description, 1)
+	if assert.NotNil(t, errResp) {
+		assert.Equal(t, uint32(http.StatusForbidden), errResp.StatusCode)
+	}
+
+	requests, errResp = p.newRateLimitRequests(request, &domain.SubjectInformation{ID: "user"}, description, 1)
+	assert.Nil(t, errResp)
+	assert.Len(t, requests, 1)
+	assert.Equal(t, uint64(10), requests[0].Limit.Rate)
+}
+
+type testIPOverrideTable map[string]override.Entry
+
+func (t testIPOverrideTable) Match(_ string, consumer override.Consumer) (override.Entry, bool) {
+	entry, ok := t[consumer.IP]
+	return entry, ok
+}
+
+func TestNewRateLimitRequestsIPOverride(t *testing.T) {
+	t.Parallel()
+
+	p := &impl{
+		overrideTable: testIPOverrideTable{
+			"203.0.113.7": {Kind: override.KindIp, Value: "203.0.113.7/32", Action: override.ActionBlock},
+		},
+		trustedProxies: []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")},
+	}
+
+	subject := domain.NewAnonymousSubject()
+	description := &domain.ProviderDescription{}
+
+	tests := []struct {
+		name    string
+		request *domain.ProcessRequest
+		blocked bool
+	}{
+		{
+			name:    "spoofed header of untrusted peer",
+			request: &domain.ProcessRequest{RemoteAddr: "203.0.113.7:5000", Headers: http.Header{"X-Forwarded-For": {"1.1.1.1"}}},
+			blocked: true,
+		},
+		{
+			name:    "junk header of untrusted peer",
+			request: &domain.ProcessRequest{RemoteAddr: "203.0.113.7:5000", Headers: http.Header{"X-Forwarded-For": {"junk"}}},
+			blocked: true,
+		},
+		{
+			name:    "proxy chain",
+			request: &domain.ProcessRequest{RemoteAddr: "10.0.0.1:5000", Headers: http.Header{"X-Forwarded-For": {"1.1.1.1, 203.0.113.7"}}},
+			blocked: true,
+		},
+		{
+			name:    "other client",
+			request: &domain.ProcessRequest{RemoteAddr: "10.0.0.1:5000", Headers: http.Header{"X-Forwarded-For": {"198.51.100.1"}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			tt.request.Service = "greeting"
+			tt.request.APIMethod = "hello"
+
+			_, errResp := p.newRateLimitRequests(tt.request, subject, description, 1)
+			assert.Equal(t, tt.blocked, errResp != nil)
+		})
+	}
+}
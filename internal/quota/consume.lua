@@ -0,0 +1,29 @@
+-- Counts request by all quotas when none of them is exhausted, otherwise returns current usage.
+-- Every KEY is a sorted set of usage by entity in quota window.
+-- ARGV holds entity, limit and unix time of key expiration for every key.
+local allowed = 1
+local used = {}
+
+for i, key in ipairs(KEYS) do
+  local current = redis.call("ZSCORE", key, ARGV[3 * i - 2])
+  if current then
+    current = tonumber(current)
+  else
+    current = 0
+  end
+
+  if current + 1 > tonumber(ARGV[3 * i - 1]) then
+    allowed = 0
+  end
+
+  used[i] = current
+end
+
+if allowed == 1 then
+  for i, key in ipairs(KEYS) do
+    used[i] = tonumber(redis.call("ZINCRBY", key, 1, ARGV[3 * i - 2]))
+    redis.call("EXPIREAT", key, ARGV[3 * i])
+  end
+end
+
+return {allowed, used}
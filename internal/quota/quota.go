@@ -0,0 +1,53 @@
+package quota
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Key identifies usage of entity in quota window.
+type Key struct {
+	Service string
+	Quota   string
+	// WindowStart identifies window of quota.
+	WindowStart time.Time
+	Entity      string
+}
+
+func (k Key) window() string {
+	return windowKey(k.Service, k.Quota, k.WindowStart)
+}
+
+func windowKey(service, quota string, windowStart time.Time) string {
+	return fmt.Sprintf("quota_%s:%s:%d", service, quota, windowStart.Unix())
+}
+
+// Request is a key to count against its limit.
+type Request struct {
+	Key   Key
+	Limit uint64
+	// WindowEnd is a time of quota reset.
+	WindowEnd time.Time
+}
+
+// Result ...
+type Result struct {
+	Allowed bool
+	// Used is usage of every request after counting, or current usage when request is not allowed.
+	Used []uint64
+}
+
+// Usage of quota by entity.
+type Usage struct {
+	Entity string
+	Used   uint64
+}
+
+// Counter of quotas.
+type Counter interface {
+	// Consume counts all requests atomically, request is counted by no quota unless all of them allow it.
+	Consume(ctx context.Context, requests []Request) (Result, error)
+	// Usage returns usage of quota window by all entities, most used first.
+	Usage(ctx context.Context, service, quota string, windowStart time.Time) ([]Usage, error)
+}
@@ -0,0 +1,78 @@
+package quota
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// usageRetention keeps usage of ended windows to report it, e.g. for billing of previous month.
+const usageRetention = 35 * 24 * time.Hour
+
+//go:embed consume.lua
+var consumeScript string
+
+var consume = redis.NewScript(consumeScript)
+
+type redisCounter struct {
+	client *redis.Client
+}
+
+// NewRedis returns new Counter. All keys are counted by one script, so Redis must not be a cluster.
+func NewRedis(client *redis.Client) Counter {
+	return &redisCounter{
+		client: client,
+	}
+}
+
+func (r *redisCounter) Consume(ctx context.Context, requests []Request) (Result, error) {
+	if len(requests) == 0 {
+		return Result{Allowed: true}, nil
+	}
+
+	keys := make([]string, 0, len(requests))
+	args := make([]any, 0, 3*len(requests))
+
+	for _, request := range requests {
+		keys = append(keys, request.Key.window())
+		args = append(args, request.Key.Entity, request.Limit, request.WindowEnd.Add(usageRetention).Unix())
+	}
+
+	values, err := consume.Run(ctx, r.client, keys, args...).Slice()
+	if err != nil {
+		return Result{}, fmt.Errorf("redis quota: %w", err)
+	}
+
+	used := values[1].([]any) //nolint:forcetypeassert
+
+	result := Result{
+		Allowed: values[0].(int64) == 1, //nolint:forcetypeassert
+		Used:    make([]uint64, 0, len(used)),
+	}
+
+	for _, value := range used {
+		result.Used = append(result.Used, uint64(value.(int64))) //nolint:forcetypeassert
+	}
+
+	return result, nil
+}
+
+func (r *redisCounter) Usage(ctx context.Context, service, quota string, windowStart time.Time) ([]Usage, error) {
+	entries, err := r.client.ZRevRangeWithScores(ctx, windowKey(service, quota, windowStart), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("redis quota: %w", err)
+	}
+
+	result := make([]Usage, 0, len(entries))
+	for _, entry := range entries {
+		result = append(result, Usage{
+			Entity: entry.Member.(string), //nolint:forcetypeassert
+			Used:   uint64(entry.Score),
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,60 @@
+package quota
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisConsume(t *testing.T) {
+	t.Parallel()
+
+	srv := miniredis.RunT(t)
+	counter := NewRedis(redis.NewClient(&redis.Options{Addr: srv.Addr()}))
+	ctx := context.Background()
+
+	// keys expire after windows end, so windows must be current.
+	now := time.Now().UTC()
+	windowStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	windowEnd := windowStart.AddDate(0, 1, 0)
+
+	monthly := Request{
+		Key:       Key{Service: "svc", Quota: "monthly", WindowStart: windowStart, Entity: "partner"},
+		Limit:     3,
+		WindowEnd: windowEnd,
+	}
+	daily := Request{
+		Key:       Key{Service: "svc", Quota: "daily", WindowStart: windowStart, Entity: "partner"},
+		Limit:     1,
+		WindowEnd: windowStart.AddDate(0, 0, 1),
+	}
+
+	result, err := counter.Consume(ctx, []Request{monthly, daily})
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, []uint64{1, 1}, result.Used)
+
+	// daily quota is exhausted, so request isn't counted by monthly one.
+	result, err = counter.Consume(ctx, []Request{monthly, daily})
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, []uint64{1, 1}, result.Used)
+
+	other := monthly
+	other.Key.Entity = "other"
+
+	for _, request := range []Request{monthly, other, monthly} {
+		result, err = counter.Consume(ctx, []Request{request})
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	}
+
+	usage, err := counter.Usage(ctx, "svc", "monthly", windowStart)
+	require.NoError(t, err)
+	assert.Equal(t, []Usage{{Entity: "partner", Used: 3}, {Entity: "other", Used: 1}}, usage)
+}
@@ -0,0 +1,30 @@
+-- Adds tokens to TAT of keys without checking limits and returns TAT relative to now. It adds requests
+-- counted by hybrid limiter since last sync, so gateways learn about requests of each other without
+-- comparing clocks, and charges actual cost reported by providers.
+-- ARGV holds emission interval in seconds and count of tokens for every key.
+redis.replicate_commands()
+
+local jan_1_2017 = 1483228800
+local now = redis.call("TIME")
+now = (now[1] - jan_1_2017) + (now[2] / 1000000)
+
+local result = {}
+
+for i, key in ipairs(KEYS) do
+  local emission_interval = tonumber(ARGV[2 * i - 1])
+  local count = tonumber(ARGV[2 * i])
+
+  local tat = redis.call("GET", key)
+  if not tat then
+    tat = now
+  else
+    tat = tonumber(tat)
+  end
+
+  tat = math.max(tat, now) + emission_interval * count
+
+  redis.call("SET", key, tat, "EX", math.ceil(tat - now))
+  result[i] = tostring(tat - now)
+end
+
+return result
@@ -0,0 +1,66 @@
+-- GCRA of multiple keys as in github.com/go-redis/redis_rate. Request is allowed only when all keys allow it,
+-- otherwise no key is updated and the longest retry after is returned. Remaining tokens and time until
+-- key is full again are returned for every key.
+-- ARGV holds burst, rate, period in seconds and cost of request for every key.
+redis.replicate_commands()
+
+local jan_1_2017 = 1483228800
+local now = redis.call("TIME")
+now = (now[1] - jan_1_2017) + (now[2] / 1000000)
+
+local allowed = 1
+local retry_after = 0
+local tats = {}
+local new_tats = {}
+local emission_intervals = {}
+local burst_offsets = {}
+
+for i, key in ipairs(KEYS) do
+  local burst = tonumber(ARGV[4 * i - 3])
+  local rate = tonumber(ARGV[4 * i - 2])
+  local period = tonumber(ARGV[4 * i - 1])
+  local cost = tonumber(ARGV[4 * i])
+
+  local emission_interval = period / rate
+  local burst_offset = emission_interval * burst
+
+  local tat = redis.call("GET", key)
+  if not tat then
+    tat = now
+  else
+    tat = tonumber(tat)
+  end
+
+  tat = math.max(tat, now)
+
+  local new_tat = tat + emission_interval * cost
+  local diff = now - (new_tat - burst_offset)
+
+  if diff < 0 then
+    allowed = 0
+    retry_after = math.max(retry_after, -diff)
+  end
+
+  tats[i] = tat
+  new_tats[i] = new_tat
+  emission_intervals[i] = emission_interval
+  burst_offsets[i] = burst_offset
+end
+
+local remaining = {}
+local reset = {}
+
+for i, key in ipairs(KEYS) do
+  local tat = tats[i]
+
+  if allowed == 1 then
+    tat = new_tats[i]
+    redis.call("SET", key, tat, "EX", math.ceil(tat - now))
+  end
+
+  -- epsilon keeps whole tokens whole despite float arithmetic.
+  remaining[i] = math.max(0, math.floor((burst_offsets[i] - (tat - now)) / emission_intervals[i] + 1e-9))
+  reset[i] = tostring(tat - now)
+end
+
+return {allowed, tostring(retry_after), remaining, reset}
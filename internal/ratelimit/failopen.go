@@ -0,0 +1,37 @@
+package ratelimit
+
+import (
+	"context"
+	"log/slog"
+)
+
+type failOpenLimiter struct {
+	limiter Limiter
+}
+
+// WithFailOpen returns Limiter that allows requests when limiter fails, e.g. when Redis is unreachable.
+func WithFailOpen(limiter Limiter) Limiter {
+	return &failOpenLimiter{
+		limiter: limiter,
+	}
+}
+
+func (f *failOpenLimiter) Allow(ctx context.Context, requests []Request) (Result, error) {
+	result, err := f.limiter.Allow(ctx, requests)
+	if err != nil {
+		failOpenAllowed.Inc()
+		slog.Warn("Rate limiter failed, request is allowed", slog.String("err", err.Error()))
+
+		return Result{Allowed: true}, nil
+	}
+
+	return result, nil
+}
+
+func (f *failOpenLimiter) Charge(ctx context.Context, requests []Request) error {
+	return f.limiter.Charge(ctx, requests)
+}
+
+func (f *failOpenLimiter) Refund(ctx context.Context, requests []Request) error {
+	return f.limiter.Refund(ctx, requests)
+}
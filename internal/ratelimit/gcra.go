@@ -0,0 +1,60 @@
+package ratelimit
+
+import (
+	"math"
+	"time"
+)
+
+// gcra returns new theoretical arrival time of key with limit, request is allowed when retryAfter is zero.
+func gcra(now, tat time.Time, limit Limit, cost uint64) (newTAT time.Time, retryAfter time.Duration) {
+	if limit.Rate == 0 {
+		return tat, limit.Period
+	}
+
+	emissionInterval := limit.emissionInterval()
+	burstOffset := emissionInterval * time.Duration(limit.Burst)
+
+	if tat.Before(now) {
+		tat = now
+	}
+
+	newTAT = tat.Add(tokensDuration(emissionInterval, cost))
+	if allowAt := newTAT.Add(-burstOffset); now.Before(allowAt) {
+		return tat, allowAt.Sub(now)
+	}
+
+	return newTAT, 0
+}
+
+// tokensDuration returns time taken by count tokens, it saturates so huge costs can't wrap to negative.
+func tokensDuration(emissionInterval time.Duration, count uint64) time.Duration {
+	if emissionInterval > 0 && count > uint64(math.MaxInt64/emissionInterval) {
+		return math.MaxInt64
+	}
+
+	return emissionInterval * time.Duration(count) //nolint:gosec
+}
+
+func (l Limit) emissionInterval() time.Duration {
+	if l.Rate == 0 {
+		return l.Period
+	}
+
+	return l.Period / time.Duration(l.Rate)
+}
+
+// status returns remaining tokens of key with theoretical arrival time and time until key is full again.
+func status(now, tat time.Time, limit Limit) Status {
+	if tat.Before(now) {
+		tat = now
+	}
+
+	result := Status{Limit: limit, Reset: tat.Sub(now)}
+
+	if emissionInterval := limit.emissionInterval(); emissionInterval > 0 {
+		available := emissionInterval*time.Duration(limit.Burst) - result.Reset
+		result.Remaining = uint64(max(available/emissionInterval, 0))
+	}
+
+	return result
+}
@@ -0,0 +1,178 @@
+package ratelimit
+
+import (
+	"context"
+	_ "embed"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// addScript adds tokens to keys of Redis without checking limits.
+//
+//go:embed add.lua
+var addScript string
+
+var add = redis.NewScript(addScript)
+
+// pendingSync is a count of tokens taken locally since last sync.
+type pendingSync struct {
+	emissionInterval time.Duration
+	count            uint64
+}
+
+type hybridLimiter struct {
+	*memoryLimiter
+	client *redis.Client
+
+	// pending is guarded by mutex of memoryLimiter.
+	pending map[string]*pendingSync
+}
+
+// HybridOptions ...
+type HybridOptions struct {
+	Client     *redis.Client
+	SyncPeriod time.Duration
+}
+
+// NewHybrid returns new Limiter that checks limits in memory and adds counted requests to Redis every sync period.
+// Gateways may together exceed limit by requests counted during one sync period, but Redis is not called per request.
+func NewHybrid(ctx context.Context, opts HybridOptions) Limiter {
+	h := &hybridLimiter{
+		memoryLimiter: newMemory(time.Now),
+		client:        opts.Client,
+		pending:       make(map[string]*pendingSync),
+	}
+
+	go func() {
+		ticker := time.NewTicker(opts.SyncPeriod)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := h.sync(ctx); err != nil {
+					syncFailures.Inc()
+					slog.Warn("Failed to sync rate limits", slog.String("err", err.Error()))
+				}
+			}
+		}
+	}()
+
+	return h
+}
+
+func (h *hybridLimiter) Allow(ctx context.Context, requests []Request) (Result, error) {
+	result, err := h.memoryLimiter.Allow(ctx, requests)
+	if err != nil || !result.Allowed {
+		return result, err
+	}
+
+	h.addPending(requests)
+
+	return result, nil
+}
+
+func (h *hybridLimiter) Charge(ctx context.Context, requests []Request) error {
+	if err := h.memoryLimiter.Charge(ctx, requests); err != nil {
+		return err
+	}
+
+	h.addPending(requests)
+
+	return nil
+}
+
+// Refund returns tokens to memory and takes them from counts pending sync. Tokens synced to Redis already
+// are not returned there, so other gateways count them until they are emitted.
+func (h *hybridLimiter) Refund(ctx context.Context, requests []Request) error {
+	if err := h.memoryLimiter.Refund(ctx, requests); err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, request := range requests {
+		if p, ok := h.pending[request.Key.string()]; ok {
+			p.count -= min(p.count, request.cost())
+		}
+	}
+
+	return nil
+}
+
+func (h *hybridLimiter) addPending(requests []Request) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, request := range requests {
+		key := request.Key.string()
+
+		p, ok := h.pending[key]
+		if !ok {
+			p = &pendingSync{emissionInterval: request.Limit.emissionInterval()}
+			h.pending[key] = p
+		}
+
+		p.count += request.cost()
+	}
+}
+
+func (h *hybridLimiter) sync(ctx context.Context) error {
+	h.mu.Lock()
+	pending := h.pending
+	h.pending = make(map[string]*pendingSync, len(pending))
+	h.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(pending))
+	args := make([]any, 0, 2*len(pending))
+
+	for key, p := range pending {
+		keys = append(keys, key)
+		args = append(args, p.emissionInterval.Seconds(), p.count)
+	}
+
+	start := time.Now()
+
+	values, err := add.Run(ctx, h.client, keys, args...).StringSlice()
+	if err != nil {
+		h.restore(pending)
+		return fmt.Errorf("redis limiter sync: %w", err)
+	}
+
+	for i, key := range keys {
+		offset, err := strconv.ParseFloat(values[i], 64)
+		if err != nil {
+			return fmt.Errorf("redis limiter sync: %w", err)
+		}
+
+		h.advance(key, start.Add(time.Duration(offset*float64(time.Second))))
+	}
+
+	return nil
+}
+
+// restore returns counts of failed sync to be added by next sync.
+func (h *hybridLimiter) restore(pending map[string]*pendingSync) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for key, p := range pending {
+		if current, ok := h.pending[key]; ok {
+			current.count += p.count
+			continue
+		}
+
+		h.pending[key] = p
+	}
+}
@@ -0,0 +1,88 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHybridSync(t *testing.T) {
+	t.Parallel()
+
+	srv := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	// sync is called by test.
+	first := NewHybrid(ctx, HybridOptions{Client: client, SyncPeriod: time.Hour}).(*hybridLimiter)
+	second := NewHybrid(ctx, HybridOptions{Client: client, SyncPeriod: time.Hour}).(*hybridLimiter)
+
+	perHour := Request{
+		Key:   Key{Service: "svc", IsServiceLimiter: true, Limiter: "global:1h0m0s", Entity: "global"},
+		Limit: Limit{Rate: 4, Burst: 4, Period: time.Hour},
+	}
+
+	for i := 0; i < 3; i++ {
+		result, err := first.Allow(ctx, []Request{perHour})
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	}
+
+	require.NoError(t, first.sync(ctx))
+	assert.Empty(t, first.pending)
+
+	// second gateway learns about requests of first one when it syncs own request.
+	result, err := second.Allow(ctx, []Request{perHour})
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	require.NoError(t, second.sync(ctx))
+
+	result, err = second.Allow(ctx, []Request{perHour})
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+
+	result, err = first.Allow(ctx, []Request{perHour})
+	require.NoError(t, err)
+	assert.True(t, result.Allowed, "first gateway hasn't synced yet")
+
+	require.NoError(t, first.sync(ctx))
+
+	result, err = first.Allow(ctx, []Request{perHour})
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+}
+
+func TestHybridRefund(t *testing.T) {
+	t.Parallel()
+
+	srv := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: srv.Addr()})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	limiter := NewHybrid(ctx, HybridOptions{Client: client, SyncPeriod: time.Hour}).(*hybridLimiter)
+
+	perHour := Request{
+		Key:   Key{Service: "svc", IsServiceLimiter: true, Limiter: "global:1h0m0s", Entity: "global"},
+		Limit: Limit{Rate: 4, Burst: 4, Period: time.Hour},
+	}
+
+	for i := 0; i < 2; i++ {
+		result, err := limiter.Allow(ctx, []Request{perHour})
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	}
+
+	// refunded request is not synced to other gateways.
+	require.NoError(t, limiter.Refund(ctx, []Request{perHour}))
+	assert.Equal(t, uint64(1), limiter.pending[perHour.Key.string()].count)
+}
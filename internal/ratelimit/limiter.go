@@ -11,15 +11,17 @@ type Key struct {
 	Service          string
 	IsServiceLimiter bool
 	Method           string
-	Entity           string
+	// Limiter identifies limiter among limiters of one scope.
+	Limiter string
+	Entity  string
 }
 
 func (k Key) string() string {
 	if k.IsServiceLimiter {
-		return fmt.Sprintf("lim_%s:%s", k.Service, k.Entity)
+		return fmt.Sprintf("lim_%s:%s:%s", k.Service, k.Limiter, k.Entity)
 	}
 
-	return fmt.Sprintf("lim_%s:%s:%s", k.Service, k.Method, k.Entity)
+	return fmt.Sprintf("lim_%s:%s:%s:%s", k.Service, k.Method, k.Limiter, k.Entity)
 }
 
 // Limit ...
@@ -29,7 +31,41 @@ type Limit struct {
 	Period time.Duration
 }
 
+// Request is a key to check against its limit.
+type Request struct {
+	Key   Key
+	Limit Limit
+	// Cost is a count of tokens taken by request, 1 when zero.
+	Cost uint64
+}
+
+func (r Request) cost() uint64 {
+	return max(r.Cost, 1)
+}
+
+// Result ...
+type Result struct {
+	Allowed bool
+	// RetryAfter is the time after which all denying limits allow request.
+	RetryAfter time.Duration
+	// Statuses of requests in the same order, empty when limiter can't report them.
+	Statuses []Status
+}
+
+// Status of limit after request.
+type Status struct {
+	Limit     Limit
+	Remaining uint64
+	// Reset is the time until all tokens of limit are available again.
+	Reset time.Duration
+}
+
 // Limiter ...
 type Limiter interface {
-	Allow(ctx context.Context, key Key, limit Limit) (allowed bool, retryAfter time.Duration, err error)
+	// Allow checks all requests atomically, request is counted by no limit unless all of them allow it.
+	Allow(ctx context.Context, requests []Request) (Result, error)
+	// Charge takes cost of requests from limits without checking them, e.g. actual cost reported after processing.
+	Charge(ctx context.Context, requests []Request) error
+	// Refund returns cost of allowed requests to limits, e.g. when request is shed before reaching provider.
+	Refund(ctx context.Context, requests []Request) error
 }
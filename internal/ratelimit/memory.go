@@ -0,0 +1,133 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+const memorySweepPeriod = time.Minute
+
+type memoryLimiter struct {
+	mu        sync.Mutex
+	now       func() time.Time
+	tats      map[string]time.Time
+	nextSweep time.Time
+}
+
+// NewMemory returns new Limiter that keeps limits in memory of process, so limits are not shared between gateways.
+func NewMemory() Limiter {
+	return newMemory(time.Now)
+}
+
+func newMemory(now func() time.Time) *memoryLimiter {
+	return &memoryLimiter{
+		now:  now,
+		tats: make(map[string]time.Time),
+	}
+}
+
+func (m *memoryLimiter) Allow(_ context.Context, requests []Request) (Result, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	m.sweep(now)
+
+	var result Result
+
+	newTATs := make([]time.Time, len(requests))
+
+	for i, request := range requests {
+		newTAT, retryAfter := gcra(now, m.tats[request.Key.string()], request.Limit, request.cost())
+		result.RetryAfter = max(result.RetryAfter, retryAfter)
+		newTATs[i] = newTAT
+	}
+
+	result.Allowed = result.RetryAfter == 0
+	result.Statuses = make([]Status, 0, len(requests))
+
+	for i, request := range requests {
+		key := request.Key.string()
+
+		if result.Allowed {
+			m.tats[key] = newTATs[i]
+		}
+
+		result.Statuses = append(result.Statuses, status(now, m.tats[key], request.Limit))
+	}
+
+	return result, nil
+}
+
+func (m *memoryLimiter) Charge(_ context.Context, requests []Request) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+
+	for _, request := range requests {
+		key := request.Key.string()
+		m.tats[key] = charge(now, m.tats[key], request)
+	}
+
+	return nil
+}
+
+func (m *memoryLimiter) Refund(_ context.Context, requests []Request) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+
+	for _, request := range requests {
+		key := request.Key.string()
+
+		tat, ok := m.tats[key]
+		if !ok {
+			continue
+		}
+
+		if tat = tat.Add(-tokensDuration(request.Limit.emissionInterval(), request.cost())); tat.After(now) {
+			m.tats[key] = tat
+		} else {
+			delete(m.tats, key)
+		}
+	}
+
+	return nil
+}
+
+// charge returns theoretical arrival time with cost of request added regardless of limit.
+func charge(now, tat time.Time, request Request) time.Time {
+	if tat.Before(now) {
+		tat = now
+	}
+
+	return tat.Add(tokensDuration(request.Limit.emissionInterval(), request.cost()))
+}
+
+// advance moves theoretical arrival time of key forward to tat, e.g. when other gateways counted requests.
+func (m *memoryLimiter) advance(key string, tat time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if tat.After(m.tats[key]) {
+		m.tats[key] = tat
+	}
+}
+
+// sweep removes keys with theoretical arrival time in the past, they are equal to missing keys.
+func (m *memoryLimiter) sweep(now time.Time) {
+	if now.Before(m.nextSweep) {
+		return
+	}
+
+	for key, tat := range m.tats {
+		if !tat.After(now) {
+			delete(m.tats, key)
+		}
+	}
+
+	m.nextSweep = now.Add(memorySweepPeriod)
+}
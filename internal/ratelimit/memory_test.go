@@ -0,0 +1,138 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryAllowStacked(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	limiter := newMemory(func() time.Time { return now })
+	ctx := context.Background()
+
+	perSecond := Request{
+		Key:   Key{Service: "svc", IsServiceLimiter: true, Limiter: "ip:1s", Entity: "1.1.1.1"},
+		Limit: Limit{Rate: 10, Burst: 10, Period: time.Second},
+	}
+	perHour := Request{
+		Key:   Key{Service: "svc", Method: "hello", Limiter: "ip:1h0m0s", Entity: "1.1.1.1"},
+		Limit: Limit{Rate: 2, Burst: 2, Period: time.Hour},
+	}
+
+	for i := 0; i < 2; i++ {
+		result, err := limiter.Allow(ctx, []Request{perSecond, perHour})
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	}
+
+	result, err := limiter.Allow(ctx, []Request{perSecond, perHour})
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 30*time.Minute, result.RetryAfter)
+
+	// denied request isn't counted by per second limit.
+	for i := 0; i < 8; i++ {
+		result, err = limiter.Allow(ctx, []Request{perSecond})
+		require.NoError(t, err)
+		assert.True(t, result.Allowed, i)
+	}
+
+	result, err = limiter.Allow(ctx, []Request{perSecond})
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 100*time.Millisecond, result.RetryAfter)
+
+	now = now.Add(100 * time.Millisecond)
+
+	result, err = limiter.Allow(ctx, []Request{perSecond})
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+}
+
+func TestMemoryCost(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	limiter := newMemory(func() time.Time { return now })
+	ctx := context.Background()
+
+	request := Request{
+		Key:   Key{Service: "svc", Method: "report", Limiter: "subject_id:1m0s", Entity: "user"},
+		Limit: Limit{Rate: 10, Burst: 10, Period: time.Minute},
+		Cost:  6,
+	}
+
+	result, err := limiter.Allow(ctx, []Request{request})
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, []Status{{Limit: request.Limit, Remaining: 4, Reset: 36 * time.Second}}, result.Statuses)
+
+	// 4 tokens are left, so expensive request waits for 2 of them.
+	result, err = limiter.Allow(ctx, []Request{request})
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 12*time.Second, result.RetryAfter)
+
+	// provider reported actual cost 10, so extra 4 tokens are charged.
+	request.Cost = 4
+	require.NoError(t, limiter.Charge(ctx, []Request{request}))
+
+	request.Cost = 1
+	result, err = limiter.Allow(ctx, []Request{request})
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Equal(t, 6*time.Second, result.RetryAfter)
+}
+
+func TestMemoryRefund(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	limiter := newMemory(func() time.Time { return now })
+	ctx := context.Background()
+
+	request := Request{
+		Key:   Key{Service: "svc", Method: "report", Limiter: "subject_id:1m0s", Entity: "user"},
+		Limit: Limit{Rate: 10, Burst: 10, Period: time.Minute},
+		Cost:  10,
+	}
+
+	result, err := limiter.Allow(ctx, []Request{request})
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+
+	request.Cost = 4
+	require.NoError(t, limiter.Refund(ctx, []Request{request}))
+
+	result, err = limiter.Allow(ctx, []Request{request})
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, uint64(0), result.Statuses[0].Remaining)
+
+	// refund can't leave more tokens than burst.
+	request.Cost = 100
+	require.NoError(t, limiter.Refund(ctx, []Request{request}))
+	assert.NotContains(t, limiter.tats, request.Key.string())
+}
+
+func TestMemoryHugeCost(t *testing.T) {
+	t.Parallel()
+
+	now := time.Unix(1700000000, 0)
+	limiter := newMemory(func() time.Time { return now })
+
+	result, err := limiter.Allow(context.Background(), []Request{{
+		Key:   Key{Service: "svc", Method: "report", Limiter: "subject_id:24h0m0s", Entity: "user"},
+		Limit: Limit{Rate: 1, Burst: 1, Period: 24 * time.Hour},
+		Cost:  1 << 63,
+	}})
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Positive(t, result.RetryAfter)
+}
@@ -0,0 +1,22 @@
+package ratelimit
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	failOpenAllowed = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "rate_limiter_fail_open_count",
+			Help: "The total number of requests allowed without rate limit check because limiter failed",
+		},
+	)
+
+	syncFailures = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "rate_limiter_sync_failures_count",
+			Help: "The total number of failed syncs of hybrid rate limiter with Redis",
+		},
+	)
+)
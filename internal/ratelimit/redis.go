@@ -2,33 +2,123 @@ package ratelimit
 
 import (
 	"context"
+	_ "embed"
 	"fmt"
+	"strconv"
 	"time"
 
-	"github.com/go-redis/redis_rate/v10"
 	"github.com/redis/go-redis/v9"
 )
 
+// allowScript is GCRA of all keys, keys are updated only when all of them allow request.
+//
+//go:embed allow.lua
+var allowScript string
+
+var allow = redis.NewScript(allowScript)
+
+// refundScript takes tokens back from keys of allowed requests.
+//
+//go:embed refund.lua
+var refundScript string
+
+var refund = redis.NewScript(refundScript)
+
 type redisLimiter struct {
-	limiter *redis_rate.Limiter
+	client *redis.Client
 }
 
-// NewRedis returns new Limiter.
+// NewRedis returns new Limiter. All keys are checked by one script, so Redis must not be a cluster.
 func NewRedis(client *redis.Client) Limiter {
 	return &redisLimiter{
-		limiter: redis_rate.NewLimiter(client),
+		client: client,
 	}
 }
 
-func (r *redisLimiter) Allow(ctx context.Context, key Key, limit Limit) (bool, time.Duration, error) {
-	res, err := r.limiter.Allow(ctx, key.string(), redis_rate.Limit{
-		Rate:   int(limit.Rate),
-		Burst:  int(limit.Burst),
-		Period: limit.Period,
-	})
+func (r *redisLimiter) Allow(ctx context.Context, requests []Request) (Result, error) {
+	if len(requests) == 0 {
+		return Result{Allowed: true}, nil
+	}
+
+	keys := make([]string, 0, len(requests))
+	args := make([]any, 0, 4*len(requests))
+
+	for _, request := range requests {
+		keys = append(keys, request.Key.string())
+		args = append(args, request.Limit.Burst, request.Limit.Rate, request.Limit.Period.Seconds(), request.cost())
+	}
+
+	values, err := allow.Run(ctx, r.client, keys, args...).Slice()
 	if err != nil {
-		return false, time.Duration(0), fmt.Errorf("redis limiter: %w", err)
+		return Result{}, fmt.Errorf("redis limiter: %w", err)
+	}
+
+	retryAfter, err := strconv.ParseFloat(values[1].(string), 64) //nolint:forcetypeassert
+	if err != nil {
+		return Result{}, fmt.Errorf("redis limiter: %w", err)
+	}
+
+	result := Result{
+		Allowed:    values[0].(int64) == 1, //nolint:forcetypeassert
+		RetryAfter: time.Duration(retryAfter * float64(time.Second)),
+		Statuses:   make([]Status, 0, len(requests)),
+	}
+
+	remaining := values[2].([]any) //nolint:forcetypeassert
+	reset := values[3].([]any)     //nolint:forcetypeassert
+
+	for i, request := range requests {
+		resetSeconds, err := strconv.ParseFloat(reset[i].(string), 64) //nolint:forcetypeassert
+		if err != nil {
+			return Result{}, fmt.Errorf("redis limiter: %w", err)
+		}
+
+		result.Statuses = append(result.Statuses, Status{
+			Limit:     request.Limit,
+			Remaining: uint64(remaining[i].(int64)), //nolint:forcetypeassert
+			Reset:     time.Duration(resetSeconds * float64(time.Second)),
+		})
+	}
+
+	return result, nil
+}
+
+func (r *redisLimiter) Charge(ctx context.Context, requests []Request) error {
+	if len(requests) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(requests))
+	args := make([]any, 0, 2*len(requests))
+
+	for _, request := range requests {
+		keys = append(keys, request.Key.string())
+		args = append(args, request.Limit.emissionInterval().Seconds(), request.cost())
+	}
+
+	if err := add.Run(ctx, r.client, keys, args...).Err(); err != nil {
+		return fmt.Errorf("redis limiter: %w", err)
+	}
+
+	return nil
+}
+
+func (r *redisLimiter) Refund(ctx context.Context, requests []Request) error {
+	if len(requests) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(requests))
+	args := make([]any, 0, 2*len(requests))
+
+	for _, request := range requests {
+		keys = append(keys, request.Key.string())
+		args = append(args, request.Limit.emissionInterval().Seconds(), request.cost())
+	}
+
+	if err := refund.Run(ctx, r.client, keys, args...).Err(); err != nil {
+		return fmt.Errorf("redis limiter: %w", err)
 	}
 
-	return res.RetryAfter == -1, res.RetryAfter, nil
+	return nil
 }
@@ -0,0 +1,91 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisAllowStacked(t *testing.T) {
+	t.Parallel()
+
+	srv := miniredis.RunT(t)
+	// time is frozen, so limits are not refilled during test.
+	srv.SetTime(time.Unix(time.Now().Unix(), 0))
+	limiter := NewRedis(redis.NewClient(&redis.Options{Addr: srv.Addr()}))
+	ctx := context.Background()
+
+	perSecond := Request{
+		Key:   Key{Service: "svc", IsServiceLimiter: true, Limiter: "ip:1s", Entity: "1.1.1.1"},
+		Limit: Limit{Rate: 8, Burst: 8, Period: time.Second},
+	}
+	perHour := Request{
+		Key:   Key{Service: "svc", Method: "hello", Limiter: "ip:1h0m0s", Entity: "1.1.1.1"},
+		Limit: Limit{Rate: 2, Burst: 2, Period: time.Hour},
+	}
+
+	for i := 0; i < 2; i++ {
+		result, err := limiter.Allow(ctx, []Request{perSecond, perHour})
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+		require.Len(t, result.Statuses, 2)
+		assert.Equal(t, uint64(7-i), result.Statuses[0].Remaining)
+		assert.Equal(t, uint64(1-i), result.Statuses[1].Remaining)
+		assert.Equal(t, time.Duration(i+1)*30*time.Minute, result.Statuses[1].Reset)
+	}
+
+	// hour limit is exhausted, so request is denied with the longest retry after.
+	result, err := limiter.Allow(ctx, []Request{perSecond, perHour})
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+	assert.Greater(t, result.RetryAfter, 20*time.Minute)
+
+	// denied request isn't counted by per second limit, it still has 6 requests.
+	for i := 0; i < 6; i++ {
+		result, err = limiter.Allow(ctx, []Request{perSecond})
+		require.NoError(t, err)
+		assert.True(t, result.Allowed, i)
+	}
+
+	result, err = limiter.Allow(ctx, []Request{perSecond})
+	require.NoError(t, err)
+	assert.False(t, result.Allowed)
+}
+
+func TestRedisRefund(t *testing.T) {
+	t.Parallel()
+
+	srv := miniredis.RunT(t)
+	srv.SetTime(time.Unix(time.Now().Unix(), 0))
+	limiter := NewRedis(redis.NewClient(&redis.Options{Addr: srv.Addr()}))
+	ctx := context.Background()
+
+	request := Request{
+		Key:   Key{Service: "svc", Method: "hello", Limiter: "ip:1h0m0s", Entity: "1.1.1.1"},
+		Limit: Limit{Rate: 2, Burst: 2, Period: time.Hour},
+	}
+
+	for i := 0; i < 2; i++ {
+		result, err := limiter.Allow(ctx, []Request{request})
+		require.NoError(t, err)
+		assert.True(t, result.Allowed)
+	}
+
+	// shed request returns its token, so the next one is allowed.
+	require.NoError(t, limiter.Refund(ctx, []Request{request}))
+
+	result, err := limiter.Allow(ctx, []Request{request})
+	require.NoError(t, err)
+	assert.True(t, result.Allowed)
+	assert.Equal(t, uint64(0), result.Statuses[0].Remaining)
+
+	// refund of the whole window removes key.
+	request.Cost = 10
+	require.NoError(t, limiter.Refund(ctx, []Request{request}))
+	assert.False(t, srv.Exists(request.Key.string()))
+}
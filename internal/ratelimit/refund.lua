@@ -0,0 +1,23 @@
+-- Takes tokens back from TAT of keys, e.g. when allowed request was shed before reaching provider.
+-- Keys without TAT are full already and are left as is.
+-- ARGV holds emission interval in seconds and count of tokens for every key.
+redis.replicate_commands()
+
+local jan_1_2017 = 1483228800
+local now = redis.call("TIME")
+now = (now[1] - jan_1_2017) + (now[2] / 1000000)
+
+for i, key in ipairs(KEYS) do
+  local tat = redis.call("GET", key)
+  if tat then
+    tat = tonumber(tat) - tonumber(ARGV[2 * i - 1]) * tonumber(ARGV[2 * i])
+
+    if tat > now then
+      redis.call("SET", key, tat, "EX", math.ceil(tat - now))
+    else
+      redis.call("DEL", key)
+    end
+  end
+end
+
+return 0
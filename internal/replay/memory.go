@@ -0,0 +1,41 @@
+package replay
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+type memoryCache struct {
+	mu        sync.Mutex
+	expiresAt map[string]time.Time
+}
+
+// NewMemory returns new Cache stored in memory of process, so replays to other gateways are not detected.
+func NewMemory() Cache {
+	return &memoryCache{
+		expiresAt: make(map[string]time.Time),
+	}
+}
+
+func (m *memoryCache) Remember(_ context.Context, key string, ttl time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+
+	if expiresAt, ok := m.expiresAt[key]; ok && now.Before(expiresAt) {
+		return false, nil
+	}
+
+	// keys are swept on insert, proofs live for minutes, so map stays small.
+	for k, expiresAt := range m.expiresAt {
+		if !now.Before(expiresAt) {
+			delete(m.expiresAt, k)
+		}
+	}
+
+	m.expiresAt[key] = now.Add(ttl)
+
+	return true, nil
+}
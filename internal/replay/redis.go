@@ -0,0 +1,35 @@
+package replay
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Cache remembers keys to detect replays.
+type Cache interface {
+	// Remember returns false if key was already remembered and not expired.
+	Remember(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+type redisCache struct {
+	client *redis.Client
+}
+
+// NewRedis returns new Cache stored in redis.
+func NewRedis(client *redis.Client) Cache {
+	return &redisCache{
+		client: client,
+	}
+}
+
+func (r *redisCache) Remember(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	firstUse, err := r.client.SetNX(ctx, "replay_"+key, 1, ttl).Result()
+	if err != nil {
+		return false, fmt.Errorf("redis replay cache: %w", err)
+	}
+
+	return firstUse, nil
+}
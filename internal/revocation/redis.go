@@ -0,0 +1,129 @@
+package revocation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+const (
+	keyPrefix = "revoked_"
+	scanCount = 100
+)
+
+type redisList struct {
+	client *redis.Client
+}
+
+// NewRedis returns new List stored in redis.
+func NewRedis(client *redis.Client) List {
+	return &redisList{
+		client: client,
+	}
+}
+
+func key(kind Kind, id string) string {
+	return fmt.Sprintf("%s%s:%s", keyPrefix, kind.String(), id)
+}
+
+func (r *redisList) IsRevoked(ctx context.Context, subject *domain.SubjectInformation) (bool, error) {
+	keys := []string{key(KindSubject, subject.ID)}
+	if subject.TokenID != "" {
+		keys = append(keys, key(KindToken, subject.TokenID))
+	}
+
+	res, err := r.client.Exists(ctx, keys...).Result()
+	if err != nil {
+		return false, fmt.Errorf("redis revocation list: %w", err)
+	}
+
+	return res != 0, nil
+}
+
+var (
+	errEmptyID = errors.New("id cannot be empty")
+)
+
+// Revoke entity, zero ttl means that entity revoked until restored.
+func (r *redisList) Revoke(ctx context.Context, kind Kind, id string, ttl time.Duration) error {
+	if id == "" {
+		return errEmptyID
+	}
+
+	if err := r.client.Set(ctx, key(kind, id), time.Now().Unix(), ttl).Err(); err != nil {
+		return fmt.Errorf("redis revocation list: %w", err)
+	}
+
+	return nil
+}
+
+func (r *redisList) Restore(ctx context.Context, kind Kind, id string) error {
+	if err := r.client.Del(ctx, key(kind, id)).Err(); err != nil {
+		return fmt.Errorf("redis revocation list: %w", err)
+	}
+
+	return nil
+}
+
+func (r *redisList) Entries(ctx context.Context) ([]Entry, error) {
+	var entries []Entry
+
+	iter := r.client.Scan(ctx, 0, keyPrefix+"*", scanCount).Iterator()
+	for iter.Next(ctx) {
+		entry, ok := r.entry(ctx, iter.Val())
+		if !ok {
+			continue
+		}
+
+		entries = append(entries, entry)
+	}
+
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("redis revocation list: %w", err)
+	}
+
+	return entries, nil
+}
+
+func (r *redisList) entry(ctx context.Context, redisKey string) (Entry, bool) {
+	kindName, id, ok := strings.Cut(strings.TrimPrefix(redisKey, keyPrefix), ":")
+	if !ok {
+		return Entry{}, false
+	}
+
+	kind, err := ParseKind(kindName)
+	if err != nil {
+		return Entry{}, false
+	}
+
+	pipe := r.client.Pipeline()
+	getCmd := pipe.Get(ctx, redisKey)
+	ttlCmd := pipe.TTL(ctx, redisKey)
+
+	if _, err = pipe.Exec(ctx); err != nil {
+		// entry can expire between scan and get.
+		return Entry{}, false
+	}
+
+	entry := Entry{
+		Kind: kind,
+		ID:   id,
+	}
+
+	if revokedAt, err := strconv.ParseInt(getCmd.Val(), 10, 64); err == nil {
+		entry.RevokedAt = time.Unix(revokedAt, 0)
+	}
+
+	if ttl := ttlCmd.Val(); ttl > 0 {
+		entry.ExpiresAt = time.Now().Add(ttl)
+	}
+
+	return entry, true
+}
@@ -0,0 +1,72 @@
+package revocation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+func TestRedisRevoke(t *testing.T) {
+	t.Parallel()
+
+	srv := miniredis.RunT(t)
+	list := NewRedis(redis.NewClient(&redis.Options{Addr: srv.Addr()}))
+	ctx := context.Background()
+
+	token := &domain.SubjectInformation{ID: "user-1", TokenID: "jti-1"}
+	otherToken := &domain.SubjectInformation{ID: "user-1", TokenID: "jti-2"}
+	otherSubject := &domain.SubjectInformation{ID: "user-2", TokenID: "jti-3"}
+
+	require.NoError(t, list.Revoke(ctx, KindToken, "jti-1", time.Minute))
+
+	revoked, err := list.IsRevoked(ctx, token)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	revoked, err = list.IsRevoked(ctx, otherToken)
+	require.NoError(t, err)
+	assert.False(t, revoked, "only token with revoked jti is rejected")
+
+	// subject revocation rejects all tokens of subject until restored.
+	require.NoError(t, list.Revoke(ctx, KindSubject, "user-2", 0))
+
+	revoked, err = list.IsRevoked(ctx, otherSubject)
+	require.NoError(t, err)
+	assert.True(t, revoked)
+
+	entries, err := list.Entries(ctx)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	for _, entry := range entries {
+		switch entry.Kind {
+		case KindToken:
+			assert.Equal(t, "jti-1", entry.ID)
+			assert.False(t, entry.ExpiresAt.IsZero())
+		case KindSubject:
+			assert.Equal(t, "user-2", entry.ID)
+			assert.True(t, entry.ExpiresAt.IsZero())
+		}
+	}
+
+	srv.FastForward(2 * time.Minute)
+
+	revoked, err = list.IsRevoked(ctx, token)
+	require.NoError(t, err)
+	assert.False(t, revoked, "token revocation expires with ttl")
+
+	require.NoError(t, list.Restore(ctx, KindSubject, "user-2"))
+
+	revoked, err = list.IsRevoked(ctx, otherSubject)
+	require.NoError(t, err)
+	assert.False(t, revoked)
+
+	require.ErrorIs(t, list.Revoke(ctx, KindToken, "", 0), errEmptyID)
+}
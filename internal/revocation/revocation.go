@@ -0,0 +1,30 @@
+package revocation
+
+//go:generate go run github.com/abice/go-enum
+
+import (
+	"context"
+	"time"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+// Kind of revoked entity.
+// ENUM(token, subject)
+type Kind uint8
+
+// Entry of revocation list.
+type Entry struct {
+	Kind      Kind
+	ID        string
+	RevokedAt time.Time
+	ExpiresAt time.Time
+}
+
+// List of revoked tokens and subjects.
+type List interface {
+	IsRevoked(ctx context.Context, subject *domain.SubjectInformation) (bool, error)
+	Revoke(ctx context.Context, kind Kind, id string, ttl time.Duration) error
+	Restore(ctx context.Context, kind Kind, id string) error
+	Entries(ctx context.Context) ([]Entry, error)
+}
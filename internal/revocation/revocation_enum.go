@@ -0,0 +1,56 @@
+// Code generated by go-enum DO NOT EDIT.
+// Version:
+// Revision:
+// Build Date:
+// Built By:
+
+package revocation
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	// KindToken is a Kind of type Token.
+	KindToken Kind = iota
+	// KindSubject is a Kind of type Subject.
+	KindSubject
+)
+
+var ErrInvalidKind = errors.New("not a valid Kind")
+
+const _KindName = "tokensubject"
+
+var _KindMap = map[Kind]string{
+	KindToken:   _KindName[0:5],
+	KindSubject: _KindName[5:12],
+}
+
+// String implements the Stringer interface.
+func (x Kind) String() string {
+	if str, ok := _KindMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("Kind(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x Kind) IsValid() bool {
+	_, ok := _KindMap[x]
+	return ok
+}
+
+var _KindValue = map[string]Kind{
+	_KindName[0:5]:  KindToken,
+	_KindName[5:12]: KindSubject,
+}
+
+// ParseKind attempts to convert a string to a Kind.
+func ParseKind(name string) (Kind, error) {
+	if x, ok := _KindValue[name]; ok {
+		return x, nil
+	}
+	return Kind(0), fmt.Errorf("%s is %w", name, ErrInvalidKind)
+}
@@ -0,0 +1,160 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+)
+
+// Schemes of secret references.
+const (
+	SchemeEnv   = "env"
+	SchemeFile  = "file"
+	SchemeVault = "vault"
+)
+
+// reservedSchemes must be resolved by provider, so misconfigured reference is never used as a literal secret.
+var reservedSchemes = []string{SchemeEnv, SchemeFile, SchemeVault}
+
+// Provider resolves secret references of one scheme, ref doesn't contain scheme prefix.
+type Provider interface {
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// Resolver resolves values like env:NAME or file:/run/secrets/name, values without known scheme are returned as is.
+type Resolver struct {
+	providers map[string]Provider
+}
+
+// New returns new Resolver with env and file providers.
+func New() *Resolver {
+	return &Resolver{
+		providers: map[string]Provider{
+			SchemeEnv:  envProvider{},
+			SchemeFile: fileProvider{},
+		},
+	}
+}
+
+// Register provider for scheme.
+func (r *Resolver) Register(scheme string, provider Provider) {
+	r.providers[scheme] = provider
+}
+
+// Resolve returns value of secret reference.
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	scheme, ref, found := strings.Cut(value, ":")
+	if !found {
+		return value, nil
+	}
+
+	provider, ok := r.providers[scheme]
+	if !ok {
+		for _, reserved := range reservedSchemes {
+			if scheme == reserved {
+				return "", fmt.Errorf("secret provider for scheme %s is not configured", scheme)
+			}
+		}
+
+		return value, nil
+	}
+
+	resolved, err := provider.Resolve(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve %s secret: %w", scheme, err)
+	}
+
+	return resolved, nil
+}
+
+var secretType = reflect.TypeOf(domain.Secret(""))
+
+// ResolveAll resolves in place all domain.Secret fields reachable from v, v must be a pointer.
+func (r *Resolver) ResolveAll(ctx context.Context, v any) error {
+	return r.resolveValue(ctx, reflect.ValueOf(v), "")
+}
+
+func (r *Resolver) resolveValue(ctx context.Context, v reflect.Value, path string) error {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if v.IsNil() {
+			return nil
+		}
+
+		return r.resolveValue(ctx, v.Elem(), path)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if !field.IsExported() {
+				continue
+			}
+
+			if err := r.resolveValue(ctx, v.Field(i), joinPath(path, field.Name)); err != nil {
+				return err
+			}
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			if err := r.resolveValue(ctx, v.Index(i), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+	case reflect.String:
+		if v.Type() != secretType || !v.CanSet() {
+			return nil
+		}
+
+		resolved, err := r.Resolve(ctx, v.String())
+		if err != nil {
+			return fmt.Errorf("field %s: %w", path, err)
+		}
+
+		v.SetString(resolved)
+	}
+
+	return nil
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
+
+type envProvider struct{}
+
+func (envProvider) Resolve(_ context.Context, name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+
+	return value, nil
+}
+
+type fileProvider struct{}
+
+var (
+	errEmptyPath = errors.New("empty file path")
+)
+
+func (fileProvider) Resolve(_ context.Context, path string) (string, error) {
+	if path == "" {
+		return "", errEmptyPath
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read secret file: %w", err)
+	}
+
+	// secret files usually end with newline, it is never a part of secret.
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
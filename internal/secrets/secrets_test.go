@@ -0,0 +1,64 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/secrets/vaulttest"
+)
+
+func TestResolveAll(t *testing.T) {
+	t.Setenv("TEST_REDIS_PASSWORD", "redis-password")
+
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	require.NoError(t, os.WriteFile(secretFile, []byte("file-secret\n"), 0o600))
+
+	srv := vaulttest.NewServer("vault-token", map[string]map[string]string{
+		"secret/data/gateway": {"introspection_secret": "vault-secret"},
+	})
+	defer srv.Close()
+
+	resolver := New()
+	resolver.Register(SchemeVault, NewVault(VaultOptions{Address: srv.URL, Token: "vault-token"}))
+
+	cfg := &domain.Config{
+		Auth0ClientSecret: domain.Secret("file:" + secretFile),
+		RedisPassword:     "env:TEST_REDIS_PASSWORD",
+		Introspection: &domain.ConfigIntrospection{
+			ClientSecret: "vault:secret/data/gateway#introspection_secret",
+		},
+		Auth0Domain: "env:NOT_A_SECRET",
+	}
+
+	require.NoError(t, resolver.ResolveAll(context.Background(), cfg))
+	assert.Equal(t, "file-secret", cfg.Auth0ClientSecret.Value())
+	assert.Equal(t, "redis-password", cfg.RedisPassword.Value())
+	assert.Equal(t, "vault-secret", cfg.Introspection.ClientSecret.Value())
+	assert.Equal(t, "env:NOT_A_SECRET", cfg.Auth0Domain, "only secret fields are resolved")
+
+	data, err := json.Marshal(cfg)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "redis-password")
+	assert.NotContains(t, string(data), "vault-secret")
+}
+
+func TestResolve(t *testing.T) {
+	resolver := New()
+
+	value, err := resolver.Resolve(context.Background(), "plain:value")
+	require.NoError(t, err)
+	assert.Equal(t, "plain:value", value)
+
+	_, err = resolver.Resolve(context.Background(), "env:TEST_MISSING_VARIABLE")
+	require.Error(t, err)
+
+	_, err = resolver.Resolve(context.Background(), "vault:secret/data/gateway#field")
+	require.Error(t, err, "vault reference must not be used as literal when vault is not configured")
+}
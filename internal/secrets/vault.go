@@ -0,0 +1,103 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Vault resolves references like path#field from Vault KV v2 secrets engine, e.g. secret/data/gateway#auth0_client_secret.
+type Vault struct {
+	address    string
+	token      string
+	namespace  string
+	httpClient *http.Client
+}
+
+// VaultOptions ...
+type VaultOptions struct {
+	Address    string
+	Token      string
+	Namespace  string
+	HTTPClient *http.Client
+}
+
+func (opts *VaultOptions) setDefaults() {
+	opts.Address = strings.TrimSuffix(opts.Address, "/")
+
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+}
+
+// NewVault returns new Vault provider.
+func NewVault(opts VaultOptions) *Vault {
+	opts.setDefaults()
+
+	return &Vault{
+		address:    opts.Address,
+		token:      opts.Token,
+		namespace:  opts.Namespace,
+		httpClient: opts.HTTPClient,
+	}
+}
+
+type vaultResponse struct {
+	Data struct {
+		Data map[string]any `json:"data"`
+	} `json:"data"`
+}
+
+var (
+	errVaultFieldRequired = errors.New("vault reference must be in path#field format")
+)
+
+// Resolve ...
+func (v *Vault) Resolve(ctx context.Context, ref string) (string, error) {
+	path, field, found := strings.Cut(ref, "#")
+	if !found || path == "" || field == "" {
+		return "", errVaultFieldRequired
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.address+"/v1/"+strings.TrimPrefix(path, "/"), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create new request: %w", err)
+	}
+
+	req.Header.Set("X-Vault-Token", v.token)
+	if v.namespace != "" {
+		req.Header.Set("X-Vault-Namespace", v.namespace)
+	}
+
+	resp, err := v.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to do request: %w", err)
+	}
+
+	defer resp.Body.Close() //nolint:errcheck
+
+	readData, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("got response with unexpected status %d for %s", resp.StatusCode, path)
+	}
+
+	var vaultResp vaultResponse
+	if err = json.Unmarshal(readData, &vaultResp); err != nil {
+		return "", fmt.Errorf("failed to unmarshal vault response: %w", err)
+	}
+
+	value, ok := vaultResp.Data.Data[field].(string)
+	if !ok {
+		return "", fmt.Errorf("field %s not found in %s", field, path)
+	}
+
+	return value, nil
+}
@@ -0,0 +1,38 @@
+// Package vaulttest provides local Vault KV v2 compatible server for tests.
+package vaulttest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+)
+
+// NewServer returns started server that serves secrets by path (e.g. secret/data/gateway) to clients with token.
+func NewServer(token string, secrets map[string]map[string]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		if r.Header.Get("X-Vault-Token") != token {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		data, ok := secrets[strings.TrimPrefix(r.URL.Path, "/v1/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{ //nolint:errcheck
+			"data": map[string]any{
+				"data":     data,
+				"metadata": map[string]any{"version": 1},
+			},
+		})
+	}))
+}
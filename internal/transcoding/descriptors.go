@@ -0,0 +1,189 @@
+package transcoding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// LoadDescriptorSet returns service from FileDescriptorSet file, e.g. produced by protoc --include_imports --descriptor_set_out.
+func LoadDescriptorSet(path, service string) (protoreflect.ServiceDescriptor, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read descriptor set: %w", err)
+	}
+
+	var set descriptorpb.FileDescriptorSet
+	if err = proto.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal descriptor set: %w", err)
+	}
+
+	return findService(set.GetFile(), service)
+}
+
+// Reflect returns service from server reflection of conn.
+func Reflect(ctx context.Context, conn grpc.ClientConnInterface, service string) (protoreflect.ServiceDescriptor, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open reflection stream: %w", err)
+	}
+
+	files := make(map[string]*descriptorpb.FileDescriptorProto)
+
+	request := &reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_FileContainingSymbol{FileContainingSymbol: service},
+	}
+
+	// dependencies are requested until all files are known, well-known files may be resolved globally.
+	for request != nil {
+		if err = stream.Send(request); err != nil {
+			return nil, fmt.Errorf("failed to send reflection request: %w", err)
+		}
+
+		resp, err := stream.Recv()
+		if err != nil {
+			return nil, fmt.Errorf("failed to receive reflection response: %w", err)
+		}
+
+		if errResp := resp.GetErrorResponse(); errResp != nil {
+			return nil, fmt.Errorf("reflection error: %s", errResp.GetErrorMessage())
+		}
+
+		for _, data := range resp.GetFileDescriptorResponse().GetFileDescriptorProto() {
+			file := &descriptorpb.FileDescriptorProto{}
+			if err = proto.Unmarshal(data, file); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal file descriptor: %w", err)
+			}
+
+			files[file.GetName()] = file
+		}
+
+		request = nil
+
+		for _, file := range files {
+			if dependency := missingDependency(files, file); dependency != "" {
+				request = &reflectionpb.ServerReflectionRequest{
+					MessageRequest: &reflectionpb.ServerReflectionRequest_FileByFilename{FileByFilename: dependency},
+				}
+
+				break
+			}
+		}
+	}
+
+	_ = stream.CloseSend() //nolint:errcheck
+
+	list := make([]*descriptorpb.FileDescriptorProto, 0, len(files))
+	for _, file := range files {
+		list = append(list, file)
+	}
+
+	return findService(list, service)
+}
+
+func missingDependency(files map[string]*descriptorpb.FileDescriptorProto, file *descriptorpb.FileDescriptorProto) string {
+	for _, dependency := range file.GetDependency() {
+		if _, ok := files[dependency]; ok {
+			continue
+		}
+
+		if _, err := protoregistry.GlobalFiles.FindFileByPath(dependency); err == nil {
+			continue
+		}
+
+		return dependency
+	}
+
+	return ""
+}
+
+var (
+	errNotService = errors.New("descriptor is not a service")
+)
+
+// findService builds files in dependency order and returns service by full name.
+func findService(list []*descriptorpb.FileDescriptorProto, service string) (protoreflect.ServiceDescriptor, error) {
+	protos := make(map[string]*descriptorpb.FileDescriptorProto, len(list))
+	for _, file := range list {
+		protos[file.GetName()] = file
+	}
+
+	files := &protoregistry.Files{}
+	resolver := fallbackResolver{local: files}
+
+	var build func(name string) error
+	build = func(name string) error {
+		file, ok := protos[name]
+		if !ok {
+			return nil
+		}
+
+		if _, err := files.FindFileByPath(name); err == nil {
+			return nil
+		}
+
+		for _, dependency := range file.GetDependency() {
+			if err := build(dependency); err != nil {
+				return err
+			}
+		}
+
+		fd, err := protodesc.NewFile(file, resolver)
+		if err != nil {
+			return fmt.Errorf("failed to build file %s: %w", name, err)
+		}
+
+		return files.RegisterFile(fd)
+	}
+
+	for name := range protos {
+		if err := build(name); err != nil {
+			return nil, err
+		}
+	}
+
+	desc, err := files.FindDescriptorByName(protoreflect.FullName(service))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find service %s: %w", service, err)
+	}
+
+	sd, ok := desc.(protoreflect.ServiceDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", service, errNotService)
+	}
+
+	return sd, nil
+}
+
+// fallbackResolver resolves well-known files missing in descriptor set from linked ones.
+type fallbackResolver struct {
+	local *protoregistry.Files
+}
+
+func (r fallbackResolver) FindFileByPath(path string) (protoreflect.FileDescriptor, error) {
+	if fd, err := r.local.FindFileByPath(path); err == nil {
+		return fd, nil
+	}
+
+	return protoregistry.GlobalFiles.FindFileByPath(path)
+}
+
+func (r fallbackResolver) FindDescriptorByName(name protoreflect.FullName) (protoreflect.Descriptor, error) {
+	if desc, err := r.local.FindDescriptorByName(name); err == nil {
+		return desc, nil
+	}
+
+	return protoregistry.GlobalFiles.FindDescriptorByName(name)
+}
@@ -0,0 +1,179 @@
+package transcoding
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+const (
+	wildcard     = "*"
+	deepWildcard = "**"
+)
+
+// Template is a path template of google.api.http rule, e.g. /v1/{name=shelves/*}/books:publish.
+type Template struct {
+	// segments are literals, * or **.
+	segments  []string
+	variables []variable
+	verb      string
+}
+
+// variable binds segments [start, end) to field path.
+type variable struct {
+	fieldPath  string
+	start, end int
+}
+
+// ParseTemplate parses path template.
+func ParseTemplate(template string) (*Template, error) {
+	if !strings.HasPrefix(template, "/") {
+		return nil, fmt.Errorf("template %q must start with /", template)
+	}
+
+	t := &Template{}
+	path := template[1:]
+
+	if idx := strings.LastIndex(path, ":"); idx > strings.LastIndex(path, "/") && idx > strings.LastIndex(path, "}") {
+		path, t.verb = path[:idx], path[idx+1:]
+	}
+
+	parts, err := splitTemplate(path)
+	if err != nil {
+		return nil, fmt.Errorf("template %q is invalid: %w", template, err)
+	}
+
+	for _, part := range parts {
+		if !strings.HasPrefix(part, "{") {
+			t.segments = append(t.segments, part)
+			continue
+		}
+
+		fieldPath, pattern, found := strings.Cut(part[1:len(part)-1], "=")
+		if !found {
+			pattern = wildcard
+		}
+
+		if fieldPath == "" {
+			return nil, fmt.Errorf("template %q has variable without field", template)
+		}
+
+		v := variable{fieldPath: fieldPath, start: len(t.segments)}
+		t.segments = append(t.segments, strings.Split(pattern, "/")...)
+		v.end = len(t.segments)
+
+		t.variables = append(t.variables, v)
+	}
+
+	for i, segment := range t.segments {
+		if segment == "" {
+			return nil, fmt.Errorf("template %q has empty segment", template)
+		}
+
+		if segment == deepWildcard && i != len(t.segments)-1 {
+			return nil, fmt.Errorf("template %q has ** not in the last segment", template)
+		}
+	}
+
+	return t, nil
+}
+
+// splitTemplate splits path by slashes outside of variables.
+func splitTemplate(path string) ([]string, error) {
+	var (
+		parts []string
+		depth int
+		start int
+	)
+
+	for i, c := range path {
+		switch c {
+		case '{':
+			if depth > 0 {
+				return nil, fmt.Errorf("nested variable")
+			}
+
+			depth++
+		case '}':
+			if depth == 0 {
+				return nil, fmt.Errorf("unexpected }")
+			}
+
+			depth--
+		case '/':
+			if depth == 0 {
+				parts = append(parts, path[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	if depth != 0 {
+		return nil, fmt.Errorf("unclosed variable")
+	}
+
+	return append(parts, path[start:]), nil
+}
+
+// Match matches path against template and returns values of variables by field paths.
+func (t *Template) Match(path string) (map[string]string, bool) {
+	path = strings.TrimPrefix(path, "/")
+
+	if t.verb != "" {
+		var found bool
+		if path, found = strings.CutSuffix(path, ":"+t.verb); !found {
+			return nil, false
+		}
+	}
+
+	parts := strings.Split(path, "/")
+	// positions[i] is an index of part matched by segment i.
+	positions := make([]int, len(t.segments)+1)
+
+	j := 0
+	for i, segment := range t.segments {
+		positions[i] = j
+
+		switch {
+		case segment == deepWildcard:
+			j = len(parts)
+			continue
+		case j >= len(parts):
+			return nil, false
+		case segment == wildcard:
+			if parts[j] == "" {
+				return nil, false
+			}
+		case segment != parts[j]:
+			return nil, false
+		}
+
+		j++
+	}
+
+	if j != len(parts) {
+		return nil, false
+	}
+
+	positions[len(t.segments)] = j
+
+	values := make(map[string]string, len(t.variables))
+
+	for _, v := range t.variables {
+		matched := parts[positions[v.start]:positions[v.end]]
+
+		unescaped := make([]string, 0, len(matched))
+		for _, part := range matched {
+			value, err := url.PathUnescape(part)
+			if err != nil {
+				return nil, false
+			}
+
+			unescaped = append(unescaped, value)
+		}
+
+		values[v.fieldPath] = strings.Join(unescaped, "/")
+	}
+
+	return values, true
+}
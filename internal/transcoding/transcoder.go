@@ -0,0 +1,428 @@
+package transcoding
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+const bodyWildcard = "*"
+
+var (
+	// ErrNotFound is returned when no method matches request.
+	ErrNotFound = errors.New("method not found")
+	// ErrInvalidRequest is returned when request can't be converted to request message.
+	ErrInvalidRequest = errors.New("invalid request")
+
+	errUnknownField = errors.New("unknown field")
+)
+
+// Transcoder maps HTTP requests with JSON bodies to unary methods of gRPC service.
+type Transcoder struct {
+	service protoreflect.ServiceDescriptor
+	methods map[string]protoreflect.MethodDescriptor
+	routes  []route
+}
+
+type route struct {
+	httpMethod   string
+	template     *Template
+	method       protoreflect.MethodDescriptor
+	body         string
+	responseBody string
+}
+
+// New returns Transcoder of service. Methods are routed by google.api.http annotations
+// and by name, streaming methods are not supported.
+func New(service protoreflect.ServiceDescriptor) (*Transcoder, error) {
+	t := &Transcoder{
+		service: service,
+		methods: make(map[string]protoreflect.MethodDescriptor),
+	}
+
+	methods := service.Methods()
+	for i := 0; i < methods.Len(); i++ {
+		method := methods.Get(i)
+		if method.IsStreamingClient() || method.IsStreamingServer() {
+			continue
+		}
+
+		t.methods[string(method.Name())] = method
+
+		rule, ok := proto.GetExtension(method.Options(), annotations.E_Http).(*annotations.HttpRule)
+		if !ok || rule == nil {
+			continue
+		}
+
+		for _, r := range append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...) {
+			httpMethod, template := rulePattern(r)
+			if template == "" {
+				// custom patterns have methods not supported by gateway.
+				continue
+			}
+
+			parsed, err := ParseTemplate(template)
+			if err != nil {
+				return nil, fmt.Errorf("method %s: %w", method.FullName(), err)
+			}
+
+			t.routes = append(t.routes, route{
+				httpMethod:   httpMethod,
+				template:     parsed,
+				method:       method,
+				body:         r.GetBody(),
+				responseBody: r.GetResponseBody(),
+			})
+		}
+	}
+
+	return t, nil
+}
+
+func rulePattern(rule *annotations.HttpRule) (string, string) {
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return http.MethodGet, pattern.Get
+	case *annotations.HttpRule_Put:
+		return http.MethodPut, pattern.Put
+	case *annotations.HttpRule_Post:
+		return http.MethodPost, pattern.Post
+	case *annotations.HttpRule_Delete:
+		return http.MethodDelete, pattern.Delete
+	case *annotations.HttpRule_Patch:
+		return http.MethodPatch, pattern.Patch
+	default:
+		return "", ""
+	}
+}
+
+// Call is a gRPC call converted from HTTP request.
+type Call struct {
+	// FullMethod is a method name for grpc.ClientConn.Invoke, e.g. /pkg.Service/Method.
+	FullMethod string
+	Request    proto.Message
+	Response   proto.Message
+
+	responseBody string
+}
+
+// NewCall converts HTTP request to call. Path is a path below service, e.g. /v1/books/1 or /GetBook.
+// When first path segment is a method name, that method is called with request message built from
+// JSON body and query parameters, otherwise annotated routes are matched.
+func (t *Transcoder) NewCall(httpMethod, path, query string, body []byte) (*Call, error) {
+	r, values, err := t.match(httpMethod, path)
+	if err != nil {
+		return nil, err
+	}
+
+	request := dynamicpb.NewMessage(r.method.Input())
+
+	if err = bindBody(request, r.body, body); err != nil {
+		return nil, fmt.Errorf("%w: body: %w", ErrInvalidRequest, err)
+	}
+
+	// path variables override body.
+	for fieldPath, value := range values {
+		if err = setField(request, fieldPath, value); err != nil {
+			return nil, fmt.Errorf("%w: path variable %s: %w", ErrInvalidRequest, fieldPath, err)
+		}
+	}
+
+	if r.body != bodyWildcard {
+		if err = bindQuery(request, query, r.body, values); err != nil {
+			return nil, fmt.Errorf("%w: query: %w", ErrInvalidRequest, err)
+		}
+	}
+
+	return &Call{
+		FullMethod:   fmt.Sprintf("/%s/%s", t.service.FullName(), r.method.Name()),
+		Request:      request,
+		Response:     dynamicpb.NewMessage(r.method.Output()),
+		responseBody: r.responseBody,
+	}, nil
+}
+
+// Method returns name of method that serves request, so gateway applies rules of that method
+// and not of first path segment of annotated route.
+func (t *Transcoder) Method(httpMethod, path string) (string, error) {
+	r, _, err := t.match(httpMethod, path)
+	if err != nil {
+		return "", err
+	}
+
+	return string(r.method.Name()), nil
+}
+
+func (t *Transcoder) match(httpMethod, path string) (route, map[string]string, error) {
+	// method name in first segment takes precedence, so templates like /{parent=*}/books
+	// must not route it to another method.
+	name, rest, _ := strings.Cut(strings.TrimPrefix(path, "/"), "/")
+	if method, ok := t.methods[name]; ok {
+		if strings.Trim(rest, "/") != "" {
+			return route{}, nil, ErrNotFound
+		}
+
+		return route{method: method, body: bodyWildcard}, nil, nil
+	}
+
+	for _, r := range t.routes {
+		if r.httpMethod != httpMethod {
+			continue
+		}
+
+		if values, ok := r.template.Match(path); ok {
+			return r, values, nil
+		}
+	}
+
+	return route{}, nil, ErrNotFound
+}
+
+// MarshalResponse returns JSON of response or its response_body field.
+func (c *Call) MarshalResponse() ([]byte, error) {
+	data, err := protojson.Marshal(c.Response)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.responseBody == "" {
+		return data, nil
+	}
+
+	fd := c.Response.ProtoReflect().Descriptor().Fields().ByName(protoreflect.Name(c.responseBody))
+	if fd == nil {
+		return nil, fmt.Errorf("response body %s: %w", c.responseBody, errUnknownField)
+	}
+
+	var fields map[string]json.RawMessage
+	if err = json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	if value, ok := fields[fd.JSONName()]; ok {
+		return value, nil
+	}
+
+	return []byte("null"), nil
+}
+
+func bindBody(request *dynamicpb.Message, bodyField string, body []byte) error {
+	if bodyField == "" || len(body) == 0 {
+		return nil
+	}
+
+	if bodyField != bodyWildcard {
+		// body is a value of field, so it's wrapped to be decoded with field type.
+		fieldName, err := json.Marshal(bodyField)
+		if err != nil {
+			return err
+		}
+
+		body = slices.Concat([]byte("{"), fieldName, []byte(":"), body, []byte("}"))
+	}
+
+	return protojson.Unmarshal(body, request)
+}
+
+func bindQuery(request *dynamicpb.Message, query, bodyField string, values map[string]string) error {
+	params, err := url.ParseQuery(query)
+	if err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	for _, key := range keys {
+		if _, bound := values[key]; bound || isBodyField(key, bodyField) {
+			continue
+		}
+
+		for _, value := range params[key] {
+			err = setField(request, key, value)
+			if errors.Is(err, errUnknownField) {
+				// unknown parameters are ignored, e.g. cache busters.
+				break
+			}
+
+			if err != nil {
+				return fmt.Errorf("parameter %s: %w", key, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func isBodyField(key, bodyField string) bool {
+	return bodyField != "" && (key == bodyField || strings.HasPrefix(key, bodyField+"."))
+}
+
+// setField sets value of field by dotted path, repeated fields are appended.
+func setField(msg protoreflect.Message, fieldPath, value string) error {
+	names := strings.Split(fieldPath, ".")
+
+	for _, name := range names[:len(names)-1] {
+		fd := fieldByName(msg, name)
+		if fd == nil {
+			return fmt.Errorf("%s: %w", name, errUnknownField)
+		}
+
+		if fd.Kind() != protoreflect.MessageKind || fd.IsList() || fd.IsMap() {
+			return fmt.Errorf("%s is not a message", name)
+		}
+
+		msg = msg.Mutable(fd).Message()
+	}
+
+	fd := fieldByName(msg, names[len(names)-1])
+	if fd == nil {
+		return fmt.Errorf("%s: %w", fieldPath, errUnknownField)
+	}
+
+	if fd.IsMap() {
+		return fmt.Errorf("%s is a map", fieldPath)
+	}
+
+	if fd.IsList() {
+		list := msg.Mutable(fd).List()
+
+		v, err := parseValue(fd, list.NewElement, value)
+		if err != nil {
+			return err
+		}
+
+		list.Append(v)
+
+		return nil
+	}
+
+	v, err := parseValue(fd, func() protoreflect.Value { return msg.NewField(fd) }, value)
+	if err != nil {
+		return err
+	}
+
+	msg.Set(fd, v)
+
+	return nil
+}
+
+func fieldByName(msg protoreflect.Message, name string) protoreflect.FieldDescriptor {
+	fields := msg.Descriptor().Fields()
+	if fd := fields.ByName(protoreflect.Name(name)); fd != nil {
+		return fd
+	}
+
+	return fields.ByJSONName(name)
+}
+
+func parseValue(fd protoreflect.FieldDescriptor, newValue func() protoreflect.Value, s string) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(s), nil
+	case protoreflect.BoolKind:
+		b, err := strconv.ParseBool(s)
+		return protoreflect.ValueOfBool(b), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		n, err := strconv.ParseInt(s, 10, 32)
+		return protoreflect.ValueOfInt32(int32(n)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		n, err := strconv.ParseInt(s, 10, 64)
+		return protoreflect.ValueOfInt64(n), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		n, err := strconv.ParseUint(s, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(n)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		n, err := strconv.ParseUint(s, 10, 64)
+		return protoreflect.ValueOfUint64(n), err
+	case protoreflect.FloatKind:
+		f, err := strconv.ParseFloat(s, 32)
+		return protoreflect.ValueOfFloat32(float32(f)), err
+	case protoreflect.DoubleKind:
+		f, err := strconv.ParseFloat(s, 64)
+		return protoreflect.ValueOfFloat64(f), err
+	case protoreflect.BytesKind:
+		b, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			b, err = base64.URLEncoding.DecodeString(s)
+		}
+
+		return protoreflect.ValueOfBytes(b), err
+	case protoreflect.EnumKind:
+		if ev := fd.Enum().Values().ByName(protoreflect.Name(s)); ev != nil {
+			return protoreflect.ValueOfEnum(ev.Number()), nil
+		}
+
+		n, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			return protoreflect.Value{}, fmt.Errorf("unknown value %s of enum %s", s, fd.Enum().FullName())
+		}
+
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(n)), nil
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		// well-known types like Timestamp have JSON string representation.
+		v := newValue()
+
+		data, err := json.Marshal(s)
+		if err != nil {
+			return protoreflect.Value{}, err
+		}
+
+		if err = protojson.Unmarshal(data, v.Message().Interface()); err != nil {
+			return protoreflect.Value{}, err
+		}
+
+		return v, nil
+	default:
+		return protoreflect.Value{}, fmt.Errorf("unsupported kind %s", fd.Kind())
+	}
+}
+
+// HTTPStatusFromCode returns HTTP status of gRPC code.
+func HTTPStatusFromCode(code codes.Code) int {
+	switch code {
+	case codes.OK:
+		return http.StatusOK
+	case codes.Canceled:
+		return 499
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted:
+		return http.StatusConflict
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.FailedPrecondition:
+		return http.StatusBadRequest
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
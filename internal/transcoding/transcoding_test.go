@@ -0,0 +1,298 @@
+package transcoding
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	provider "github.com/TheUnitedCoders/devpost-auth0-api-gateway/pkg/pb/contract/v1"
+)
+
+func TestTemplateMatch(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		template string
+		path     string
+		values   map[string]string
+		ok       bool
+	}{
+		{template: "/v1/books/{id}", path: "/v1/books/42", values: map[string]string{"id": "42"}, ok: true},
+		{template: "/v1/books/{id}", path: "/v1/books/42/pages", ok: false},
+		{template: "/v1/{name=shelves/*/books/*}", path: "/v1/shelves/1/books/2", values: map[string]string{"name": "shelves/1/books/2"}, ok: true},
+		{template: "/v1/files/{path=**}", path: "/v1/files/a/b%20c", values: map[string]string{"path": "a/b c"}, ok: true},
+		{template: "/v1/books/{id}:publish", path: "/v1/books/7:publish", values: map[string]string{"id": "7"}, ok: true},
+		{template: "/v1/books/{id}:publish", path: "/v1/books/7", ok: false},
+		{template: "/v1/books", path: "/v1/shelves", ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.template+" "+tt.path, func(t *testing.T) {
+			t.Parallel()
+
+			template, err := ParseTemplate(tt.template)
+			require.NoError(t, err)
+
+			values, ok := template.Match(tt.path)
+			assert.Equal(t, tt.ok, ok)
+
+			if tt.ok {
+				assert.Equal(t, tt.values, values)
+			}
+		})
+	}
+}
+
+func TestParseTemplateInvalid(t *testing.T) {
+	t.Parallel()
+
+	for _, template := range []string{"v1/books", "/v1/{id", "/v1/**/books", "/v1//books", "/v1/{=*}"} {
+		_, err := ParseTemplate(template)
+		assert.Error(t, err, template)
+	}
+}
+
+// libraryFile is a descriptor of service with google.api.http annotations.
+func libraryFile(t *testing.T) *descriptorpb.FileDescriptorProto {
+	t.Helper()
+
+	getOptions := &descriptorpb.MethodOptions{}
+	proto.SetExtension(getOptions, annotations.E_Http, &annotations.HttpRule{
+		Pattern: &annotations.HttpRule_Get{Get: "/v1/shelves/{shelf}/books/{book.id}"},
+	})
+
+	createOptions := &descriptorpb.MethodOptions{}
+	proto.SetExtension(createOptions, annotations.E_Http, &annotations.HttpRule{
+		Pattern: &annotations.HttpRule_Post{Post: "/{shelf=*}/books"},
+		Body:    "book",
+	})
+
+	updateOptions := &descriptorpb.MethodOptions{}
+	proto.SetExtension(updateOptions, annotations.E_Http, &annotations.HttpRule{
+		Pattern:      &annotations.HttpRule_Patch{Patch: "/v1/books/{book.id}"},
+		Body:         "book",
+		ResponseBody: "title",
+	})
+
+	field := func(name string, number int32, typ descriptorpb.FieldDescriptorProto_Type, label descriptorpb.FieldDescriptorProto_Label, typeName string) *descriptorpb.FieldDescriptorProto {
+		f := &descriptorpb.FieldDescriptorProto{
+			Name:     proto.String(name),
+			JsonName: proto.String(name),
+			Number:   proto.Int32(number),
+			Type:     typ.Enum(),
+			Label:    label.Enum(),
+		}
+
+		if typeName != "" {
+			f.TypeName = proto.String(typeName)
+		}
+
+		return f
+	}
+
+	optional := descriptorpb.FieldDescriptorProto_LABEL_OPTIONAL
+	repeated := descriptorpb.FieldDescriptorProto_LABEL_REPEATED
+
+	return &descriptorpb.FileDescriptorProto{
+		Name:       proto.String("library/v1/library.proto"),
+		Package:    proto.String("library.v1"),
+		Syntax:     proto.String("proto3"),
+		Dependency: []string{"google/api/annotations.proto"},
+		MessageType: []*descriptorpb.DescriptorProto{
+			{
+				Name: proto.String("Book"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("id", 1, descriptorpb.FieldDescriptorProto_TYPE_INT64, optional, ""),
+					field("title", 2, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional, ""),
+					field("tags", 3, descriptorpb.FieldDescriptorProto_TYPE_STRING, repeated, ""),
+				},
+			},
+			{
+				Name: proto.String("BookRequest"),
+				Field: []*descriptorpb.FieldDescriptorProto{
+					field("shelf", 1, descriptorpb.FieldDescriptorProto_TYPE_STRING, optional, ""),
+					field("book", 2, descriptorpb.FieldDescriptorProto_TYPE_MESSAGE, optional, ".library.v1.Book"),
+					field("limit", 3, descriptorpb.FieldDescriptorProto_TYPE_INT32, optional, ""),
+				},
+			},
+		},
+		Service: []*descriptorpb.ServiceDescriptorProto{{
+			Name: proto.String("LibraryService"),
+			Method: []*descriptorpb.MethodDescriptorProto{
+				{Name: proto.String("GetBook"), InputType: proto.String(".library.v1.BookRequest"), OutputType: proto.String(".library.v1.Book"), Options: getOptions},
+				{Name: proto.String("CreateBook"), InputType: proto.String(".library.v1.BookRequest"), OutputType: proto.String(".library.v1.Book"), Options: createOptions},
+				{Name: proto.String("UpdateBook"), InputType: proto.String(".library.v1.BookRequest"), OutputType: proto.String(".library.v1.Book"), Options: updateOptions},
+			},
+		}},
+	}
+}
+
+func TestNewCall(t *testing.T) {
+	t.Parallel()
+
+	service, err := findService([]*descriptorpb.FileDescriptorProto{libraryFile(t)}, "library.v1.LibraryService")
+	require.NoError(t, err)
+
+	transcoder, err := New(service)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		httpMethod string
+		path       string
+		query      string
+		body       string
+		fullMethod string
+		request    string
+		err        error
+	}{
+		{
+			name:       "annotated path and query",
+			httpMethod: "GET",
+			path:       "/v1/shelves/fiction/books/3",
+			query:      "limit=10&unknown=1&book.tags=a&book.tags=b",
+			fullMethod: "/library.v1.LibraryService/GetBook",
+			request:    `{"shelf":"fiction","book":{"id":"3","tags":["a","b"]},"limit":10}`,
+		},
+		{
+			name:       "annotated body field",
+			httpMethod: "PATCH",
+			path:       "/v1/books/5",
+			body:       `{"title":"Dune","id":"1"}`,
+			fullMethod: "/library.v1.LibraryService/UpdateBook",
+			request:    `{"book":{"id":"5","title":"Dune"}}`,
+		},
+		{
+			name:       "method name",
+			httpMethod: "POST",
+			path:       "/GetBook",
+			body:       `{"shelf":"poetry"}`,
+			fullMethod: "/library.v1.LibraryService/GetBook",
+			request:    `{"shelf":"poetry"}`,
+		},
+		{
+			name:       "annotated template with variable first segment",
+			httpMethod: "POST",
+			path:       "/fiction/books",
+			body:       `{"title":"Dune"}`,
+			fullMethod: "/library.v1.LibraryService/CreateBook",
+			request:    `{"shelf":"fiction","book":{"title":"Dune"}}`,
+		},
+		{
+			name:       "method name is not captured by template",
+			httpMethod: "POST",
+			path:       "/GetBook/books",
+			err:        ErrNotFound,
+		},
+		{
+			name:       "not found",
+			httpMethod: "DELETE",
+			path:       "/v1/books/5",
+			err:        ErrNotFound,
+		},
+		{
+			name:       "invalid path variable",
+			httpMethod: "GET",
+			path:       "/v1/shelves/fiction/books/abc",
+			err:        ErrInvalidRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			call, err := transcoder.NewCall(tt.httpMethod, tt.path, tt.query, []byte(tt.body))
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.fullMethod, call.FullMethod)
+
+			request, err := protojson.Marshal(call.Request)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.request, string(request))
+		})
+	}
+}
+
+func TestMethod(t *testing.T) {
+	t.Parallel()
+
+	service, err := findService([]*descriptorpb.FileDescriptorProto{libraryFile(t)}, "library.v1.LibraryService")
+	require.NoError(t, err)
+
+	transcoder, err := New(service)
+	require.NoError(t, err)
+
+	method, err := transcoder.Method("GET", "/v1/shelves/fiction/books/3")
+	require.NoError(t, err)
+	assert.Equal(t, "GetBook", method)
+
+	method, err = transcoder.Method("PATCH", "/v1/books/5")
+	require.NoError(t, err)
+	assert.Equal(t, "UpdateBook", method)
+
+	method, err = transcoder.Method("POST", "/CreateBook")
+	require.NoError(t, err)
+	assert.Equal(t, "CreateBook", method)
+
+	_, err = transcoder.Method("DELETE", "/v1/books/5")
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestMarshalResponseBody(t *testing.T) {
+	t.Parallel()
+
+	service, err := findService([]*descriptorpb.FileDescriptorProto{libraryFile(t)}, "library.v1.LibraryService")
+	require.NoError(t, err)
+
+	transcoder, err := New(service)
+	require.NoError(t, err)
+
+	call, err := transcoder.NewCall("PATCH", "/v1/books/5", "", nil)
+	require.NoError(t, err)
+
+	require.NoError(t, protojson.Unmarshal([]byte(`{"id":"5","title":"Dune"}`), call.Response))
+
+	body, err := call.MarshalResponse()
+	require.NoError(t, err)
+	assert.Equal(t, `"Dune"`, string(body))
+}
+
+func TestReflect(t *testing.T) {
+	t.Parallel()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	server := grpc.NewServer()
+	provider.RegisterProviderServiceServer(server, provider.UnimplementedProviderServiceServer{})
+	reflection.Register(server)
+
+	go server.Serve(listener) //nolint:errcheck
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() }) //nolint:errcheck
+
+	service, err := Reflect(context.Background(), conn, "contract.v1.ProviderService")
+	require.NoError(t, err)
+	assert.NotNil(t, service.Methods().ByName("Process"))
+
+	_, err = Reflect(context.Background(), conn, "contract.v1.Missing")
+	require.Error(t, err)
+}
@@ -0,0 +1,93 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+const cleanupEvery = 1024
+
+type entry[V any] struct {
+	value     V
+	expiresAt time.Time
+}
+
+// Cache is a generic in-memory cache with per-entry expiration.
+type Cache[K comparable, V any] struct {
+	data    map[K]entry[V]
+	mux     sync.Mutex
+	setsNum uint
+	now     func() time.Time
+}
+
+// New returns new Cache.
+func New[K comparable, V any]() *Cache[K, V] {
+	return &Cache[K, V]{
+		data: make(map[K]entry[V]),
+		now:  time.Now,
+	}
+}
+
+// Get returns value if it exists and not expired.
+func (c *Cache[K, V]) Get(key K) (value V, ok bool) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	e, ok := c.data[key]
+	if !ok {
+		return value, false
+	}
+
+	if !c.now().Before(e.expiresAt) {
+		delete(c.data, key)
+		return value, false
+	}
+
+	return e.value, true
+}
+
+// Set value with provided ttl. Non-positive ttl means that value will not be stored.
+func (c *Cache[K, V]) Set(key K, value V, ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	c.data[key] = entry[V]{
+		value:     value,
+		expiresAt: c.now().Add(ttl),
+	}
+
+	c.setsNum++
+	if c.setsNum%cleanupEvery == 0 {
+		c.cleanup()
+	}
+}
+
+// Delete value by key.
+func (c *Cache[K, V]) Delete(key K) {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	delete(c.data, key)
+}
+
+// Len returns number of stored entries including expired but not yet cleaned.
+func (c *Cache[K, V]) Len() int {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+
+	return len(c.data)
+}
+
+func (c *Cache[K, V]) cleanup() {
+	now := c.now()
+
+	for key, e := range c.data {
+		if !now.Before(e.expiresAt) {
+			delete(c.data, key)
+		}
+	}
+}
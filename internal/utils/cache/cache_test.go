@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+
+	now := time.Now()
+
+	c := New[string, int]()
+	c.now = func() time.Time { return now }
+
+	c.Set("foo", 1, time.Minute)
+	c.Set("bar", 2, 0)
+
+	val, ok := c.Get("foo")
+	assert.Equal(t, 1, val)
+	assert.True(t, ok)
+
+	_, ok = c.Get("bar")
+	assert.False(t, ok)
+
+	now = now.Add(time.Minute)
+
+	_, ok = c.Get("foo")
+	assert.False(t, ok)
+	assert.Equal(t, 0, c.Len())
+
+	c.Set("foo", 3, time.Minute)
+	c.Delete("foo")
+
+	_, ok = c.Get("foo")
+	assert.False(t, ok)
+}
@@ -0,0 +1,66 @@
+package netutil
+
+import (
+	"net"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strings"
+)
+
+const forwardedForHeader = "X-Forwarded-For"
+
+// PeerAddr returns address of peer from http.Request.RemoteAddr, invalid when it can't be parsed.
+func PeerAddr(remoteAddr string) netip.Addr {
+	host, _, err := net.SplitHostPort(remoteAddr)
+	if err != nil {
+		host = remoteAddr
+	}
+
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}
+	}
+
+	return addr.Unmap()
+}
+
+// IsTrustedProxy reports whether addr belongs to one of trusted proxies.
+func IsTrustedProxy(trustedProxies []netip.Prefix, addr netip.Addr) bool {
+	return addr.IsValid() && slices.ContainsFunc(trustedProxies, func(prefix netip.Prefix) bool { return prefix.Contains(addr) })
+}
+
+// FromTrustedProxy reports whether request came from trusted proxy, so its X-Forwarded-* headers can be used.
+func FromTrustedProxy(remoteAddr string, trustedProxies []netip.Prefix) bool {
+	return IsTrustedProxy(trustedProxies, PeerAddr(remoteAddr))
+}
+
+// ClientAddr returns address of client. X-Forwarded-For is used only when request came from trusted proxy,
+// the rightmost address that isn't trusted proxy is the client, addresses to the left of it can be spoofed.
+func ClientAddr(remoteAddr string, headers http.Header, trustedProxies []netip.Prefix) netip.Addr {
+	addr := PeerAddr(remoteAddr)
+	if !IsTrustedProxy(trustedProxies, addr) {
+		return addr
+	}
+
+	forwarded := strings.Split(strings.Join(headers.Values(forwardedForHeader), ","), ",")
+
+	for i := len(forwarded) - 1; i >= 0; i-- {
+		value := strings.TrimSpace(forwarded[i])
+		if value == "" {
+			continue
+		}
+
+		forwardedAddr, err := netip.ParseAddr(value)
+		if err != nil {
+			return netip.Addr{}
+		}
+
+		addr = forwardedAddr.Unmap()
+		if !IsTrustedProxy(trustedProxies, addr) {
+			return addr
+		}
+	}
+
+	return addr
+}
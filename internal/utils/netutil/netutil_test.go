@@ -0,0 +1,38 @@
+package netutil
+
+import (
+	"net/http"
+	"net/netip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientAddr(t *testing.T) {
+	t.Parallel()
+
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+	headers := http.Header{forwardedForHeader: {"1.1.1.1, 203.0.113.7", "10.0.0.2"}}
+
+	// header of untrusted peer is ignored.
+	assert.Equal(t, "198.51.100.1", ClientAddr("198.51.100.1:5000", headers, trusted).String())
+	assert.Equal(t, "10.0.0.1", ClientAddr("10.0.0.1:5000", headers, nil).String())
+
+	// the rightmost untrusted address is the client.
+	assert.Equal(t, "203.0.113.7", ClientAddr("10.0.0.1:5000", headers, trusted).String())
+
+	assert.False(t, ClientAddr("10.0.0.1:5000", http.Header{forwardedForHeader: {"garbage"}}, trusted).IsValid())
+}
+
+func TestFromTrustedProxy(t *testing.T) {
+	t.Parallel()
+
+	trusted := []netip.Prefix{netip.MustParsePrefix("10.0.0.0/8")}
+
+	assert.True(t, FromTrustedProxy("10.0.0.1:5000", trusted))
+	assert.True(t, FromTrustedProxy("[::ffff:10.0.0.1]:5000", trusted), "mapped IPv4 address")
+	assert.True(t, FromTrustedProxy("10.0.0.1", trusted), "address without port")
+	assert.False(t, FromTrustedProxy("203.0.113.7:5000", trusted))
+	assert.False(t, FromTrustedProxy("pipe", trusted))
+	assert.False(t, FromTrustedProxy("10.0.0.1:5000", nil))
+}
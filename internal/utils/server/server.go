@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
 	"log/slog"
 	"net/http"
@@ -29,6 +30,12 @@ func New(address string, handler http.Handler, logger *slog.Logger) *Server {
 	}
 }
 
+// WithTLS enables TLS, certificates must be provided in config.
+func (s *Server) WithTLS(cfg *tls.Config) *Server {
+	s.srv.TLSConfig = cfg
+	return s
+}
+
 // Run HTTP server.
 func (s *Server) Run(ctx context.Context) error {
 	go func() {
@@ -45,7 +52,14 @@ func (s *Server) Run(ctx context.Context) error {
 
 	s.logger.Info("starting http server", slog.String("addr", s.srv.Addr))
 
-	if err := s.srv.ListenAndServe(); err != nil {
+	var err error
+	if s.srv.TLSConfig != nil {
+		err = s.srv.ListenAndServeTLS("", "")
+	} else {
+		err = s.srv.ListenAndServe()
+	}
+
+	if err != nil {
 		if errors.Is(err, http.ErrServerClosed) {
 			return nil
 		}
@@ -45,3 +45,11 @@ func (s *Store[K, V]) Data() map[K]V {
 
 	return s.data
 }
+
+// Delete ...
+func (s *Store[K, V]) Delete(key K) {
+	s.mux.Lock()
+	defer s.mux.Unlock()
+
+	delete(s.data, key)
+}
@@ -26,4 +26,8 @@ func TestStore(t *testing.T) {
 	assert.True(t, ok)
 
 	assert.EqualValues(t, map[string]int{"foo": 1, "bar": 2}, s.Data())
+
+	s.Delete("bar")
+	_, ok = s.Get("bar")
+	assert.False(t, ok)
 }
@@ -0,0 +1,92 @@
+package tlsutil
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// LoadCertPool returns pool with certificates from PEM files.
+func LoadCertPool(files []string) (*x509.CertPool, error) {
+	pool := x509.NewCertPool()
+
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CA file %s: %w", file, err)
+		}
+
+		if !pool.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in CA file %s", file)
+		}
+	}
+
+	return pool, nil
+}
+
+// ServerConfigOptions ...
+type ServerConfigOptions struct {
+	CertFile      string
+	KeyFile       string
+	ClientCAFiles []string
+	ClientAuth    tls.ClientAuthType
+}
+
+var (
+	errClientCAsRequired = errors.New("client CA files are required to verify client certificates")
+)
+
+// ServerConfig returns TLS config for server with optional client certificates verification.
+func ServerConfig(opts ServerConfigOptions) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load server certificate: %w", err)
+	}
+
+	cfg := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+		ClientAuth:   opts.ClientAuth,
+	}
+
+	if len(opts.ClientCAFiles) != 0 {
+		if cfg.ClientCAs, err = LoadCertPool(opts.ClientCAFiles); err != nil {
+			return nil, err
+		}
+	} else if opts.ClientAuth >= tls.VerifyClientCertIfGiven {
+		return nil, errClientCAsRequired
+	}
+
+	return cfg, nil
+}
+
+// ClientConfigOptions ...
+type ClientConfigOptions struct {
+	CertFile string
+	KeyFile  string
+	// CAFiles are optional, system pool is used when empty.
+	CAFiles []string
+}
+
+// ClientConfig returns TLS config for client that presents certificate.
+func ClientConfig(opts ClientConfigOptions) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(opts.CertFile, opts.KeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load client certificate: %w", err)
+	}
+
+	cfg := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if len(opts.CAFiles) != 0 {
+		if cfg.RootCAs, err = LoadCertPool(opts.CAFiles); err != nil {
+			return nil, err
+		}
+	}
+
+	return cfg, nil
+}
@@ -0,0 +1,92 @@
+package tlsutil
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCertificate writes self-signed certificate and its key to dir, returns paths of both files.
+func writeCertificate(t *testing.T, dir string) (certFile, keyFile string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "gateway"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	certFile = filepath.Join(dir, "cert.pem")
+	keyFile = filepath.Join(dir, "key.pem")
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	return certFile, keyFile
+}
+
+func TestLoadCertPool(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	certFile, keyFile := writeCertificate(t, dir)
+
+	pool, err := LoadCertPool([]string{certFile})
+	require.NoError(t, err)
+	assert.False(t, pool.Equal(x509.NewCertPool()))
+
+	_, err = LoadCertPool([]string{keyFile})
+	require.ErrorContains(t, err, "no certificates found")
+
+	_, err = LoadCertPool([]string{filepath.Join(dir, "missing.pem")})
+	require.ErrorContains(t, err, "failed to read CA file")
+}
+
+func TestServerConfig(t *testing.T) {
+	t.Parallel()
+
+	certFile, keyFile := writeCertificate(t, t.TempDir())
+
+	cfg, err := ServerConfig(ServerConfigOptions{CertFile: certFile, KeyFile: keyFile})
+	require.NoError(t, err)
+	assert.Equal(t, tls.NoClientCert, cfg.ClientAuth)
+	assert.Nil(t, cfg.ClientCAs)
+
+	// unverified client certificates can be requested without CAs, but not verified.
+	_, err = ServerConfig(ServerConfigOptions{CertFile: certFile, KeyFile: keyFile, ClientAuth: tls.RequestClientCert})
+	require.NoError(t, err)
+
+	_, err = ServerConfig(ServerConfigOptions{CertFile: certFile, KeyFile: keyFile, ClientAuth: tls.VerifyClientCertIfGiven})
+	require.ErrorIs(t, err, errClientCAsRequired)
+
+	cfg, err = ServerConfig(ServerConfigOptions{
+		CertFile:      certFile,
+		KeyFile:       keyFile,
+		ClientCAFiles: []string{certFile},
+		ClientAuth:    tls.RequireAndVerifyClientCert,
+	})
+	require.NoError(t, err)
+	assert.NotNil(t, cfg.ClientCAs)
+}
@@ -79,12 +79,184 @@ func (HttpMethod) EnumDescriptor() ([]byte, []int) {
 	return file_contract_v1_provider_proto_rawDescGZIP(), []int{0}
 }
 
+type AuthenticationMode int32
+
+const (
+	AuthenticationMode_AUTHENTICATION_MODE_UNSPECIFIED AuthenticationMode = 0
+	AuthenticationMode_AUTHENTICATION_MODE_NONE        AuthenticationMode = 1
+	AuthenticationMode_AUTHENTICATION_MODE_OPTIONAL    AuthenticationMode = 2
+	AuthenticationMode_AUTHENTICATION_MODE_REQUIRED    AuthenticationMode = 3
+)
+
+// Enum value maps for AuthenticationMode.
+var (
+	AuthenticationMode_name = map[int32]string{
+		0: "AUTHENTICATION_MODE_UNSPECIFIED",
+		1: "AUTHENTICATION_MODE_NONE",
+		2: "AUTHENTICATION_MODE_OPTIONAL",
+		3: "AUTHENTICATION_MODE_REQUIRED",
+	}
+	AuthenticationMode_value = map[string]int32{
+		"AUTHENTICATION_MODE_UNSPECIFIED": 0,
+		"AUTHENTICATION_MODE_NONE":        1,
+		"AUTHENTICATION_MODE_OPTIONAL":    2,
+		"AUTHENTICATION_MODE_REQUIRED":    3,
+	}
+)
+
+func (x AuthenticationMode) Enum() *AuthenticationMode {
+	p := new(AuthenticationMode)
+	*p = x
+	return p
+}
+
+func (x AuthenticationMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (AuthenticationMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_contract_v1_provider_proto_enumTypes[1].Descriptor()
+}
+
+func (AuthenticationMode) Type() protoreflect.EnumType {
+	return &file_contract_v1_provider_proto_enumTypes[1]
+}
+
+func (x AuthenticationMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use AuthenticationMode.Descriptor instead.
+func (AuthenticationMode) EnumDescriptor() ([]byte, []int) {
+	return file_contract_v1_provider_proto_rawDescGZIP(), []int{1}
+}
+
+type CertificateAuthentication int32
+
+const (
+	CertificateAuthentication_CERTIFICATE_AUTHENTICATION_UNSPECIFIED CertificateAuthentication = 0
+	CertificateAuthentication_CERTIFICATE_AUTHENTICATION_DISABLED    CertificateAuthentication = 1
+	CertificateAuthentication_CERTIFICATE_AUTHENTICATION_ACCEPTED    CertificateAuthentication = 2
+	CertificateAuthentication_CERTIFICATE_AUTHENTICATION_REQUIRED    CertificateAuthentication = 3
+)
+
+// Enum value maps for CertificateAuthentication.
+var (
+	CertificateAuthentication_name = map[int32]string{
+		0: "CERTIFICATE_AUTHENTICATION_UNSPECIFIED",
+		1: "CERTIFICATE_AUTHENTICATION_DISABLED",
+		2: "CERTIFICATE_AUTHENTICATION_ACCEPTED",
+		3: "CERTIFICATE_AUTHENTICATION_REQUIRED",
+	}
+	CertificateAuthentication_value = map[string]int32{
+		"CERTIFICATE_AUTHENTICATION_UNSPECIFIED": 0,
+		"CERTIFICATE_AUTHENTICATION_DISABLED":    1,
+		"CERTIFICATE_AUTHENTICATION_ACCEPTED":    2,
+		"CERTIFICATE_AUTHENTICATION_REQUIRED":    3,
+	}
+)
+
+func (x CertificateAuthentication) Enum() *CertificateAuthentication {
+	p := new(CertificateAuthentication)
+	*p = x
+	return p
+}
+
+func (x CertificateAuthentication) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (CertificateAuthentication) Descriptor() protoreflect.EnumDescriptor {
+	return file_contract_v1_provider_proto_enumTypes[2].Descriptor()
+}
+
+func (CertificateAuthentication) Type() protoreflect.EnumType {
+	return &file_contract_v1_provider_proto_enumTypes[2]
+}
+
+func (x CertificateAuthentication) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use CertificateAuthentication.Descriptor instead.
+func (CertificateAuthentication) EnumDescriptor() ([]byte, []int) {
+	return file_contract_v1_provider_proto_rawDescGZIP(), []int{2}
+}
+
+// Priority of request under overload, unspecified means normal.
+type Priority int32
+
+const (
+	Priority_PRIORITY_UNSPECIFIED Priority = 0
+	Priority_PRIORITY_LOW         Priority = 1
+	Priority_PRIORITY_NORMAL      Priority = 2
+	Priority_PRIORITY_HIGH        Priority = 3
+	Priority_PRIORITY_CRITICAL    Priority = 4
+)
+
+// Enum value maps for Priority.
+var (
+	Priority_name = map[int32]string{
+		0: "PRIORITY_UNSPECIFIED",
+		1: "PRIORITY_LOW",
+		2: "PRIORITY_NORMAL",
+		3: "PRIORITY_HIGH",
+		4: "PRIORITY_CRITICAL",
+	}
+	Priority_value = map[string]int32{
+		"PRIORITY_UNSPECIFIED": 0,
+		"PRIORITY_LOW":         1,
+		"PRIORITY_NORMAL":      2,
+		"PRIORITY_HIGH":        3,
+		"PRIORITY_CRITICAL":    4,
+	}
+)
+
+func (x Priority) Enum() *Priority {
+	p := new(Priority)
+	*p = x
+	return p
+}
+
+func (x Priority) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Priority) Descriptor() protoreflect.EnumDescriptor {
+	return file_contract_v1_provider_proto_enumTypes[3].Descriptor()
+}
+
+func (Priority) Type() protoreflect.EnumType {
+	return &file_contract_v1_provider_proto_enumTypes[3]
+}
+
+func (x Priority) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Priority.Descriptor instead.
+func (Priority) EnumDescriptor() ([]byte, []int) {
+	return file_contract_v1_provider_proto_rawDescGZIP(), []int{3}
+}
+
 type RateLimitBy int32
 
 const (
 	RateLimitBy_RATE_LIMIT_BY_UNSPECIFIED RateLimitBy = 0
 	RateLimitBy_RATE_LIMIT_BY_IP          RateLimitBy = 2
 	RateLimitBy_RATE_LIMIT_BY_SUBJECT_ID  RateLimitBy = 3
+	// claim of access token named by name, e.g. org_id.
+	RateLimitBy_RATE_LIMIT_BY_CLAIM RateLimitBy = 4
+	// request header named by name.
+	RateLimitBy_RATE_LIMIT_BY_HEADER RateLimitBy = 5
+	// query parameter named by name.
+	RateLimitBy_RATE_LIMIT_BY_QUERY RateLimitBy = 6
+	// one limit for all callers.
+	RateLimitBy_RATE_LIMIT_BY_GLOBAL RateLimitBy = 7
+	// API key from header named by name, X-API-Key by default.
+	RateLimitBy_RATE_LIMIT_BY_API_KEY RateLimitBy = 8
+	// combination of keys.
+	RateLimitBy_RATE_LIMIT_BY_COMPOSITE RateLimitBy = 9
 )
 
 // Enum value maps for RateLimitBy.
@@ -93,11 +265,23 @@ var (
 		0: "RATE_LIMIT_BY_UNSPECIFIED",
 		2: "RATE_LIMIT_BY_IP",
 		3: "RATE_LIMIT_BY_SUBJECT_ID",
+		4: "RATE_LIMIT_BY_CLAIM",
+		5: "RATE_LIMIT_BY_HEADER",
+		6: "RATE_LIMIT_BY_QUERY",
+		7: "RATE_LIMIT_BY_GLOBAL",
+		8: "RATE_LIMIT_BY_API_KEY",
+		9: "RATE_LIMIT_BY_COMPOSITE",
 	}
 	RateLimitBy_value = map[string]int32{
 		"RATE_LIMIT_BY_UNSPECIFIED": 0,
 		"RATE_LIMIT_BY_IP":          2,
 		"RATE_LIMIT_BY_SUBJECT_ID":  3,
+		"RATE_LIMIT_BY_CLAIM":       4,
+		"RATE_LIMIT_BY_HEADER":      5,
+		"RATE_LIMIT_BY_QUERY":       6,
+		"RATE_LIMIT_BY_GLOBAL":      7,
+		"RATE_LIMIT_BY_API_KEY":     8,
+		"RATE_LIMIT_BY_COMPOSITE":   9,
 	}
 )
 
@@ -112,11 +296,11 @@ func (x RateLimitBy) String() string {
 }
 
 func (RateLimitBy) Descriptor() protoreflect.EnumDescriptor {
-	return file_contract_v1_provider_proto_enumTypes[1].Descriptor()
+	return file_contract_v1_provider_proto_enumTypes[4].Descriptor()
 }
 
 func (RateLimitBy) Type() protoreflect.EnumType {
-	return &file_contract_v1_provider_proto_enumTypes[1]
+	return &file_contract_v1_provider_proto_enumTypes[4]
 }
 
 func (x RateLimitBy) Number() protoreflect.EnumNumber {
@@ -125,7 +309,62 @@ func (x RateLimitBy) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use RateLimitBy.Descriptor instead.
 func (RateLimitBy) EnumDescriptor() ([]byte, []int) {
-	return file_contract_v1_provider_proto_rawDescGZIP(), []int{1}
+	return file_contract_v1_provider_proto_rawDescGZIP(), []int{4}
+}
+
+type RateLimitKey struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	By   RateLimitBy `protobuf:"varint,1,opt,name=by,proto3,enum=contract.v1.RateLimitBy" json:"by,omitempty"`
+	Name string      `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+}
+
+func (x *RateLimitKey) Reset() {
+	*x = RateLimitKey{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contract_v1_provider_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RateLimitKey) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimitKey) ProtoMessage() {}
+
+func (x *RateLimitKey) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_v1_provider_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimitKey.ProtoReflect.Descriptor instead.
+func (*RateLimitKey) Descriptor() ([]byte, []int) {
+	return file_contract_v1_provider_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RateLimitKey) GetBy() RateLimitBy {
+	if x != nil {
+		return x.By
+	}
+	return RateLimitBy_RATE_LIMIT_BY_UNSPECIFIED
+}
+
+func (x *RateLimitKey) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
 type RateLimiter struct {
@@ -137,12 +376,16 @@ type RateLimiter struct {
 	Limit  uint64               `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
 	Burst  uint64               `protobuf:"varint,3,opt,name=burst,proto3" json:"burst,omitempty"`
 	Period *durationpb.Duration `protobuf:"bytes,4,opt,name=period,proto3" json:"period,omitempty"`
+	// name of claim, header or query parameter.
+	Name string `protobuf:"bytes,5,opt,name=name,proto3" json:"name,omitempty"`
+	// keys of composite limiter.
+	Keys []*RateLimitKey `protobuf:"bytes,6,rep,name=keys,proto3" json:"keys,omitempty"`
 }
 
 func (x *RateLimiter) Reset() {
 	*x = RateLimiter{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_contract_v1_provider_proto_msgTypes[0]
+		mi := &file_contract_v1_provider_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -155,7 +398,7 @@ func (x *RateLimiter) String() string {
 func (*RateLimiter) ProtoMessage() {}
 
 func (x *RateLimiter) ProtoReflect() protoreflect.Message {
-	mi := &file_contract_v1_provider_proto_msgTypes[0]
+	mi := &file_contract_v1_provider_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -168,7 +411,7 @@ func (x *RateLimiter) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RateLimiter.ProtoReflect.Descriptor instead.
 func (*RateLimiter) Descriptor() ([]byte, []int) {
-	return file_contract_v1_provider_proto_rawDescGZIP(), []int{0}
+	return file_contract_v1_provider_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *RateLimiter) GetBy() RateLimitBy {
@@ -199,6 +442,95 @@ func (x *RateLimiter) GetPeriod() *durationpb.Duration {
 	return nil
 }
 
+func (x *RateLimiter) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RateLimiter) GetKeys() []*RateLimitKey {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+// RequestCost is a count of tokens taken by request from rate limiters:
+// base + value of query_param + started body_bytes_per_unit chunks of body, capped by max.
+type RequestCost struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// base cost, 1 when zero.
+	Base             uint64 `protobuf:"varint,1,opt,name=base,proto3" json:"base,omitempty"`
+	QueryParam       string `protobuf:"bytes,2,opt,name=query_param,json=queryParam,proto3" json:"query_param,omitempty"`
+	BodyBytesPerUnit uint64 `protobuf:"varint,3,opt,name=body_bytes_per_unit,json=bodyBytesPerUnit,proto3" json:"body_bytes_per_unit,omitempty"`
+	// max cost, unlimited when zero.
+	Max uint64 `protobuf:"varint,4,opt,name=max,proto3" json:"max,omitempty"`
+}
+
+func (x *RequestCost) Reset() {
+	*x = RequestCost{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contract_v1_provider_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RequestCost) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestCost) ProtoMessage() {}
+
+func (x *RequestCost) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_v1_provider_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestCost.ProtoReflect.Descriptor instead.
+func (*RequestCost) Descriptor() ([]byte, []int) {
+	return file_contract_v1_provider_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *RequestCost) GetBase() uint64 {
+	if x != nil {
+		return x.Base
+	}
+	return 0
+}
+
+func (x *RequestCost) GetQueryParam() string {
+	if x != nil {
+		return x.QueryParam
+	}
+	return ""
+}
+
+func (x *RequestCost) GetBodyBytesPerUnit() uint64 {
+	if x != nil {
+		return x.BodyBytesPerUnit
+	}
+	return 0
+}
+
+func (x *RequestCost) GetMax() uint64 {
+	if x != nil {
+		return x.Max
+	}
+	return 0
+}
+
 type DescriptionRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -208,7 +540,7 @@ type DescriptionRequest struct {
 func (x *DescriptionRequest) Reset() {
 	*x = DescriptionRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_contract_v1_provider_proto_msgTypes[1]
+		mi := &file_contract_v1_provider_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -221,7 +553,7 @@ func (x *DescriptionRequest) String() string {
 func (*DescriptionRequest) ProtoMessage() {}
 
 func (x *DescriptionRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_contract_v1_provider_proto_msgTypes[1]
+	mi := &file_contract_v1_provider_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -234,7 +566,7 @@ func (x *DescriptionRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DescriptionRequest.ProtoReflect.Descriptor instead.
 func (*DescriptionRequest) Descriptor() ([]byte, []int) {
-	return file_contract_v1_provider_proto_rawDescGZIP(), []int{1}
+	return file_contract_v1_provider_proto_rawDescGZIP(), []int{3}
 }
 
 type DescriptionResponse struct {
@@ -242,17 +574,24 @@ type DescriptionResponse struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	AuditEnabled           bool                 `protobuf:"varint,1,opt,name=audit_enabled,json=auditEnabled,proto3" json:"audit_enabled,omitempty"`
-	RequiredAuthentication bool                 `protobuf:"varint,3,opt,name=required_authentication,json=requiredAuthentication,proto3" json:"required_authentication,omitempty"`
-	RateLimiter            *RateLimiter         `protobuf:"bytes,4,opt,name=rate_limiter,json=rateLimiter,proto3" json:"rate_limiter,omitempty"`
-	RequiredPermissions    []string             `protobuf:"bytes,5,rep,name=required_permissions,json=requiredPermissions,proto3" json:"required_permissions,omitempty"`
-	Methods                []*DescriptionMethod `protobuf:"bytes,6,rep,name=methods,proto3" json:"methods,omitempty"`
+	AuditEnabled              bool                      `protobuf:"varint,1,opt,name=audit_enabled,json=auditEnabled,proto3" json:"audit_enabled,omitempty"`
+	RequiredAuthentication    bool                      `protobuf:"varint,3,opt,name=required_authentication,json=requiredAuthentication,proto3" json:"required_authentication,omitempty"`
+	RateLimiter               *RateLimiter              `protobuf:"bytes,4,opt,name=rate_limiter,json=rateLimiter,proto3" json:"rate_limiter,omitempty"`
+	RequiredPermissions       []string                  `protobuf:"bytes,5,rep,name=required_permissions,json=requiredPermissions,proto3" json:"required_permissions,omitempty"`
+	Methods                   []*DescriptionMethod      `protobuf:"bytes,6,rep,name=methods,proto3" json:"methods,omitempty"`
+	AuthenticationMode        AuthenticationMode        `protobuf:"varint,7,opt,name=authentication_mode,json=authenticationMode,proto3,enum=contract.v1.AuthenticationMode" json:"authentication_mode,omitempty"`
+	CertificateAuthentication CertificateAuthentication `protobuf:"varint,8,opt,name=certificate_authentication,json=certificateAuthentication,proto3,enum=contract.v1.CertificateAuthentication" json:"certificate_authentication,omitempty"`
+	// version is a hash of description, it changes only when description changes.
+	Version string `protobuf:"bytes,9,opt,name=version,proto3" json:"version,omitempty"`
+	// rate_limiters apply all together with limiters of method. When set, they replace rate_limiter,
+	// which holds the first of them for older gateways.
+	RateLimiters []*RateLimiter `protobuf:"bytes,10,rep,name=rate_limiters,json=rateLimiters,proto3" json:"rate_limiters,omitempty"`
 }
 
 func (x *DescriptionResponse) Reset() {
 	*x = DescriptionResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_contract_v1_provider_proto_msgTypes[2]
+		mi := &file_contract_v1_provider_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -265,7 +604,7 @@ func (x *DescriptionResponse) String() string {
 func (*DescriptionResponse) ProtoMessage() {}
 
 func (x *DescriptionResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_contract_v1_provider_proto_msgTypes[2]
+	mi := &file_contract_v1_provider_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -278,7 +617,7 @@ func (x *DescriptionResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DescriptionResponse.ProtoReflect.Descriptor instead.
 func (*DescriptionResponse) Descriptor() ([]byte, []int) {
-	return file_contract_v1_provider_proto_rawDescGZIP(), []int{2}
+	return file_contract_v1_provider_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *DescriptionResponse) GetAuditEnabled() bool {
@@ -316,23 +655,164 @@ func (x *DescriptionResponse) GetMethods() []*DescriptionMethod {
 	return nil
 }
 
+func (x *DescriptionResponse) GetAuthenticationMode() AuthenticationMode {
+	if x != nil {
+		return x.AuthenticationMode
+	}
+	return AuthenticationMode_AUTHENTICATION_MODE_UNSPECIFIED
+}
+
+func (x *DescriptionResponse) GetCertificateAuthentication() CertificateAuthentication {
+	if x != nil {
+		return x.CertificateAuthentication
+	}
+	return CertificateAuthentication_CERTIFICATE_AUTHENTICATION_UNSPECIFIED
+}
+
+func (x *DescriptionResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *DescriptionResponse) GetRateLimiters() []*RateLimiter {
+	if x != nil {
+		return x.RateLimiters
+	}
+	return nil
+}
+
+type WatchDescriptionRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// version known by gateway, description is not sent again while it's unchanged.
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+}
+
+func (x *WatchDescriptionRequest) Reset() {
+	*x = WatchDescriptionRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contract_v1_provider_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchDescriptionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchDescriptionRequest) ProtoMessage() {}
+
+func (x *WatchDescriptionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_v1_provider_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchDescriptionRequest.ProtoReflect.Descriptor instead.
+func (*WatchDescriptionRequest) Descriptor() ([]byte, []int) {
+	return file_contract_v1_provider_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *WatchDescriptionRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+type WatchDescriptionResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Version string `protobuf:"bytes,1,opt,name=version,proto3" json:"version,omitempty"`
+	// description is empty when it's not changed since requested version.
+	Description *DescriptionResponse `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+}
+
+func (x *WatchDescriptionResponse) Reset() {
+	*x = WatchDescriptionResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_contract_v1_provider_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WatchDescriptionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchDescriptionResponse) ProtoMessage() {}
+
+func (x *WatchDescriptionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_contract_v1_provider_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchDescriptionResponse.ProtoReflect.Descriptor instead.
+func (*WatchDescriptionResponse) Descriptor() ([]byte, []int) {
+	return file_contract_v1_provider_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *WatchDescriptionResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *WatchDescriptionResponse) GetDescription() *DescriptionResponse {
+	if x != nil {
+		return x.Description
+	}
+	return nil
+}
+
 type DescriptionMethod struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Method                 string       `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
-	AuditEnabled           bool         `protobuf:"varint,2,opt,name=audit_enabled,json=auditEnabled,proto3" json:"audit_enabled,omitempty"`
-	RequiredAuthentication bool         `protobuf:"varint,4,opt,name=required_authentication,json=requiredAuthentication,proto3" json:"required_authentication,omitempty"`
-	RateLimiter            *RateLimiter `protobuf:"bytes,5,opt,name=rate_limiter,json=rateLimiter,proto3" json:"rate_limiter,omitempty"`
-	RequiredPermissions    []string     `protobuf:"bytes,6,rep,name=required_permissions,json=requiredPermissions,proto3" json:"required_permissions,omitempty"`
-	AllowedHttpMethods     []HttpMethod `protobuf:"varint,7,rep,packed,name=allowed_http_methods,json=allowedHttpMethods,proto3,enum=contract.v1.HttpMethod" json:"allowed_http_methods,omitempty"`
+	Method                    string                    `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	AuditEnabled              bool                      `protobuf:"varint,2,opt,name=audit_enabled,json=auditEnabled,proto3" json:"audit_enabled,omitempty"`
+	RequiredAuthentication    bool                      `protobuf:"varint,4,opt,name=required_authentication,json=requiredAuthentication,proto3" json:"required_authentication,omitempty"`
+	RateLimiter               *RateLimiter              `protobuf:"bytes,5,opt,name=rate_limiter,json=rateLimiter,proto3" json:"rate_limiter,omitempty"`
+	RequiredPermissions       []string                  `protobuf:"bytes,6,rep,name=required_permissions,json=requiredPermissions,proto3" json:"required_permissions,omitempty"`
+	AllowedHttpMethods        []HttpMethod              `protobuf:"varint,7,rep,packed,name=allowed_http_methods,json=allowedHttpMethods,proto3,enum=contract.v1.HttpMethod" json:"allowed_http_methods,omitempty"`
+	AuthenticationMode        AuthenticationMode        `protobuf:"varint,8,opt,name=authentication_mode,json=authenticationMode,proto3,enum=contract.v1.AuthenticationMode" json:"authentication_mode,omitempty"`
+	CertificateAuthentication CertificateAuthentication `protobuf:"varint,9,opt,name=certificate_authentication,json=certificateAuthentication,proto3,enum=contract.v1.CertificateAuthentication" json:"certificate_authentication,omitempty"`
+	// rate_limiters apply all together with limiters of service. When set, they replace rate_limiter,
+	// which holds the first of them for older gateways.
+	RateLimiters []*RateLimiter `protobuf:"bytes,10,rep,name=rate_limiters,json=rateLimiters,proto3" json:"rate_limiters,omitempty"`
+	// cost of request, every request costs 1 when not set.
+	Cost *RequestCost `protobuf:"bytes,11,opt,name=cost,proto3" json:"cost,omitempty"`
+	// priority of requests to method, consumer priority of gateway may raise it.
+	Priority Priority `protobuf:"varint,12,opt,name=priority,proto3,enum=contract.v1.Priority" json:"priority,omitempty"`
 }
 
 func (x *DescriptionMethod) Reset() {
 	*x = DescriptionMethod{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_contract_v1_provider_proto_msgTypes[3]
+		mi := &file_contract_v1_provider_proto_msgTypes[7]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -345,7 +825,7 @@ func (x *DescriptionMethod) String() string {
 func (*DescriptionMethod) ProtoMessage() {}
 
 func (x *DescriptionMethod) ProtoReflect() protoreflect.Message {
-	mi := &file_contract_v1_provider_proto_msgTypes[3]
+	mi := &file_contract_v1_provider_proto_msgTypes[7]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -358,7 +838,7 @@ func (x *DescriptionMethod) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DescriptionMethod.ProtoReflect.Descriptor instead.
 func (*DescriptionMethod) Descriptor() ([]byte, []int) {
-	return file_contract_v1_provider_proto_rawDescGZIP(), []int{3}
+	return file_contract_v1_provider_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *DescriptionMethod) GetMethod() string {
@@ -403,6 +883,41 @@ func (x *DescriptionMethod) GetAllowedHttpMethods() []HttpMethod {
 	return nil
 }
 
+func (x *DescriptionMethod) GetAuthenticationMode() AuthenticationMode {
+	if x != nil {
+		return x.AuthenticationMode
+	}
+	return AuthenticationMode_AUTHENTICATION_MODE_UNSPECIFIED
+}
+
+func (x *DescriptionMethod) GetCertificateAuthentication() CertificateAuthentication {
+	if x != nil {
+		return x.CertificateAuthentication
+	}
+	return CertificateAuthentication_CERTIFICATE_AUTHENTICATION_UNSPECIFIED
+}
+
+func (x *DescriptionMethod) GetRateLimiters() []*RateLimiter {
+	if x != nil {
+		return x.RateLimiters
+	}
+	return nil
+}
+
+func (x *DescriptionMethod) GetCost() *RequestCost {
+	if x != nil {
+		return x.Cost
+	}
+	return nil
+}
+
+func (x *DescriptionMethod) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_UNSPECIFIED
+}
+
 type SubjectInformation struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -410,12 +925,13 @@ type SubjectInformation struct {
 
 	Id          string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
 	Permissions []string `protobuf:"bytes,2,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	Anonymous   bool     `protobuf:"varint,3,opt,name=anonymous,proto3" json:"anonymous,omitempty"`
 }
 
 func (x *SubjectInformation) Reset() {
 	*x = SubjectInformation{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_contract_v1_provider_proto_msgTypes[4]
+		mi := &file_contract_v1_provider_proto_msgTypes[8]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -428,7 +944,7 @@ func (x *SubjectInformation) String() string {
 func (*SubjectInformation) ProtoMessage() {}
 
 func (x *SubjectInformation) ProtoReflect() protoreflect.Message {
-	mi := &file_contract_v1_provider_proto_msgTypes[4]
+	mi := &file_contract_v1_provider_proto_msgTypes[8]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -441,7 +957,7 @@ func (x *SubjectInformation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SubjectInformation.ProtoReflect.Descriptor instead.
 func (*SubjectInformation) Descriptor() ([]byte, []int) {
-	return file_contract_v1_provider_proto_rawDescGZIP(), []int{4}
+	return file_contract_v1_provider_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *SubjectInformation) GetId() string {
@@ -458,6 +974,13 @@ func (x *SubjectInformation) GetPermissions() []string {
 	return nil
 }
 
+func (x *SubjectInformation) GetAnonymous() bool {
+	if x != nil {
+		return x.Anonymous
+	}
+	return false
+}
+
 type ProcessRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -470,12 +993,14 @@ type ProcessRequest struct {
 	Body               []byte                  `protobuf:"bytes,5,opt,name=body,proto3" json:"body,omitempty"`
 	Headers            map[string]*HeaderValue `protobuf:"bytes,6,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
 	SubjectInformation *SubjectInformation     `protobuf:"bytes,7,opt,name=subject_information,json=subjectInformation,proto3" json:"subject_information,omitempty"`
+	// priority of request assigned by gateway, providers may shed low priority requests first.
+	Priority Priority `protobuf:"varint,8,opt,name=priority,proto3,enum=contract.v1.Priority" json:"priority,omitempty"`
 }
 
 func (x *ProcessRequest) Reset() {
 	*x = ProcessRequest{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_contract_v1_provider_proto_msgTypes[5]
+		mi := &file_contract_v1_provider_proto_msgTypes[9]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -488,7 +1013,7 @@ func (x *ProcessRequest) String() string {
 func (*ProcessRequest) ProtoMessage() {}
 
 func (x *ProcessRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_contract_v1_provider_proto_msgTypes[5]
+	mi := &file_contract_v1_provider_proto_msgTypes[9]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -501,7 +1026,7 @@ func (x *ProcessRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessRequest.ProtoReflect.Descriptor instead.
 func (*ProcessRequest) Descriptor() ([]byte, []int) {
-	return file_contract_v1_provider_proto_rawDescGZIP(), []int{5}
+	return file_contract_v1_provider_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *ProcessRequest) GetApiMethod() string {
@@ -553,6 +1078,13 @@ func (x *ProcessRequest) GetSubjectInformation() *SubjectInformation {
 	return nil
 }
 
+func (x *ProcessRequest) GetPriority() Priority {
+	if x != nil {
+		return x.Priority
+	}
+	return Priority_PRIORITY_UNSPECIFIED
+}
+
 type ProcessResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -561,12 +1093,14 @@ type ProcessResponse struct {
 	Body       []byte                  `protobuf:"bytes,1,opt,name=body,proto3" json:"body,omitempty"`
 	StatusCode uint32                  `protobuf:"varint,2,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
 	Headers    map[string]*HeaderValue `protobuf:"bytes,3,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// actual_cost is charged from rate limiters when it exceeds cost of request, 0 means not reported.
+	ActualCost uint64 `protobuf:"varint,4,opt,name=actual_cost,json=actualCost,proto3" json:"actual_cost,omitempty"`
 }
 
 func (x *ProcessResponse) Reset() {
 	*x = ProcessResponse{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_contract_v1_provider_proto_msgTypes[6]
+		mi := &file_contract_v1_provider_proto_msgTypes[10]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -579,7 +1113,7 @@ func (x *ProcessResponse) String() string {
 func (*ProcessResponse) ProtoMessage() {}
 
 func (x *ProcessResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_contract_v1_provider_proto_msgTypes[6]
+	mi := &file_contract_v1_provider_proto_msgTypes[10]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -592,7 +1126,7 @@ func (x *ProcessResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ProcessResponse.ProtoReflect.Descriptor instead.
 func (*ProcessResponse) Descriptor() ([]byte, []int) {
-	return file_contract_v1_provider_proto_rawDescGZIP(), []int{6}
+	return file_contract_v1_provider_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ProcessResponse) GetBody() []byte {
@@ -616,6 +1150,13 @@ func (x *ProcessResponse) GetHeaders() map[string]*HeaderValue {
 	return nil
 }
 
+func (x *ProcessResponse) GetActualCost() uint64 {
+	if x != nil {
+		return x.ActualCost
+	}
+	return 0
+}
+
 type HeaderValue struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -627,7 +1168,7 @@ type HeaderValue struct {
 func (x *HeaderValue) Reset() {
 	*x = HeaderValue{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_contract_v1_provider_proto_msgTypes[7]
+		mi := &file_contract_v1_provider_proto_msgTypes[11]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -640,7 +1181,7 @@ func (x *HeaderValue) String() string {
 func (*HeaderValue) ProtoMessage() {}
 
 func (x *HeaderValue) ProtoReflect() protoreflect.Message {
-	mi := &file_contract_v1_provider_proto_msgTypes[7]
+	mi := &file_contract_v1_provider_proto_msgTypes[11]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -653,7 +1194,7 @@ func (x *HeaderValue) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use HeaderValue.ProtoReflect.Descriptor instead.
 func (*HeaderValue) Descriptor() ([]byte, []int) {
-	return file_contract_v1_provider_proto_rawDescGZIP(), []int{7}
+	return file_contract_v1_provider_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *HeaderValue) GetValues() []string {
@@ -670,135 +1211,255 @@ var file_contract_v1_provider_proto_rawDesc = []byte{
 	0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x0b, 0x63, 0x6f,
 	0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
 	0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x96, 0x01, 0x0a, 0x0b, 0x52, 0x61,
-	0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x12, 0x28, 0x0a, 0x02, 0x62, 0x79, 0x18,
+	0x69, 0x6f, 0x6e, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x22, 0x4c, 0x0a, 0x0c, 0x52, 0x61, 0x74,
+	0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x4b, 0x65, 0x79, 0x12, 0x28, 0x0a, 0x02, 0x62, 0x79, 0x18,
 	0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74,
 	0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x79, 0x52,
-	0x02, 0x62, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x04, 0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x75, 0x72,
-	0x73, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x62, 0x75, 0x72, 0x73, 0x74, 0x12,
-	0x31, 0x0a, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
-	0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
-	0x66, 0x2e, 0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69,
-	0x6f, 0x64, 0x22, 0x14, 0x0a, 0x12, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f,
-	0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x9d, 0x02, 0x0a, 0x13, 0x44, 0x65, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x12, 0x23, 0x0a, 0x0d, 0x61, 0x75, 0x64, 0x69, 0x74, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65,
-	0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x61, 0x75, 0x64, 0x69, 0x74, 0x45, 0x6e,
-	0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x37, 0x0a, 0x17, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
-	0x64, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
-	0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x16, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64,
-	0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3b,
-	0x0a, 0x0c, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x18, 0x04,
-	0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e,
-	0x76, 0x31, 0x2e, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x52, 0x0b,
-	0x72, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x12, 0x31, 0x0a, 0x14, 0x72,
-	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69,
-	0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x72, 0x65, 0x71, 0x75, 0x69,
-	0x72, 0x65, 0x64, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x38,
-	0x0a, 0x07, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32,
-	0x1e, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65,
-	0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x52,
-	0x07, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x22, 0xc4, 0x02, 0x0a, 0x11, 0x44, 0x65, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x16,
-	0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06,
-	0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x75, 0x64, 0x69, 0x74, 0x5f,
-	0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x61,
-	0x75, 0x64, 0x69, 0x74, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x37, 0x0a, 0x17, 0x72,
-	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69,
-	0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x16, 0x72, 0x65,
-	0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61,
-	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x0c, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x6c, 0x69, 0x6d,
-	0x69, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x6f, 0x6e,
-	0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d,
-	0x69, 0x74, 0x65, 0x72, 0x52, 0x0b, 0x72, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65,
-	0x72, 0x12, 0x31, 0x0a, 0x14, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x70, 0x65,
-	0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52,
-	0x13, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x73, 0x12, 0x49, 0x0a, 0x14, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f,
-	0x68, 0x74, 0x74, 0x70, 0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x18, 0x07, 0x20, 0x03,
-	0x28, 0x0e, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31,
-	0x2e, 0x48, 0x74, 0x74, 0x70, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x12, 0x61, 0x6c, 0x6c,
-	0x6f, 0x77, 0x65, 0x64, 0x48, 0x74, 0x74, 0x70, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x22,
-	0x46, 0x0a, 0x12, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d,
-	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73,
-	0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x65, 0x72, 0x6d,
-	0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x93, 0x03, 0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x63,
-	0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x70,
-	0x69, 0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09,
-	0x61, 0x70, 0x69, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x38, 0x0a, 0x0b, 0x68, 0x74, 0x74,
-	0x70, 0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17,
-	0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x74, 0x74,
-	0x70, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x0a, 0x68, 0x74, 0x74, 0x70, 0x4d, 0x65, 0x74,
-	0x68, 0x6f, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79,
-	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x12, 0x0a,
-	0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x6f, 0x64,
-	0x79, 0x12, 0x42, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03,
-	0x28, 0x0b, 0x32, 0x28, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31,
-	0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e,
-	0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68, 0x65,
-	0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x50, 0x0a, 0x13, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74,
-	0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01,
-	0x28, 0x0b, 0x32, 0x1f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31,
-	0x2e, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x12, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x6e, 0x66, 0x6f,
-	0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x1a, 0x54, 0x0a, 0x0c, 0x48, 0x65, 0x61, 0x64, 0x65,
-	0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01,
-	0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2e, 0x0a, 0x05, 0x76, 0x61, 0x6c,
-	0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72,
-	0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x56, 0x61, 0x6c,
-	0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0xe1, 0x01,
-	0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x52,
-	0x04, 0x62, 0x6f, 0x64, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x5f,
-	0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x74, 0x61, 0x74,
-	0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x43, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72,
-	0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61,
-	0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73,
-	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74,
-	0x72, 0x79, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x1a, 0x54, 0x0a, 0x0c, 0x48,
-	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b,
-	0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2e, 0x0a,
-	0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63,
-	0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65,
-	0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38,
-	0x01, 0x22, 0x25, 0x0a, 0x0b, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65,
-	0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09,
-	0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x2a, 0x98, 0x01, 0x0a, 0x0a, 0x48, 0x74, 0x74,
-	0x70, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x1b, 0x0a, 0x17, 0x48, 0x54, 0x54, 0x50, 0x5f,
-	0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
-	0x45, 0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x48, 0x54, 0x54, 0x50, 0x5f, 0x4d, 0x45, 0x54,
-	0x48, 0x4f, 0x44, 0x5f, 0x47, 0x45, 0x54, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x48, 0x54, 0x54,
-	0x50, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f, 0x50, 0x55, 0x54, 0x10, 0x02, 0x12, 0x14,
-	0x0a, 0x10, 0x48, 0x54, 0x54, 0x50, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f, 0x50, 0x4f,
-	0x53, 0x54, 0x10, 0x03, 0x12, 0x16, 0x0a, 0x12, 0x48, 0x54, 0x54, 0x50, 0x5f, 0x4d, 0x45, 0x54,
-	0x48, 0x4f, 0x44, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x04, 0x12, 0x15, 0x0a, 0x11,
-	0x48, 0x54, 0x54, 0x50, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f, 0x50, 0x41, 0x54, 0x43,
-	0x48, 0x10, 0x05, 0x2a, 0x60, 0x0a, 0x0b, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74,
-	0x42, 0x79, 0x12, 0x1d, 0x0a, 0x19, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54,
-	0x5f, 0x42, 0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10,
-	0x00, 0x12, 0x14, 0x0a, 0x10, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x5f,
-	0x42, 0x59, 0x5f, 0x49, 0x50, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x52, 0x41, 0x54, 0x45, 0x5f,
-	0x4c, 0x49, 0x4d, 0x49, 0x54, 0x5f, 0x42, 0x59, 0x5f, 0x53, 0x55, 0x42, 0x4a, 0x45, 0x43, 0x54,
-	0x5f, 0x49, 0x44, 0x10, 0x03, 0x32, 0xa9, 0x01, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64,
-	0x65, 0x72, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x0b, 0x44, 0x65, 0x73,
-	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72,
-	0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
-	0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x63, 0x6f, 0x6e, 0x74,
-	0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
-	0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x07, 0x50,
-	0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63,
-	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75,
-	0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76,
-	0x31, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x42, 0x52, 0x5a, 0x50, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f,
-	0x54, 0x68, 0x65, 0x55, 0x6e, 0x69, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x64, 0x65, 0x72, 0x73, 0x2f,
-	0x64, 0x65, 0x76, 0x70, 0x6f, 0x73, 0x74, 0x2d, 0x61, 0x75, 0x74, 0x68, 0x30, 0x2d, 0x61, 0x70,
-	0x69, 0x2d, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x62,
-	0x2f, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2f, 0x76, 0x31, 0x3b, 0x70, 0x72, 0x6f,
-	0x76, 0x69, 0x64, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x02, 0x62, 0x79, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x22, 0xd9, 0x01, 0x0a, 0x0b, 0x52, 0x61, 0x74, 0x65,
+	0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x12, 0x28, 0x0a, 0x02, 0x62, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76,
+	0x31, 0x2e, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x79, 0x52, 0x02, 0x62,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x62, 0x75, 0x72, 0x73, 0x74,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x05, 0x62, 0x75, 0x72, 0x73, 0x74, 0x12, 0x31, 0x0a,
+	0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e,
+	0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
+	0x44, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x06, 0x70, 0x65, 0x72, 0x69, 0x6f, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x6e, 0x61, 0x6d, 0x65, 0x12, 0x2d, 0x0a, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x06, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x19, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x4b, 0x65, 0x79, 0x52, 0x04, 0x6b,
+	0x65, 0x79, 0x73, 0x22, 0x83, 0x01, 0x0a, 0x0b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x43,
+	0x6f, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x61, 0x73, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x04, 0x62, 0x61, 0x73, 0x65, 0x12, 0x1f, 0x0a, 0x0b, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x5f, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a, 0x71, 0x75,
+	0x65, 0x72, 0x79, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x12, 0x2d, 0x0a, 0x13, 0x62, 0x6f, 0x64, 0x79,
+	0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x5f, 0x70, 0x65, 0x72, 0x5f, 0x75, 0x6e, 0x69, 0x74, 0x18,
+	0x03, 0x20, 0x01, 0x28, 0x04, 0x52, 0x10, 0x62, 0x6f, 0x64, 0x79, 0x42, 0x79, 0x74, 0x65, 0x73,
+	0x50, 0x65, 0x72, 0x55, 0x6e, 0x69, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x6d, 0x61, 0x78, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6d, 0x61, 0x78, 0x22, 0x14, 0x0a, 0x12, 0x44, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22,
+	0xaf, 0x04, 0x0a, 0x13, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x61, 0x75, 0x64, 0x69, 0x74,
+	0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c,
+	0x61, 0x75, 0x64, 0x69, 0x74, 0x45, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x12, 0x37, 0x0a, 0x17,
+	0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x16, 0x72,
+	0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x0c, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x6c, 0x69,
+	0x6d, 0x69, 0x74, 0x65, 0x72, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x65, 0x72, 0x52, 0x0b, 0x72, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x65, 0x72, 0x12, 0x31, 0x0a, 0x14, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x70,
+	0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x03, 0x28, 0x09,
+	0x52, 0x13, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x38, 0x0a, 0x07, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73,
+	0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1e, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63,
+	0x74, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x12,
+	0x50, 0x0a, 0x13, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x63,
+	0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65,
+	0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x12, 0x61,
+	0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64,
+	0x65, 0x12, 0x65, 0x0a, 0x1a, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65,
+	0x5f, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18,
+	0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x41,
+	0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x19, 0x63,
+	0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e,
+	0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73,
+	0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69,
+	0x6f, 0x6e, 0x12, 0x3d, 0x0a, 0x0d, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74,
+	0x65, 0x72, 0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69,
+	0x74, 0x65, 0x72, 0x52, 0x0c, 0x72, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72,
+	0x73, 0x22, 0x33, 0x0a, 0x17, 0x57, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07,
+	0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x76,
+	0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x22, 0x78, 0x0a, 0x18, 0x57, 0x61, 0x74, 0x63, 0x68, 0x44,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x42, 0x0a, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x20, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x22, 0x9d, 0x05, 0x0a, 0x11, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x23,
+	0x0a, 0x0d, 0x61, 0x75, 0x64, 0x69, 0x74, 0x5f, 0x65, 0x6e, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x08, 0x52, 0x0c, 0x61, 0x75, 0x64, 0x69, 0x74, 0x45, 0x6e, 0x61, 0x62,
+	0x6c, 0x65, 0x64, 0x12, 0x37, 0x0a, 0x17, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x5f,
+	0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x16, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65, 0x64, 0x41, 0x75,
+	0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x3b, 0x0a, 0x0c,
+	0x72, 0x61, 0x74, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x52, 0x0b, 0x72, 0x61,
+	0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x12, 0x31, 0x0a, 0x14, 0x72, 0x65, 0x71,
+	0x75, 0x69, 0x72, 0x65, 0x64, 0x5f, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e,
+	0x73, 0x18, 0x06, 0x20, 0x03, 0x28, 0x09, 0x52, 0x13, 0x72, 0x65, 0x71, 0x75, 0x69, 0x72, 0x65,
+	0x64, 0x50, 0x65, 0x72, 0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x49, 0x0a, 0x14,
+	0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x5f, 0x68, 0x74, 0x74, 0x70, 0x5f, 0x6d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x73, 0x18, 0x07, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x17, 0x2e, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x74, 0x74, 0x70, 0x4d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x52, 0x12, 0x61, 0x6c, 0x6c, 0x6f, 0x77, 0x65, 0x64, 0x48, 0x74, 0x74, 0x70,
+	0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x12, 0x50, 0x0a, 0x13, 0x61, 0x75, 0x74, 0x68, 0x65,
+	0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6d, 0x6f, 0x64, 0x65, 0x18, 0x08,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x1f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f,
+	0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x52, 0x12, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x65, 0x0a, 0x1a, 0x63, 0x65, 0x72,
+	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x5f, 0x61, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74,
+	0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x09, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x26, 0x2e,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x65, 0x72, 0x74,
+	0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63,
+	0x61, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x19, 0x63, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x65, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x3d, 0x0a, 0x0d, 0x72, 0x61, 0x74, 0x65, 0x5f, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72,
+	0x73, 0x18, 0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61,
+	0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65,
+	0x72, 0x52, 0x0c, 0x72, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x65, 0x72, 0x73, 0x12,
+	0x2c, 0x0a, 0x04, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x43, 0x6f, 0x73, 0x74, 0x52, 0x04, 0x63, 0x6f, 0x73, 0x74, 0x12, 0x31, 0x0a,
+	0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x15, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72,
+	0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79,
+	0x22, 0x64, 0x0a, 0x12, 0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x20, 0x0a, 0x0b, 0x70, 0x65, 0x72, 0x6d, 0x69, 0x73,
+	0x73, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x0b, 0x70, 0x65, 0x72,
+	0x6d, 0x69, 0x73, 0x73, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x61, 0x6e, 0x6f, 0x6e,
+	0x79, 0x6d, 0x6f, 0x75, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09, 0x61, 0x6e, 0x6f,
+	0x6e, 0x79, 0x6d, 0x6f, 0x75, 0x73, 0x22, 0xc6, 0x03, 0x0a, 0x0e, 0x50, 0x72, 0x6f, 0x63, 0x65,
+	0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x61, 0x70, 0x69,
+	0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x61,
+	0x70, 0x69, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x38, 0x0a, 0x0b, 0x68, 0x74, 0x74, 0x70,
+	0x5f, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x17, 0x2e,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x74, 0x74, 0x70,
+	0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x52, 0x0a, 0x68, 0x74, 0x74, 0x70, 0x4d, 0x65, 0x74, 0x68,
+	0x6f, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61, 0x74, 0x68, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x65, 0x72, 0x79, 0x12, 0x12, 0x0a, 0x04,
+	0x62, 0x6f, 0x64, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79,
+	0x12, 0x42, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x18, 0x06, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x28, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x48,
+	0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x73, 0x12, 0x50, 0x0a, 0x13, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x5f,
+	0x69, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x1f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x53, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x72, 0x6d, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x52, 0x12, 0x73, 0x75, 0x62, 0x6a, 0x65, 0x63, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x72,
+	0x6d, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x31, 0x0a, 0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69,
+	0x74, 0x79, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x15, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72,
+	0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x52,
+	0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x1a, 0x54, 0x0a, 0x0c, 0x48, 0x65, 0x61,
+	0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x2e, 0x0a, 0x05, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22,
+	0x82, 0x02, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x0c, 0x52, 0x04, 0x62, 0x6f, 0x64, 0x79, 0x12, 0x1f, 0x0a, 0x0b, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x0a, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x43, 0x0a, 0x07, 0x68, 0x65, 0x61, 0x64,
+	0x65, 0x72, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29, 0x2e, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x2e, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45,
+	0x6e, 0x74, 0x72, 0x79, 0x52, 0x07, 0x68, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x12, 0x1f, 0x0a,
+	0x0b, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x73, 0x74, 0x18, 0x04, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0a, 0x61, 0x63, 0x74, 0x75, 0x61, 0x6c, 0x43, 0x6f, 0x73, 0x74, 0x1a, 0x54,
+	0x0a, 0x0c, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10,
+	0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79,
+	0x12, 0x2e, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x18, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x48, 0x65,
+	0x61, 0x64, 0x65, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
+	0x3a, 0x02, 0x38, 0x01, 0x22, 0x25, 0x0a, 0x0b, 0x48, 0x65, 0x61, 0x64, 0x65, 0x72, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x09, 0x52, 0x06, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x2a, 0x98, 0x01, 0x0a, 0x0a,
+	0x48, 0x74, 0x74, 0x70, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x1b, 0x0a, 0x17, 0x48, 0x54,
+	0x54, 0x50, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43,
+	0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x13, 0x0a, 0x0f, 0x48, 0x54, 0x54, 0x50, 0x5f,
+	0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f, 0x47, 0x45, 0x54, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f,
+	0x48, 0x54, 0x54, 0x50, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f, 0x50, 0x55, 0x54, 0x10,
+	0x02, 0x12, 0x14, 0x0a, 0x10, 0x48, 0x54, 0x54, 0x50, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44,
+	0x5f, 0x50, 0x4f, 0x53, 0x54, 0x10, 0x03, 0x12, 0x16, 0x0a, 0x12, 0x48, 0x54, 0x54, 0x50, 0x5f,
+	0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f, 0x44, 0x45, 0x4c, 0x45, 0x54, 0x45, 0x10, 0x04, 0x12,
+	0x15, 0x0a, 0x11, 0x48, 0x54, 0x54, 0x50, 0x5f, 0x4d, 0x45, 0x54, 0x48, 0x4f, 0x44, 0x5f, 0x50,
+	0x41, 0x54, 0x43, 0x48, 0x10, 0x05, 0x2a, 0x9b, 0x01, 0x0a, 0x12, 0x41, 0x75, 0x74, 0x68, 0x65,
+	0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x6f, 0x64, 0x65, 0x12, 0x23, 0x0a,
+	0x1f, 0x41, 0x55, 0x54, 0x48, 0x45, 0x4e, 0x54, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f,
+	0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44,
+	0x10, 0x00, 0x12, 0x1c, 0x0a, 0x18, 0x41, 0x55, 0x54, 0x48, 0x45, 0x4e, 0x54, 0x49, 0x43, 0x41,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x4e, 0x4f, 0x4e, 0x45, 0x10, 0x01,
+	0x12, 0x20, 0x0a, 0x1c, 0x41, 0x55, 0x54, 0x48, 0x45, 0x4e, 0x54, 0x49, 0x43, 0x41, 0x54, 0x49,
+	0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x4f, 0x50, 0x54, 0x49, 0x4f, 0x4e, 0x41, 0x4c,
+	0x10, 0x02, 0x12, 0x20, 0x0a, 0x1c, 0x41, 0x55, 0x54, 0x48, 0x45, 0x4e, 0x54, 0x49, 0x43, 0x41,
+	0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x4d, 0x4f, 0x44, 0x45, 0x5f, 0x52, 0x45, 0x51, 0x55, 0x49, 0x52,
+	0x45, 0x44, 0x10, 0x03, 0x2a, 0xc2, 0x01, 0x0a, 0x19, 0x43, 0x65, 0x72, 0x74, 0x69, 0x66, 0x69,
+	0x63, 0x61, 0x74, 0x65, 0x41, 0x75, 0x74, 0x68, 0x65, 0x6e, 0x74, 0x69, 0x63, 0x61, 0x74, 0x69,
+	0x6f, 0x6e, 0x12, 0x2a, 0x0a, 0x26, 0x43, 0x45, 0x52, 0x54, 0x49, 0x46, 0x49, 0x43, 0x41, 0x54,
+	0x45, 0x5f, 0x41, 0x55, 0x54, 0x48, 0x45, 0x4e, 0x54, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e,
+	0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12, 0x27,
+	0x0a, 0x23, 0x43, 0x45, 0x52, 0x54, 0x49, 0x46, 0x49, 0x43, 0x41, 0x54, 0x45, 0x5f, 0x41, 0x55,
+	0x54, 0x48, 0x45, 0x4e, 0x54, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x44, 0x49, 0x53,
+	0x41, 0x42, 0x4c, 0x45, 0x44, 0x10, 0x01, 0x12, 0x27, 0x0a, 0x23, 0x43, 0x45, 0x52, 0x54, 0x49,
+	0x46, 0x49, 0x43, 0x41, 0x54, 0x45, 0x5f, 0x41, 0x55, 0x54, 0x48, 0x45, 0x4e, 0x54, 0x49, 0x43,
+	0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x41, 0x43, 0x43, 0x45, 0x50, 0x54, 0x45, 0x44, 0x10, 0x02,
+	0x12, 0x27, 0x0a, 0x23, 0x43, 0x45, 0x52, 0x54, 0x49, 0x46, 0x49, 0x43, 0x41, 0x54, 0x45, 0x5f,
+	0x41, 0x55, 0x54, 0x48, 0x45, 0x4e, 0x54, 0x49, 0x43, 0x41, 0x54, 0x49, 0x4f, 0x4e, 0x5f, 0x52,
+	0x45, 0x51, 0x55, 0x49, 0x52, 0x45, 0x44, 0x10, 0x03, 0x2a, 0x75, 0x0a, 0x08, 0x50, 0x72, 0x69,
+	0x6f, 0x72, 0x69, 0x74, 0x79, 0x12, 0x18, 0x0a, 0x14, 0x50, 0x52, 0x49, 0x4f, 0x52, 0x49, 0x54,
+	0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x10, 0x0a, 0x0c, 0x50, 0x52, 0x49, 0x4f, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4c, 0x4f, 0x57, 0x10,
+	0x01, 0x12, 0x13, 0x0a, 0x0f, 0x50, 0x52, 0x49, 0x4f, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x4e, 0x4f,
+	0x52, 0x4d, 0x41, 0x4c, 0x10, 0x02, 0x12, 0x11, 0x0a, 0x0d, 0x50, 0x52, 0x49, 0x4f, 0x52, 0x49,
+	0x54, 0x59, 0x5f, 0x48, 0x49, 0x47, 0x48, 0x10, 0x03, 0x12, 0x15, 0x0a, 0x11, 0x50, 0x52, 0x49,
+	0x4f, 0x52, 0x49, 0x54, 0x59, 0x5f, 0x43, 0x52, 0x49, 0x54, 0x49, 0x43, 0x41, 0x4c, 0x10, 0x04,
+	0x2a, 0xfe, 0x01, 0x0a, 0x0b, 0x52, 0x61, 0x74, 0x65, 0x4c, 0x69, 0x6d, 0x69, 0x74, 0x42, 0x79,
+	0x12, 0x1d, 0x0a, 0x19, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x5f, 0x42,
+	0x59, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49, 0x45, 0x44, 0x10, 0x00, 0x12,
+	0x14, 0x0a, 0x10, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x5f, 0x42, 0x59,
+	0x5f, 0x49, 0x50, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x49,
+	0x4d, 0x49, 0x54, 0x5f, 0x42, 0x59, 0x5f, 0x53, 0x55, 0x42, 0x4a, 0x45, 0x43, 0x54, 0x5f, 0x49,
+	0x44, 0x10, 0x03, 0x12, 0x17, 0x0a, 0x13, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x49,
+	0x54, 0x5f, 0x42, 0x59, 0x5f, 0x43, 0x4c, 0x41, 0x49, 0x4d, 0x10, 0x04, 0x12, 0x18, 0x0a, 0x14,
+	0x52, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x5f, 0x42, 0x59, 0x5f, 0x48, 0x45,
+	0x41, 0x44, 0x45, 0x52, 0x10, 0x05, 0x12, 0x17, 0x0a, 0x13, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x4c,
+	0x49, 0x4d, 0x49, 0x54, 0x5f, 0x42, 0x59, 0x5f, 0x51, 0x55, 0x45, 0x52, 0x59, 0x10, 0x06, 0x12,
+	0x18, 0x0a, 0x14, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x5f, 0x42, 0x59,
+	0x5f, 0x47, 0x4c, 0x4f, 0x42, 0x41, 0x4c, 0x10, 0x07, 0x12, 0x19, 0x0a, 0x15, 0x52, 0x41, 0x54,
+	0x45, 0x5f, 0x4c, 0x49, 0x4d, 0x49, 0x54, 0x5f, 0x42, 0x59, 0x5f, 0x41, 0x50, 0x49, 0x5f, 0x4b,
+	0x45, 0x59, 0x10, 0x08, 0x12, 0x1b, 0x0a, 0x17, 0x52, 0x41, 0x54, 0x45, 0x5f, 0x4c, 0x49, 0x4d,
+	0x49, 0x54, 0x5f, 0x42, 0x59, 0x5f, 0x43, 0x4f, 0x4d, 0x50, 0x4f, 0x53, 0x49, 0x54, 0x45, 0x10,
+	0x09, 0x32, 0x8c, 0x02, 0x0a, 0x0f, 0x50, 0x72, 0x6f, 0x76, 0x69, 0x64, 0x65, 0x72, 0x53, 0x65,
+	0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x50, 0x0a, 0x0b, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e,
+	0x76, 0x31, 0x2e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x61, 0x0a, 0x10, 0x57, 0x61, 0x74, 0x63, 0x68,
+	0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x24, 0x2e, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e, 0x57, 0x61, 0x74, 0x63, 0x68, 0x44,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x1a, 0x25, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31, 0x2e,
+	0x57, 0x61, 0x74, 0x63, 0x68, 0x44, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x30, 0x01, 0x12, 0x44, 0x0a, 0x07, 0x50, 0x72,
+	0x6f, 0x63, 0x65, 0x73, 0x73, 0x12, 0x1b, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2e, 0x76, 0x31,
+	0x2e, 0x50, 0x72, 0x6f, 0x63, 0x65, 0x73, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x42, 0x52, 0x5a, 0x50, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x54,
+	0x68, 0x65, 0x55, 0x6e, 0x69, 0x74, 0x65, 0x64, 0x43, 0x6f, 0x64, 0x65, 0x72, 0x73, 0x2f, 0x64,
+	0x65, 0x76, 0x70, 0x6f, 0x73, 0x74, 0x2d, 0x61, 0x75, 0x74, 0x68, 0x30, 0x2d, 0x61, 0x70, 0x69,
+	0x2d, 0x67, 0x61, 0x74, 0x65, 0x77, 0x61, 0x79, 0x2f, 0x70, 0x6b, 0x67, 0x2f, 0x70, 0x62, 0x2f,
+	0x63, 0x6f, 0x6e, 0x74, 0x72, 0x61, 0x63, 0x74, 0x2f, 0x76, 0x31, 0x3b, 0x70, 0x72, 0x6f, 0x76,
+	0x69, 0x64, 0x65, 0x72, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -813,45 +1474,66 @@ func file_contract_v1_provider_proto_rawDescGZIP() []byte {
 	return file_contract_v1_provider_proto_rawDescData
 }
 
-var file_contract_v1_provider_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
-var file_contract_v1_provider_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_contract_v1_provider_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_contract_v1_provider_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_contract_v1_provider_proto_goTypes = []interface{}{
-	(HttpMethod)(0),             // 0: contract.v1.HttpMethod
-	(RateLimitBy)(0),            // 1: contract.v1.RateLimitBy
-	(*RateLimiter)(nil),         // 2: contract.v1.RateLimiter
-	(*DescriptionRequest)(nil),  // 3: contract.v1.DescriptionRequest
-	(*DescriptionResponse)(nil), // 4: contract.v1.DescriptionResponse
-	(*DescriptionMethod)(nil),   // 5: contract.v1.DescriptionMethod
-	(*SubjectInformation)(nil),  // 6: contract.v1.SubjectInformation
-	(*ProcessRequest)(nil),      // 7: contract.v1.ProcessRequest
-	(*ProcessResponse)(nil),     // 8: contract.v1.ProcessResponse
-	(*HeaderValue)(nil),         // 9: contract.v1.HeaderValue
-	nil,                         // 10: contract.v1.ProcessRequest.HeadersEntry
-	nil,                         // 11: contract.v1.ProcessResponse.HeadersEntry
-	(*durationpb.Duration)(nil), // 12: google.protobuf.Duration
+	(HttpMethod)(0),                  // 0: contract.v1.HttpMethod
+	(AuthenticationMode)(0),          // 1: contract.v1.AuthenticationMode
+	(CertificateAuthentication)(0),   // 2: contract.v1.CertificateAuthentication
+	(Priority)(0),                    // 3: contract.v1.Priority
+	(RateLimitBy)(0),                 // 4: contract.v1.RateLimitBy
+	(*RateLimitKey)(nil),             // 5: contract.v1.RateLimitKey
+	(*RateLimiter)(nil),              // 6: contract.v1.RateLimiter
+	(*RequestCost)(nil),              // 7: contract.v1.RequestCost
+	(*DescriptionRequest)(nil),       // 8: contract.v1.DescriptionRequest
+	(*DescriptionResponse)(nil),      // 9: contract.v1.DescriptionResponse
+	(*WatchDescriptionRequest)(nil),  // 10: contract.v1.WatchDescriptionRequest
+	(*WatchDescriptionResponse)(nil), // 11: contract.v1.WatchDescriptionResponse
+	(*DescriptionMethod)(nil),        // 12: contract.v1.DescriptionMethod
+	(*SubjectInformation)(nil),       // 13: contract.v1.SubjectInformation
+	(*ProcessRequest)(nil),           // 14: contract.v1.ProcessRequest
+	(*ProcessResponse)(nil),          // 15: contract.v1.ProcessResponse
+	(*HeaderValue)(nil),              // 16: contract.v1.HeaderValue
+	nil,                              // 17: contract.v1.ProcessRequest.HeadersEntry
+	nil,                              // 18: contract.v1.ProcessResponse.HeadersEntry
+	(*durationpb.Duration)(nil),      // 19: google.protobuf.Duration
 }
 var file_contract_v1_provider_proto_depIdxs = []int32{
-	1,  // 0: contract.v1.RateLimiter.by:type_name -> contract.v1.RateLimitBy
-	12, // 1: contract.v1.RateLimiter.period:type_name -> google.protobuf.Duration
-	2,  // 2: contract.v1.DescriptionResponse.rate_limiter:type_name -> contract.v1.RateLimiter
-	5,  // 3: contract.v1.DescriptionResponse.methods:type_name -> contract.v1.DescriptionMethod
-	2,  // 4: contract.v1.DescriptionMethod.rate_limiter:type_name -> contract.v1.RateLimiter
-	0,  // 5: contract.v1.DescriptionMethod.allowed_http_methods:type_name -> contract.v1.HttpMethod
-	0,  // 6: contract.v1.ProcessRequest.http_method:type_name -> contract.v1.HttpMethod
-	10, // 7: contract.v1.ProcessRequest.headers:type_name -> contract.v1.ProcessRequest.HeadersEntry
-	6,  // 8: contract.v1.ProcessRequest.subject_information:type_name -> contract.v1.SubjectInformation
-	11, // 9: contract.v1.ProcessResponse.headers:type_name -> contract.v1.ProcessResponse.HeadersEntry
-	9,  // 10: contract.v1.ProcessRequest.HeadersEntry.value:type_name -> contract.v1.HeaderValue
-	9,  // 11: contract.v1.ProcessResponse.HeadersEntry.value:type_name -> contract.v1.HeaderValue
-	3,  // 12: contract.v1.ProviderService.Description:input_type -> contract.v1.DescriptionRequest
-	7,  // 13: contract.v1.ProviderService.Process:input_type -> contract.v1.ProcessRequest
-	4,  // 14: contract.v1.ProviderService.Description:output_type -> contract.v1.DescriptionResponse
-	8,  // 15: contract.v1.ProviderService.Process:output_type -> contract.v1.ProcessResponse
-	14, // [14:16] is the sub-list for method output_type
-	12, // [12:14] is the sub-list for method input_type
-	12, // [12:12] is the sub-list for extension type_name
-	12, // [12:12] is the sub-list for extension extendee
-	0,  // [0:12] is the sub-list for field type_name
+	4,  // 0: contract.v1.RateLimitKey.by:type_name -> contract.v1.RateLimitBy
+	4,  // 1: contract.v1.RateLimiter.by:type_name -> contract.v1.RateLimitBy
+	19, // 2: contract.v1.RateLimiter.period:type_name -> google.protobuf.Duration
+	5,  // 3: contract.v1.RateLimiter.keys:type_name -> contract.v1.RateLimitKey
+	6,  // 4: contract.v1.DescriptionResponse.rate_limiter:type_name -> contract.v1.RateLimiter
+	12, // 5: contract.v1.DescriptionResponse.methods:type_name -> contract.v1.DescriptionMethod
+	1,  // 6: contract.v1.DescriptionResponse.authentication_mode:type_name -> contract.v1.AuthenticationMode
+	2,  // 7: contract.v1.DescriptionResponse.certificate_authentication:type_name -> contract.v1.CertificateAuthentication
+	6,  // 8: contract.v1.DescriptionResponse.rate_limiters:type_name -> contract.v1.RateLimiter
+	9,  // 9: contract.v1.WatchDescriptionResponse.description:type_name -> contract.v1.DescriptionResponse
+	6,  // 10: contract.v1.DescriptionMethod.rate_limiter:type_name -> contract.v1.RateLimiter
+	0,  // 11: contract.v1.DescriptionMethod.allowed_http_methods:type_name -> contract.v1.HttpMethod
+	1,  // 12: contract.v1.DescriptionMethod.authentication_mode:type_name -> contract.v1.AuthenticationMode
+	2,  // 13: contract.v1.DescriptionMethod.certificate_authentication:type_name -> contract.v1.CertificateAuthentication
+	6,  // 14: contract.v1.DescriptionMethod.rate_limiters:type_name -> contract.v1.RateLimiter
+	7,  // 15: contract.v1.DescriptionMethod.cost:type_name -> contract.v1.RequestCost
+	3,  // 16: contract.v1.DescriptionMethod.priority:type_name -> contract.v1.Priority
+	0,  // 17: contract.v1.ProcessRequest.http_method:type_name -> contract.v1.HttpMethod
+	17, // 18: contract.v1.ProcessRequest.headers:type_name -> contract.v1.ProcessRequest.HeadersEntry
+	13, // 19: contract.v1.ProcessRequest.subject_information:type_name -> contract.v1.SubjectInformation
+	3,  // 20: contract.v1.ProcessRequest.priority:type_name -> contract.v1.Priority
+	18, // 21: contract.v1.ProcessResponse.headers:type_name -> contract.v1.ProcessResponse.HeadersEntry
+	16, // 22: contract.v1.ProcessRequest.HeadersEntry.value:type_name -> contract.v1.HeaderValue
+	16, // 23: contract.v1.ProcessResponse.HeadersEntry.value:type_name -> contract.v1.HeaderValue
+	8,  // 24: contract.v1.ProviderService.Description:input_type -> contract.v1.DescriptionRequest
+	10, // 25: contract.v1.ProviderService.WatchDescription:input_type -> contract.v1.WatchDescriptionRequest
+	14, // 26: contract.v1.ProviderService.Process:input_type -> contract.v1.ProcessRequest
+	9,  // 27: contract.v1.ProviderService.Description:output_type -> contract.v1.DescriptionResponse
+	11, // 28: contract.v1.ProviderService.WatchDescription:output_type -> contract.v1.WatchDescriptionResponse
+	15, // 29: contract.v1.ProviderService.Process:output_type -> contract.v1.ProcessResponse
+	27, // [27:30] is the sub-list for method output_type
+	24, // [24:27] is the sub-list for method input_type
+	24, // [24:24] is the sub-list for extension type_name
+	24, // [24:24] is the sub-list for extension extendee
+	0,  // [0:24] is the sub-list for field type_name
 }
 
 func init() { file_contract_v1_provider_proto_init() }
@@ -861,7 +1543,7 @@ func file_contract_v1_provider_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_contract_v1_provider_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*RateLimiter); i {
+			switch v := v.(*RateLimitKey); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -873,7 +1555,7 @@ func file_contract_v1_provider_proto_init() {
 			}
 		}
 		file_contract_v1_provider_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DescriptionRequest); i {
+			switch v := v.(*RateLimiter); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -885,7 +1567,7 @@ func file_contract_v1_provider_proto_init() {
 			}
 		}
 		file_contract_v1_provider_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DescriptionResponse); i {
+			switch v := v.(*RequestCost); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -897,7 +1579,7 @@ func file_contract_v1_provider_proto_init() {
 			}
 		}
 		file_contract_v1_provider_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*DescriptionMethod); i {
+			switch v := v.(*DescriptionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -909,7 +1591,7 @@ func file_contract_v1_provider_proto_init() {
 			}
 		}
 		file_contract_v1_provider_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*SubjectInformation); i {
+			switch v := v.(*DescriptionResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -921,7 +1603,7 @@ func file_contract_v1_provider_proto_init() {
 			}
 		}
 		file_contract_v1_provider_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProcessRequest); i {
+			switch v := v.(*WatchDescriptionRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -933,7 +1615,7 @@ func file_contract_v1_provider_proto_init() {
 			}
 		}
 		file_contract_v1_provider_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*ProcessResponse); i {
+			switch v := v.(*WatchDescriptionResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -945,6 +1627,54 @@ func file_contract_v1_provider_proto_init() {
 			}
 		}
 		file_contract_v1_provider_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DescriptionMethod); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_contract_v1_provider_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubjectInformation); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_contract_v1_provider_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProcessRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_contract_v1_provider_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProcessResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_contract_v1_provider_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*HeaderValue); i {
 			case 0:
 				return &v.state
@@ -962,8 +1692,8 @@ func file_contract_v1_provider_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_contract_v1_provider_proto_rawDesc,
-			NumEnums:      2,
-			NumMessages:   10,
+			NumEnums:      5,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -19,8 +19,9 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	ProviderService_Description_FullMethodName = "/contract.v1.ProviderService/Description"
-	ProviderService_Process_FullMethodName     = "/contract.v1.ProviderService/Process"
+	ProviderService_Description_FullMethodName      = "/contract.v1.ProviderService/Description"
+	ProviderService_WatchDescription_FullMethodName = "/contract.v1.ProviderService/WatchDescription"
+	ProviderService_Process_FullMethodName          = "/contract.v1.ProviderService/Process"
 )
 
 // ProviderServiceClient is the client API for ProviderService service.
@@ -28,6 +29,8 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type ProviderServiceClient interface {
 	Description(ctx context.Context, in *DescriptionRequest, opts ...grpc.CallOption) (*DescriptionResponse, error)
+	// WatchDescription sends current description and keeps stream open while provider is running.
+	WatchDescription(ctx context.Context, in *WatchDescriptionRequest, opts ...grpc.CallOption) (ProviderService_WatchDescriptionClient, error)
 	Process(ctx context.Context, in *ProcessRequest, opts ...grpc.CallOption) (*ProcessResponse, error)
 }
 
@@ -48,6 +51,38 @@ func (c *providerServiceClient) Description(ctx context.Context, in *Description
 	return out, nil
 }
 
+func (c *providerServiceClient) WatchDescription(ctx context.Context, in *WatchDescriptionRequest, opts ...grpc.CallOption) (ProviderService_WatchDescriptionClient, error) {
+	stream, err := c.cc.NewStream(ctx, &ProviderService_ServiceDesc.Streams[0], ProviderService_WatchDescription_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &providerServiceWatchDescriptionClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type ProviderService_WatchDescriptionClient interface {
+	Recv() (*WatchDescriptionResponse, error)
+	grpc.ClientStream
+}
+
+type providerServiceWatchDescriptionClient struct {
+	grpc.ClientStream
+}
+
+func (x *providerServiceWatchDescriptionClient) Recv() (*WatchDescriptionResponse, error) {
+	m := new(WatchDescriptionResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
 func (c *providerServiceClient) Process(ctx context.Context, in *ProcessRequest, opts ...grpc.CallOption) (*ProcessResponse, error) {
 	out := new(ProcessResponse)
 	err := c.cc.Invoke(ctx, ProviderService_Process_FullMethodName, in, out, opts...)
@@ -62,6 +97,8 @@ func (c *providerServiceClient) Process(ctx context.Context, in *ProcessRequest,
 // for forward compatibility
 type ProviderServiceServer interface {
 	Description(context.Context, *DescriptionRequest) (*DescriptionResponse, error)
+	// WatchDescription sends current description and keeps stream open while provider is running.
+	WatchDescription(*WatchDescriptionRequest, ProviderService_WatchDescriptionServer) error
 	Process(context.Context, *ProcessRequest) (*ProcessResponse, error)
 	mustEmbedUnimplementedProviderServiceServer()
 }
@@ -73,6 +110,9 @@ type UnimplementedProviderServiceServer struct {
 func (UnimplementedProviderServiceServer) Description(context.Context, *DescriptionRequest) (*DescriptionResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Description not implemented")
 }
+func (UnimplementedProviderServiceServer) WatchDescription(*WatchDescriptionRequest, ProviderService_WatchDescriptionServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchDescription not implemented")
+}
 func (UnimplementedProviderServiceServer) Process(context.Context, *ProcessRequest) (*ProcessResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Process not implemented")
 }
@@ -107,6 +147,27 @@ func _ProviderService_Description_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProviderService_WatchDescription_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchDescriptionRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(ProviderServiceServer).WatchDescription(m, &providerServiceWatchDescriptionServer{stream})
+}
+
+type ProviderService_WatchDescriptionServer interface {
+	Send(*WatchDescriptionResponse) error
+	grpc.ServerStream
+}
+
+type providerServiceWatchDescriptionServer struct {
+	grpc.ServerStream
+}
+
+func (x *providerServiceWatchDescriptionServer) Send(m *WatchDescriptionResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
 func _ProviderService_Process_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(ProcessRequest)
 	if err := dec(in); err != nil {
@@ -141,6 +202,12 @@ var ProviderService_ServiceDesc = grpc.ServiceDesc{
 			Handler:    _ProviderService_Process_Handler,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchDescription",
+			Handler:       _ProviderService_WatchDescription_Handler,
+			ServerStreams: true,
+		},
+	},
 	Metadata: "contract/v1/provider.proto",
 }
@@ -23,8 +23,8 @@ func main() {
 		Auth0Audience: "<AUTH0 AUDIENCE>",
 		M2MValidation: true,
 		GlobalHandlerSettings: sdk.HandlerSettings{
-			AuditEnabled:           true,
-			RequiredAuthentication: true,
+			AuditEnabled:       true,
+			AuthenticationMode: sdk.AuthenticationModeRequired,
 		},
 	})
 	if err != nil {
@@ -0,0 +1,63 @@
+package sdk
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/identity"
+)
+
+type identityTokenKey struct{}
+
+// IdentityTokenFromContext returns gateway-signed identity token of request that is being processed.
+func IdentityTokenFromContext(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(identityTokenKey{}).(string)
+	return token, ok && token != ""
+}
+
+// AppendIdentityToken adds identity token of request that is being processed to outgoing gRPC metadata,
+// so downstream services can verify on whose behalf they are called.
+func AppendIdentityToken(ctx context.Context) context.Context {
+	token, ok := IdentityTokenFromContext(ctx)
+	if !ok {
+		return ctx
+	}
+
+	return metadata.AppendToOutgoingContext(ctx, identity.MetadataKey, token)
+}
+
+var (
+	errIdentityTokenRequired = errors.New("identity token is required")
+)
+
+// verifyIdentity returns verified claims of identity token from incoming metadata.
+// Nil claims returned for anonymous subjects without token.
+func (s *server) verifyIdentity(ctx context.Context, subject *SubjectInformation) (string, *identity.Claims, error) {
+	var token string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(identity.MetadataKey); len(values) != 0 {
+			token = values[0]
+		}
+	}
+
+	if s.identityVerifier == nil {
+		return token, nil, nil
+	}
+
+	if token == "" {
+		if subject == nil || subject.Anonymous {
+			return "", nil, nil
+		}
+
+		return "", nil, errIdentityTokenRequired
+	}
+
+	claims, err := s.identityVerifier.Verify(ctx, token)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return token, claims, nil
+}
@@ -13,6 +13,7 @@ import (
 	"google.golang.org/grpc/reflection"
 
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/auth"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/identity"
 	provider "github.com/TheUnitedCoders/devpost-auth0-api-gateway/pkg/pb/contract/v1"
 )
 
@@ -20,6 +21,7 @@ import (
 type SDK struct {
 	serverAddress         string
 	tokenParser           tokenParser
+	identityVerifier      identityVerifier
 	m2mValidation         bool
 	globalHandlerSettings HandlerSettings
 	handlers              map[string]Handler
@@ -30,10 +32,17 @@ type SDK struct {
 
 // NewOptions ...
 type NewOptions struct {
-	ServerAddress         string
-	Auth0Domain           string
-	Auth0Audience         string
-	M2MValidation         bool
+	ServerAddress string
+	Auth0Domain   string
+	Auth0Audience string
+	M2MValidation bool
+	// IdentityTokenJWKSURL enables verification of gateway-signed identity tokens
+	// using keys published by gateway admin listener (/.well-known/jwks.json).
+	IdentityTokenJWKSURL string
+	IdentityTokenIssuer  string
+	// IdentityTokenAudience is a name of service in gateway config, required with IdentityTokenJWKSURL,
+	// so tokens signed for other services are rejected.
+	IdentityTokenAudience string
 	GlobalHandlerSettings HandlerSettings
 	Handlers              []Handler
 	ServerCloseTimeout    time.Duration
@@ -69,6 +78,16 @@ func (opts *NewOptions) validate() error {
 		}
 	}
 
+	if opts.IdentityTokenJWKSURL != "" {
+		if opts.IdentityTokenIssuer == "" {
+			return errors.New("identity token issuer is required")
+		}
+
+		if opts.IdentityTokenAudience == "" {
+			return errors.New("identity token audience is required")
+		}
+	}
+
 	if err := opts.GlobalHandlerSettings.validate(); err != nil {
 		return fmt.Errorf("failed to validate handlers settings: %w", err)
 	}
@@ -108,9 +127,19 @@ func New(opts NewOptions) (*SDK, error) {
 		tParser = tParserImpl
 	}
 
+	var iVerifier identityVerifier
+	if opts.IdentityTokenJWKSURL != "" {
+		iVerifier = identity.NewVerifier(identity.VerifierOptions{
+			JWKSURL:  opts.IdentityTokenJWKSURL,
+			Issuer:   opts.IdentityTokenIssuer,
+			Audience: opts.IdentityTokenAudience,
+		})
+	}
+
 	s := &SDK{
 		serverAddress:         opts.ServerAddress,
 		tokenParser:           tParser,
+		identityVerifier:      iVerifier,
 		m2mValidation:         opts.M2MValidation,
 		globalHandlerSettings: opts.GlobalHandlerSettings,
 		handlers:              make(map[string]Handler),
@@ -163,13 +192,16 @@ func (s *SDK) Run(ctx context.Context) error {
 	}
 
 	srv := grpc.NewServer()
+	stopping := make(chan struct{})
 
 	provider.RegisterProviderServiceServer(
 		srv,
 		&server{
-			tokenParser:           s.tokenParser,
-			globalHandlerSettings: s.globalHandlerSettings,
-			handlers:              s.handlers,
+			tokenParser:      s.tokenParser,
+			identityVerifier: s.identityVerifier,
+			handlers:         s.handlers,
+			description:      buildDescription(s.globalHandlerSettings, s.handlers),
+			stopping:         stopping,
 		},
 	)
 	reflection.Register(srv)
@@ -177,6 +209,7 @@ func (s *SDK) Run(ctx context.Context) error {
 	go func() {
 		<-ctx.Done()
 		s.logger.Info("stopping gRPC server")
+		close(stopping)
 
 		sCtx, sCancel := context.WithTimeout(context.Background(), s.serverCloseTimeout)
 		defer sCancel()
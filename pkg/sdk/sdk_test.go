@@ -0,0 +1,137 @@
+package sdk
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	mapset "github.com/deckarep/golang-set/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/identity"
+	provider "github.com/TheUnitedCoders/devpost-auth0-api-gateway/pkg/pb/contract/v1"
+)
+
+func TestNewRequiresIdentityTokenAudience(t *testing.T) {
+	t.Parallel()
+
+	_, err := New(NewOptions{
+		IdentityTokenJWKSURL: "http://gateway:8082/.well-known/jwks.json",
+		IdentityTokenIssuer:  "gateway",
+	})
+	require.ErrorContains(t, err, "identity token audience is required")
+
+	_, err = New(NewOptions{
+		IdentityTokenJWKSURL:  "http://gateway:8082/.well-known/jwks.json",
+		IdentityTokenIssuer:   "gateway",
+		IdentityTokenAudience: "greeting",
+	})
+	require.NoError(t, err)
+}
+
+func TestProcessVerifiesIdentityToken(t *testing.T) {
+	t.Parallel()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	signer, err := identity.NewSigner("gateway", time.Minute, []identity.Key{{ID: "key", PrivateKey: privateKey}}, "key")
+	require.NoError(t, err)
+
+	jwks := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(signer.JWKS()) //nolint:errcheck
+	}))
+	t.Cleanup(jwks.Close)
+
+	var received *SubjectInformation
+
+	srv := &server{
+		identityVerifier: identity.NewVerifier(identity.VerifierOptions{JWKSURL: jwks.URL, Issuer: "gateway", Audience: "greeting"}),
+		handlers: map[string]Handler{
+			"hello": {Method: "hello", ProcessFunc: func(_ context.Context, req *ProcessRequest) (*ProcessResponse, error) {
+				received = req.SubjectInformation
+				return &ProcessResponse{StatusCode: http.StatusOK}, nil
+			}},
+		},
+	}
+
+	subject := &domain.SubjectInformation{ID: "auth0|1", Permissions: mapset.NewThreadUnsafeSet("read")}
+	user := &provider.SubjectInformation{Id: "auth0|1", Permissions: []string{"read"}}
+
+	sign := func(audience string) string {
+		token, err := signer.Sign(audience, subject)
+		require.NoError(t, err)
+
+		return token
+	}
+
+	tests := []struct {
+		name    string
+		subject *provider.SubjectInformation
+		token   string
+		code    codes.Code
+		want    *SubjectInformation
+	}{
+		{name: "valid token", subject: user, token: sign("greeting"), want: &SubjectInformation{ID: "auth0|1", Permissions: []string{"read"}}},
+		{name: "token of another service", subject: user, token: sign("orders"), code: codes.PermissionDenied},
+		{name: "missing token", subject: user, code: codes.PermissionDenied},
+		{name: "anonymous without token", subject: &provider.SubjectInformation{Anonymous: true}, want: &SubjectInformation{Anonymous: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			received = nil
+
+			ctx := context.Background()
+			if tt.token != "" {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(identity.MetadataKey, tt.token))
+			}
+
+			_, err := srv.Process(ctx, &provider.ProcessRequest{ApiMethod: "hello", SubjectInformation: tt.subject})
+			if tt.code != codes.OK {
+				assert.Equal(t, tt.code, status.Code(err))
+				assert.Nil(t, received, "handler must not be called")
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, received)
+		})
+	}
+}
+
+func TestProcessRejectsM2MTokenWithReason(t *testing.T) {
+	t.Parallel()
+
+	srv := &server{tokenParser: fakeTokenParser{}}
+
+	_, err := srv.Process(context.Background(), &provider.ProcessRequest{ApiMethod: "hello"})
+	require.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	details := status.Convert(err).Details()
+	require.Len(t, details, 1)
+
+	info, ok := details[0].(*errdetails.ErrorInfo)
+	require.True(t, ok)
+	assert.Equal(t, domain.M2MTokenRejectedReason, info.GetReason())
+}
+
+type fakeTokenParser struct{}
+
+func (fakeTokenParser) ParseToken(context.Context, string) (*domain.SubjectInformation, error) {
+	return nil, errors.New("invalid token")
+}
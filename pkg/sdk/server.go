@@ -2,15 +2,22 @@ package sdk
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"net/http"
 	"net/url"
+	"slices"
+	"strings"
 
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/domain"
+	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/identity"
 	"github.com/TheUnitedCoders/devpost-auth0-api-gateway/internal/utils/slice"
 	provider "github.com/TheUnitedCoders/devpost-auth0-api-gateway/pkg/pb/contract/v1"
 )
@@ -19,43 +26,97 @@ type tokenParser interface {
 	ParseToken(ctx context.Context, token string) (*domain.SubjectInformation, error)
 }
 
+type identityVerifier interface {
+	Verify(ctx context.Context, token string) (*identity.Claims, error)
+}
+
 type server struct {
 	provider.ProviderServiceServer
-	tokenParser tokenParser
+	tokenParser      tokenParser
+	identityVerifier identityVerifier
 
-	globalHandlerSettings HandlerSettings
-	handlers              map[string]Handler
+	handlers    map[string]Handler
+	description *provider.DescriptionResponse
+	// stopping is closed when server is stopping to finish description watches.
+	stopping chan struct{}
 }
 
 func (s *server) Description(ctx context.Context, _ *provider.DescriptionRequest) (*provider.DescriptionResponse, error) {
 	if !s.validateM2M(ctx) {
-		return nil, status.Error(codes.Unauthenticated, "Failed to validate M2M token")
+		return nil, errM2MTokenRejected()
+	}
+
+	return s.description, nil
+}
+
+func (s *server) WatchDescription(req *provider.WatchDescriptionRequest, stream provider.ProviderService_WatchDescriptionServer) error {
+	if !s.validateM2M(stream.Context()) {
+		return errM2MTokenRejected()
+	}
+
+	resp := &provider.WatchDescriptionResponse{Version: s.description.GetVersion()}
+	if req.GetVersion() != s.description.GetVersion() {
+		resp.Description = s.description
+	}
+
+	if err := stream.Send(resp); err != nil {
+		return err
+	}
+
+	// handlers can't change while server is running, so stream is held open to let gateway
+	// notice restart of provider and fetch description of new version at once.
+	select {
+	case <-stream.Context().Done():
+		return nil
+	case <-s.stopping:
+		return status.Error(codes.Unavailable, "Server is stopping")
 	}
+}
 
-	methods := make([]*provider.DescriptionMethod, 0, len(s.handlers))
-	for _, method := range s.handlers {
+// buildDescription returns description of handlers with methods sorted to have stable version.
+func buildDescription(globalHandlerSettings HandlerSettings, handlers map[string]Handler) *provider.DescriptionResponse {
+	methods := make([]*provider.DescriptionMethod, 0, len(handlers))
+	for _, method := range handlers {
 		methods = append(methods, &provider.DescriptionMethod{
-			Method:                 method.Method,
-			AuditEnabled:           method.AuditEnabled,
-			RequiredAuthentication: method.RequiredAuthentication,
-			RateLimiter:            rateLimiterToProto(method.RateLimiterDescription),
-			RequiredPermissions:    method.RequiredPermissions,
-			AllowedHttpMethods:     slice.ConvertFunc(method.AllowedHTTPMethods, httpMethodToProto),
+			Method:                    method.Method,
+			AuditEnabled:              method.AuditEnabled,
+			RequiredAuthentication:    method.RequiredAuthentication,
+			AuthenticationMode:        authenticationModeToProto(method.AuthenticationMode),
+			CertificateAuthentication: certificateAuthenticationToProto(method.CertificateAuthentication),
+			RateLimiter:               firstRateLimiterToProto(method.rateLimiters()),
+			RateLimiters:              slice.ConvertFunc(method.rateLimiters(), rateLimiterToProto),
+			RequiredPermissions:       method.RequiredPermissions,
+			AllowedHttpMethods:        slice.ConvertFunc(method.AllowedHTTPMethods, httpMethodToProto),
+			Cost:                      requestCostToProto(method.Cost),
+			Priority:                  priorityToProto(method.Priority),
 		})
 	}
 
-	return &provider.DescriptionResponse{
-		AuditEnabled:           s.globalHandlerSettings.AuditEnabled,
-		RequiredAuthentication: s.globalHandlerSettings.RequiredAuthentication,
-		RateLimiter:            rateLimiterToProto(s.globalHandlerSettings.RateLimiterDescription),
-		RequiredPermissions:    s.globalHandlerSettings.RequiredPermissions,
-		Methods:                methods,
-	}, nil
+	slices.SortFunc(methods, func(a, b *provider.DescriptionMethod) int {
+		return strings.Compare(a.GetMethod(), b.GetMethod())
+	})
+
+	description := &provider.DescriptionResponse{
+		AuditEnabled:              globalHandlerSettings.AuditEnabled,
+		RequiredAuthentication:    globalHandlerSettings.RequiredAuthentication,
+		AuthenticationMode:        authenticationModeToProto(globalHandlerSettings.AuthenticationMode),
+		CertificateAuthentication: certificateAuthenticationToProto(globalHandlerSettings.CertificateAuthentication),
+		RateLimiter:               firstRateLimiterToProto(globalHandlerSettings.rateLimiters()),
+		RateLimiters:              slice.ConvertFunc(globalHandlerSettings.rateLimiters(), rateLimiterToProto),
+		RequiredPermissions:       globalHandlerSettings.RequiredPermissions,
+		Methods:                   methods,
+	}
+
+	data, _ := proto.MarshalOptions{Deterministic: true}.Marshal(description) //nolint:errcheck
+	hash := sha256.Sum256(data)
+	description.Version = hex.EncodeToString(hash[:])
+
+	return description
 }
 
 func (s *server) Process(ctx context.Context, req *provider.ProcessRequest) (*provider.ProcessResponse, error) {
 	if !s.validateM2M(ctx) {
-		return nil, status.Error(codes.Unauthenticated, "Failed to validate M2M token")
+		return nil, errM2MTokenRejected()
 	}
 
 	handler, ok := s.handlers[req.GetApiMethod()]
@@ -64,6 +125,18 @@ func (s *server) Process(ctx context.Context, req *provider.ProcessRequest) (*pr
 	}
 
 	queryValues, _ := url.ParseQuery(req.GetQuery()) //nolint:errcheck
+	subjectInformation := subjectInformationFromProto(req.GetSubjectInformation())
+
+	identityToken, identityClaims, err := s.verifyIdentity(ctx, subjectInformation)
+	if err != nil {
+		return nil, status.Errorf(codes.PermissionDenied, "Failed to verify identity token: %s", err)
+	}
+
+	if identityClaims != nil {
+		subjectInformation = subjectInformationFromIdentity(identityClaims)
+	}
+
+	ctx = context.WithValue(ctx, identityTokenKey{}, identityToken)
 
 	resp, err := handler.ProcessFunc(ctx, &ProcessRequest{
 		HTTPMethod:         httpMethodFromProto(req.GetHttpMethod()),
@@ -71,7 +144,10 @@ func (s *server) Process(ctx context.Context, req *provider.ProcessRequest) (*pr
 		Query:              queryValues,
 		Body:               req.GetBody(),
 		Headers:            headersFromProto(req.GetHeaders()),
-		SubjectInformation: subjectInformationFromProto(req.GetSubjectInformation()),
+		SubjectInformation: subjectInformation,
+		IdentityToken:      identityToken,
+		UserToken:          userTokenFromContext(ctx),
+		Priority:           priorityFromProto(req.GetPriority()),
 	})
 	if err != nil {
 		return nil, err
@@ -81,9 +157,23 @@ func (s *server) Process(ctx context.Context, req *provider.ProcessRequest) (*pr
 		Body:       resp.Body,
 		StatusCode: uint32(resp.StatusCode),
 		Headers:    headersToProto(resp.Headers),
+		ActualCost: resp.ActualCost,
 	}, nil
 }
 
+// errM2MTokenRejected returns error with reason that lets gateway tell rejected M2M token
+// from unauthenticated errors of handlers.
+func errM2MTokenRejected() error {
+	st, err := status.New(codes.Unauthenticated, "Failed to validate M2M token").WithDetails(&errdetails.ErrorInfo{
+		Reason: domain.M2MTokenRejectedReason,
+	})
+	if err != nil {
+		return status.Error(codes.Unauthenticated, "Failed to validate M2M token")
+	}
+
+	return st.Err()
+}
+
 func (s *server) validateM2M(ctx context.Context) bool {
 	if s.tokenParser == nil {
 		return true
@@ -136,9 +226,75 @@ func subjectInformationFromProto(info *provider.SubjectInformation) *SubjectInfo
 	return &SubjectInformation{
 		ID:          info.GetId(),
 		Permissions: info.GetPermissions(),
+		Anonymous:   info.GetAnonymous(),
 	}
 }
 
+func subjectInformationFromIdentity(claims *identity.Claims) *SubjectInformation {
+	return &SubjectInformation{
+		ID:          claims.Subject,
+		Permissions: claims.Permissions,
+		Anonymous:   claims.Anonymous,
+		Claims:      claims.TokenClaims,
+	}
+}
+
+func authenticationModeToProto(mode AuthenticationMode) provider.AuthenticationMode {
+	switch mode {
+	case AuthenticationModeNone:
+		return provider.AuthenticationMode_AUTHENTICATION_MODE_NONE
+	case AuthenticationModeOptional:
+		return provider.AuthenticationMode_AUTHENTICATION_MODE_OPTIONAL
+	case AuthenticationModeRequired:
+		return provider.AuthenticationMode_AUTHENTICATION_MODE_REQUIRED
+	}
+
+	return provider.AuthenticationMode_AUTHENTICATION_MODE_UNSPECIFIED
+}
+
+func certificateAuthenticationToProto(certificateAuthentication CertificateAuthentication) provider.CertificateAuthentication {
+	switch certificateAuthentication {
+	case CertificateAuthenticationDisabled:
+		return provider.CertificateAuthentication_CERTIFICATE_AUTHENTICATION_DISABLED
+	case CertificateAuthenticationAccepted:
+		return provider.CertificateAuthentication_CERTIFICATE_AUTHENTICATION_ACCEPTED
+	case CertificateAuthenticationRequired:
+		return provider.CertificateAuthentication_CERTIFICATE_AUTHENTICATION_REQUIRED
+	}
+
+	return provider.CertificateAuthentication_CERTIFICATE_AUTHENTICATION_UNSPECIFIED
+}
+
+func priorityToProto(priority Priority) provider.Priority {
+	switch priority {
+	case PriorityLow:
+		return provider.Priority_PRIORITY_LOW
+	case PriorityNormal:
+		return provider.Priority_PRIORITY_NORMAL
+	case PriorityHigh:
+		return provider.Priority_PRIORITY_HIGH
+	case PriorityCritical:
+		return provider.Priority_PRIORITY_CRITICAL
+	}
+
+	return provider.Priority_PRIORITY_UNSPECIFIED
+}
+
+func priorityFromProto(priority provider.Priority) Priority {
+	switch priority {
+	case provider.Priority_PRIORITY_LOW:
+		return PriorityLow
+	case provider.Priority_PRIORITY_NORMAL:
+		return PriorityNormal
+	case provider.Priority_PRIORITY_HIGH:
+		return PriorityHigh
+	case provider.Priority_PRIORITY_CRITICAL:
+		return PriorityCritical
+	}
+
+	return PriorityUnspecified
+}
+
 func httpMethodFromProto(method provider.HttpMethod) HTTPMethod {
 	switch method {
 	case provider.HttpMethod_HTTP_METHOD_GET:
@@ -173,23 +329,83 @@ func httpMethodToProto(method HTTPMethod) provider.HttpMethod {
 	return provider.HttpMethod_HTTP_METHOD_UNSPECIFIED
 }
 
-func rateLimiterToProto(rateLimiter *RateLimiterDescription) *provider.RateLimiter {
-	if rateLimiter == nil {
+// firstRateLimiterToProto returns first limiter for gateways that don't support list of limiters.
+func firstRateLimiterToProto(rateLimiters []*RateLimiterDescription) *provider.RateLimiter {
+	if len(rateLimiters) == 0 {
 		return nil
 	}
 
-	var by provider.RateLimitBy
-	switch rateLimiter.By {
-	case RateLimitDescriptionByIp:
-		by = provider.RateLimitBy_RATE_LIMIT_BY_IP
-	case RateLimitDescriptionBySubjectId:
-		by = provider.RateLimitBy_RATE_LIMIT_BY_SUBJECT_ID
+	return rateLimiterToProto(rateLimiters[0])
+}
+
+func rateLimiterToProto(rateLimiter *RateLimiterDescription) *provider.RateLimiter {
+	if rateLimiter == nil {
+		return nil
 	}
 
 	return &provider.RateLimiter{
-		By:     by,
+		By:     rateLimitByToProto(rateLimiter.By),
+		Name:   rateLimiter.Name,
+		Keys:   slice.ConvertFunc(rateLimiter.Keys, rateLimitKeyToProto),
 		Limit:  rateLimiter.Rate,
 		Burst:  rateLimiter.Burst,
 		Period: durationpb.New(rateLimiter.Period),
 	}
 }
+
+func requestCostToProto(cost *RequestCost) *provider.RequestCost {
+	if cost == nil {
+		return nil
+	}
+
+	return &provider.RequestCost{
+		Base:             cost.Base,
+		QueryParam:       cost.QueryParam,
+		BodyBytesPerUnit: cost.BodyBytesPerUnit,
+		Max:              cost.Max,
+	}
+}
+
+func rateLimitKeyToProto(key RateLimitKey) *provider.RateLimitKey {
+	return &provider.RateLimitKey{
+		By:   rateLimitByToProto(key.By),
+		Name: key.Name,
+	}
+}
+
+func rateLimitByToProto(by RateLimitDescriptionBy) provider.RateLimitBy {
+	switch by {
+	case RateLimitDescriptionByIp:
+		return provider.RateLimitBy_RATE_LIMIT_BY_IP
+	case RateLimitDescriptionBySubjectId:
+		return provider.RateLimitBy_RATE_LIMIT_BY_SUBJECT_ID
+	case RateLimitDescriptionByClaim:
+		return provider.RateLimitBy_RATE_LIMIT_BY_CLAIM
+	case RateLimitDescriptionByHeader:
+		return provider.RateLimitBy_RATE_LIMIT_BY_HEADER
+	case RateLimitDescriptionByQuery:
+		return provider.RateLimitBy_RATE_LIMIT_BY_QUERY
+	case RateLimitDescriptionByGlobal:
+		return provider.RateLimitBy_RATE_LIMIT_BY_GLOBAL
+	case RateLimitDescriptionByApiKey:
+		return provider.RateLimitBy_RATE_LIMIT_BY_API_KEY
+	case RateLimitDescriptionByComposite:
+		return provider.RateLimitBy_RATE_LIMIT_BY_COMPOSITE
+	}
+
+	return provider.RateLimitBy_RATE_LIMIT_BY_UNSPECIFIED
+}
+
+func userTokenFromContext(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+
+	val := md.Get("x-user-token")
+	if len(val) == 0 {
+		return ""
+	}
+
+	return val[0]
+}
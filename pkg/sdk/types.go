@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 )
 
@@ -18,19 +19,95 @@ type HandlerFunc func(ctx context.Context, req *ProcessRequest) (*ProcessRespons
 // ENUM(unspecified, get, put, post, delete, patch)
 type HTTPMethod uint8
 
+// AuthenticationMode ...
+// ENUM(unspecified, none, optional, required)
+type AuthenticationMode uint8
+
+// CertificateAuthentication ...
+// ENUM(unspecified, disabled, accepted, required)
+type CertificateAuthentication uint8
+
+// Priority of request under overload, gateway serves higher priority first and sheds lower priority earlier.
+// ENUM(unspecified, low, normal, high, critical)
+type Priority uint8
+
 // RateLimitDescriptionBy ...
-// ENUM(ip, subject_id)
+// ENUM(ip, subject_id, claim, header, query, global, api_key, composite)
 type RateLimitDescriptionBy uint8
 
+// RateLimitKey is a part of composite rate limiter key.
+type RateLimitKey struct {
+	By RateLimitDescriptionBy
+	// Name of claim, header or query parameter. Header of API key defaults to X-API-Key.
+	Name string
+}
+
+func (k RateLimitKey) String() string {
+	if k.Name == "" {
+		return k.By.String()
+	}
+
+	return k.By.String() + "=" + k.Name
+}
+
+func (k RateLimitKey) validate() error {
+	if !k.By.IsValid() {
+		return fmt.Errorf("invalid key by %s", k.By)
+	}
+
+	switch k.By {
+	case RateLimitDescriptionByClaim, RateLimitDescriptionByHeader, RateLimitDescriptionByQuery:
+		if k.Name == "" {
+			return fmt.Errorf("name is required for key by %s", k.By)
+		}
+	case RateLimitDescriptionByComposite:
+		return errors.New("composite key can't be nested")
+	}
+
+	return nil
+}
+
 // RateLimiterDescription ...
 type RateLimiterDescription struct {
-	By     RateLimitDescriptionBy
+	By RateLimitDescriptionBy
+	// Name of claim, header or query parameter.
+	Name string
+	// Keys of composite limiter, e.g. subject and claim org_id.
+	Keys   []RateLimitKey
 	Rate   uint64
 	Burst  uint64
 	Period time.Duration
 }
 
+func (r *RateLimiterDescription) keyParts() []RateLimitKey {
+	if r.By == RateLimitDescriptionByComposite {
+		return r.Keys
+	}
+
+	return []RateLimitKey{{By: r.By, Name: r.Name}}
+}
+
+// id must match limiter id of gateway.
+func (r *RateLimiterDescription) id() string {
+	parts := make([]string, 0, len(r.keyParts()))
+	for _, key := range r.keyParts() {
+		parts = append(parts, key.String())
+	}
+
+	return strings.Join(parts, "+") + ":" + r.Period.String()
+}
+
 func (r *RateLimiterDescription) validate() error {
+	if r.By == RateLimitDescriptionByComposite && len(r.Keys) == 0 {
+		return errors.New("keys are required for composite limiter")
+	}
+
+	for _, key := range r.keyParts() {
+		if err := key.validate(); err != nil {
+			return err
+		}
+	}
+
 	if r.Rate == 0 {
 		return errors.New("rate cannot be 0")
 	}
@@ -50,26 +127,83 @@ func (r *RateLimiterDescription) validate() error {
 type HandlerSettings struct {
 	AuditEnabled           bool
 	RateLimiterDescription *RateLimiterDescription
+	// RateLimiterDescriptions are applied together with RateLimiterDescription, e.g. per second and per day limits.
+	// Global limiters are applied together with limiters of method.
+	RateLimiterDescriptions []*RateLimiterDescription
+	// Deprecated: use AuthenticationMode instead.
 	RequiredAuthentication bool
-	RequiredPermissions    []string
+	// AuthenticationMode of handler. Unspecified method mode inherits global mode,
+	// unspecified global mode means optional authentication.
+	AuthenticationMode AuthenticationMode
+	// CertificateAuthentication allows callers to authenticate with mTLS client certificate
+	// mapped to subject by gateway. Unspecified method setting inherits global setting.
+	CertificateAuthentication CertificateAuthentication
+	RequiredPermissions       []string
 }
 
 func (s *HandlerSettings) validate() error {
-	if s.RateLimiterDescription != nil {
-		if err := s.RateLimiterDescription.validate(); err != nil {
+	if !s.AuthenticationMode.IsValid() {
+		return fmt.Errorf("invalid authentication mode %s", s.AuthenticationMode)
+	}
+
+	if !s.CertificateAuthentication.IsValid() {
+		return fmt.Errorf("invalid certificate authentication %s", s.CertificateAuthentication)
+	}
+
+	if s.RequiredAuthentication && s.AuthenticationMode != AuthenticationModeUnspecified &&
+		s.AuthenticationMode != AuthenticationModeRequired {
+		return errors.New("required authentication conflicts with authentication mode")
+	}
+
+	ids := make(map[string]struct{})
+
+	for _, rateLimiter := range s.rateLimiters() {
+		if err := rateLimiter.validate(); err != nil {
 			return fmt.Errorf("invalid rate limiter description: %w", err)
 		}
+
+		// gateway tells limiters apart by key and period.
+		id := rateLimiter.id()
+		if _, exists := ids[id]; exists {
+			return fmt.Errorf("rate limiter %s is declared twice", id)
+		}
+
+		ids[id] = struct{}{}
 	}
 
 	return nil
 }
 
+func (s *HandlerSettings) rateLimiters() []*RateLimiterDescription {
+	if s.RateLimiterDescription == nil {
+		return s.RateLimiterDescriptions
+	}
+
+	return append([]*RateLimiterDescription{s.RateLimiterDescription}, s.RateLimiterDescriptions...)
+}
+
 // Handler ...
 type Handler struct {
 	Method string
 	HandlerSettings
 	AllowedHTTPMethods []HTTPMethod
-	ProcessFunc        HandlerFunc
+	// Cost of request taken from rate limiters, every request costs 1 when nil.
+	Cost *RequestCost
+	// Priority of requests to handler, priority of consumer assigned by gateway may raise it.
+	Priority    Priority
+	ProcessFunc HandlerFunc
+}
+
+// RequestCost is a count of tokens taken by request from rate limiters:
+// Base + value of QueryParam + started BodyBytesPerUnit chunks of body, capped by Max.
+type RequestCost struct {
+	// Base cost, 1 when zero.
+	Base       uint64
+	QueryParam string
+	// BodyBytesPerUnit is disabled when zero.
+	BodyBytesPerUnit uint64
+	// Max cost, unlimited when zero.
+	Max uint64
 }
 
 func (h *Handler) validate() error {
@@ -81,6 +215,10 @@ func (h *Handler) validate() error {
 		return fmt.Errorf("invalid handler settings: %w", err)
 	}
 
+	if !h.Priority.IsValid() {
+		return fmt.Errorf("invalid priority %s", h.Priority)
+	}
+
 	if h.ProcessFunc == nil {
 		return errors.New("process function is required")
 	}
@@ -92,6 +230,11 @@ func (h *Handler) validate() error {
 type SubjectInformation struct {
 	ID          string
 	Permissions []string
+	// Anonymous is true when caller didn't provide token to method with optional authentication
+	// or when method authentication is disabled.
+	Anonymous bool
+	// Claims of original access token, filled only when identity token verification is enabled.
+	Claims map[string]any
 }
 
 // ProcessRequest ...
@@ -102,6 +245,14 @@ type ProcessRequest struct {
 	Body               []byte
 	Headers            http.Header
 	SubjectInformation *SubjectInformation
+	// IdentityToken is a gateway-signed token of subject, use AppendIdentityToken
+	// to propagate it to downstream gRPC calls.
+	IdentityToken string
+	// UserToken is an access token issued on behalf of subject for service audience,
+	// set when token exchange is enabled for service in gateway.
+	UserToken string
+	// Priority of request assigned by gateway, unspecified for older gateways.
+	Priority Priority
 }
 
 // ProcessResponse ...
@@ -109,4 +260,6 @@ type ProcessResponse struct {
 	Body       []byte
 	StatusCode int
 	Headers    http.Header
+	// ActualCost is charged from rate limiters when it exceeds cost of request, e.g. rows of report.
+	ActualCost uint64
 }
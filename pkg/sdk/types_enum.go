@@ -11,6 +11,110 @@ import (
 	"fmt"
 )
 
+const (
+	// AuthenticationModeUnspecified is a AuthenticationMode of type Unspecified.
+	AuthenticationModeUnspecified AuthenticationMode = iota
+	// AuthenticationModeNone is a AuthenticationMode of type None.
+	AuthenticationModeNone
+	// AuthenticationModeOptional is a AuthenticationMode of type Optional.
+	AuthenticationModeOptional
+	// AuthenticationModeRequired is a AuthenticationMode of type Required.
+	AuthenticationModeRequired
+)
+
+var ErrInvalidAuthenticationMode = errors.New("not a valid AuthenticationMode")
+
+const _AuthenticationModeName = "unspecifiednoneoptionalrequired"
+
+var _AuthenticationModeMap = map[AuthenticationMode]string{
+	AuthenticationModeUnspecified: _AuthenticationModeName[0:11],
+	AuthenticationModeNone:        _AuthenticationModeName[11:15],
+	AuthenticationModeOptional:    _AuthenticationModeName[15:23],
+	AuthenticationModeRequired:    _AuthenticationModeName[23:31],
+}
+
+// String implements the Stringer interface.
+func (x AuthenticationMode) String() string {
+	if str, ok := _AuthenticationModeMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("AuthenticationMode(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x AuthenticationMode) IsValid() bool {
+	_, ok := _AuthenticationModeMap[x]
+	return ok
+}
+
+var _AuthenticationModeValue = map[string]AuthenticationMode{
+	_AuthenticationModeName[0:11]:  AuthenticationModeUnspecified,
+	_AuthenticationModeName[11:15]: AuthenticationModeNone,
+	_AuthenticationModeName[15:23]: AuthenticationModeOptional,
+	_AuthenticationModeName[23:31]: AuthenticationModeRequired,
+}
+
+// ParseAuthenticationMode attempts to convert a string to a AuthenticationMode.
+func ParseAuthenticationMode(name string) (AuthenticationMode, error) {
+	if x, ok := _AuthenticationModeValue[name]; ok {
+		return x, nil
+	}
+	return AuthenticationMode(0), fmt.Errorf("%s is %w", name, ErrInvalidAuthenticationMode)
+}
+
+const (
+	// CertificateAuthenticationUnspecified is a CertificateAuthentication of type Unspecified.
+	CertificateAuthenticationUnspecified CertificateAuthentication = iota
+	// CertificateAuthenticationDisabled is a CertificateAuthentication of type Disabled.
+	CertificateAuthenticationDisabled
+	// CertificateAuthenticationAccepted is a CertificateAuthentication of type Accepted.
+	CertificateAuthenticationAccepted
+	// CertificateAuthenticationRequired is a CertificateAuthentication of type Required.
+	CertificateAuthenticationRequired
+)
+
+var ErrInvalidCertificateAuthentication = errors.New("not a valid CertificateAuthentication")
+
+const _CertificateAuthenticationName = "unspecifieddisabledacceptedrequired"
+
+var _CertificateAuthenticationMap = map[CertificateAuthentication]string{
+	CertificateAuthenticationUnspecified: _CertificateAuthenticationName[0:11],
+	CertificateAuthenticationDisabled:    _CertificateAuthenticationName[11:19],
+	CertificateAuthenticationAccepted:    _CertificateAuthenticationName[19:27],
+	CertificateAuthenticationRequired:    _CertificateAuthenticationName[27:35],
+}
+
+// String implements the Stringer interface.
+func (x CertificateAuthentication) String() string {
+	if str, ok := _CertificateAuthenticationMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("CertificateAuthentication(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x CertificateAuthentication) IsValid() bool {
+	_, ok := _CertificateAuthenticationMap[x]
+	return ok
+}
+
+var _CertificateAuthenticationValue = map[string]CertificateAuthentication{
+	_CertificateAuthenticationName[0:11]:  CertificateAuthenticationUnspecified,
+	_CertificateAuthenticationName[11:19]: CertificateAuthenticationDisabled,
+	_CertificateAuthenticationName[19:27]: CertificateAuthenticationAccepted,
+	_CertificateAuthenticationName[27:35]: CertificateAuthenticationRequired,
+}
+
+// ParseCertificateAuthentication attempts to convert a string to a CertificateAuthentication.
+func ParseCertificateAuthentication(name string) (CertificateAuthentication, error) {
+	if x, ok := _CertificateAuthenticationValue[name]; ok {
+		return x, nil
+	}
+	return CertificateAuthentication(0), fmt.Errorf("%s is %w", name, ErrInvalidCertificateAuthentication)
+}
+
 const (
 	// HTTPMethodUnspecified is a HTTPMethod of type Unspecified.
 	HTTPMethodUnspecified HTTPMethod = iota
@@ -71,20 +175,94 @@ func ParseHTTPMethod(name string) (HTTPMethod, error) {
 	return HTTPMethod(0), fmt.Errorf("%s is %w", name, ErrInvalidHTTPMethod)
 }
 
+const (
+	// PriorityUnspecified is a Priority of type Unspecified.
+	PriorityUnspecified Priority = iota
+	// PriorityLow is a Priority of type Low.
+	PriorityLow
+	// PriorityNormal is a Priority of type Normal.
+	PriorityNormal
+	// PriorityHigh is a Priority of type High.
+	PriorityHigh
+	// PriorityCritical is a Priority of type Critical.
+	PriorityCritical
+)
+
+var ErrInvalidPriority = errors.New("not a valid Priority")
+
+const _PriorityName = "unspecifiedlownormalhighcritical"
+
+var _PriorityMap = map[Priority]string{
+	PriorityUnspecified: _PriorityName[0:11],
+	PriorityLow:         _PriorityName[11:14],
+	PriorityNormal:      _PriorityName[14:20],
+	PriorityHigh:        _PriorityName[20:24],
+	PriorityCritical:    _PriorityName[24:32],
+}
+
+// String implements the Stringer interface.
+func (x Priority) String() string {
+	if str, ok := _PriorityMap[x]; ok {
+		return str
+	}
+	return fmt.Sprintf("Priority(%d)", x)
+}
+
+// IsValid provides a quick way to determine if the typed value is
+// part of the allowed enumerated values
+func (x Priority) IsValid() bool {
+	_, ok := _PriorityMap[x]
+	return ok
+}
+
+var _PriorityValue = map[string]Priority{
+	_PriorityName[0:11]:  PriorityUnspecified,
+	_PriorityName[11:14]: PriorityLow,
+	_PriorityName[14:20]: PriorityNormal,
+	_PriorityName[20:24]: PriorityHigh,
+	_PriorityName[24:32]: PriorityCritical,
+}
+
+// ParsePriority attempts to convert a string to a Priority.
+func ParsePriority(name string) (Priority, error) {
+	if x, ok := _PriorityValue[name]; ok {
+		return x, nil
+	}
+	return Priority(0), fmt.Errorf("%s is %w", name, ErrInvalidPriority)
+}
+
 const (
 	// RateLimitDescriptionByIp is a RateLimitDescriptionBy of type Ip.
 	RateLimitDescriptionByIp RateLimitDescriptionBy = iota
 	// RateLimitDescriptionBySubjectId is a RateLimitDescriptionBy of type Subject_id.
 	RateLimitDescriptionBySubjectId
+	// RateLimitDescriptionByClaim is a RateLimitDescriptionBy of type Claim.
+	RateLimitDescriptionByClaim
+	// RateLimitDescriptionByHeader is a RateLimitDescriptionBy of type Header.
+	RateLimitDescriptionByHeader
+	// RateLimitDescriptionByQuery is a RateLimitDescriptionBy of type Query.
+	RateLimitDescriptionByQuery
+	// RateLimitDescriptionByGlobal is a RateLimitDescriptionBy of type Global.
+	RateLimitDescriptionByGlobal
+	// RateLimitDescriptionByApiKey is a RateLimitDescriptionBy of type Api_key.
+	RateLimitDescriptionByApiKey
+	// RateLimitDescriptionByComposite is a RateLimitDescriptionBy of type Composite.
+	RateLimitDescriptionByComposite
 )
 
 var ErrInvalidRateLimitDescriptionBy = errors.New("not a valid RateLimitDescriptionBy")
 
-const _RateLimitDescriptionByName = "ipsubject_id"
+const _RateLimitDescriptionByName = "ipsubject_idclaimheaderqueryglobalapi_keycomposite"
 
 var _RateLimitDescriptionByMap = map[RateLimitDescriptionBy]string{
 	RateLimitDescriptionByIp:        _RateLimitDescriptionByName[0:2],
 	RateLimitDescriptionBySubjectId: _RateLimitDescriptionByName[2:12],
+	RateLimitDescriptionByClaim:     _RateLimitDescriptionByName[12:17],
+	RateLimitDescriptionByHeader:    _RateLimitDescriptionByName[17:23],
+	RateLimitDescriptionByQuery:     _RateLimitDescriptionByName[23:28],
+	RateLimitDescriptionByGlobal:    _RateLimitDescriptionByName[28:34],
+	RateLimitDescriptionByApiKey:    _RateLimitDescriptionByName[34:41],
+	RateLimitDescriptionByComposite: _RateLimitDescriptionByName[41:50],
 }
 
 // String implements the Stringer interface.
@@ -103,8 +281,14 @@ func (x RateLimitDescriptionBy) IsValid() bool {
 }
 
 var _RateLimitDescriptionByValue = map[string]RateLimitDescriptionBy{
-	_RateLimitDescriptionByName[0:2]:  RateLimitDescriptionByIp,
-	_RateLimitDescriptionByName[2:12]: RateLimitDescriptionBySubjectId,
+	_RateLimitDescriptionByName[0:2]:   RateLimitDescriptionByIp,
+	_RateLimitDescriptionByName[2:12]:  RateLimitDescriptionBySubjectId,
+	_RateLimitDescriptionByName[12:17]: RateLimitDescriptionByClaim,
+	_RateLimitDescriptionByName[17:23]: RateLimitDescriptionByHeader,
+	_RateLimitDescriptionByName[23:28]: RateLimitDescriptionByQuery,
+	_RateLimitDescriptionByName[28:34]: RateLimitDescriptionByGlobal,
+	_RateLimitDescriptionByName[34:41]: RateLimitDescriptionByApiKey,
+	_RateLimitDescriptionByName[41:50]: RateLimitDescriptionByComposite,
 }
 
 // ParseRateLimitDescriptionBy attempts to convert a string to a RateLimitDescriptionBy.